@@ -1,9 +1,39 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.9;
 
+// The latest value of an ISC oracle feed (see ISCTypes.sol in @iota/iscmagic).
+struct ISCOracleValue {
+    int64 value;
+    int64 timestamp;
+    uint32 blockIndex;
+    bytes signedData;
+    bytes signature;
+}
+
+interface ISCOracle {
+    function getOracleValue(string memory feed)
+        external
+        view
+        returns (ISCOracleValue memory);
+}
+
 contract ElectricityProvider {
-    // This is a stub. It is assumed that this function would use an oracle in a real-world scenario.
-    function getPrice(uint _period) public pure returns (uint) {        
+    // The ISC magic contract, deployed on every ISC chain.
+    ISCOracle private constant ISC = ISCOracle(0x1074000000000000000000000000000000000000);
+
+    // The name of the oracle feed providing the electricity price, configured
+    // on the committee nodes of the chain.
+    string public constant PRICE_FEED = "electricity_price";
+
+    // Returns the latest electricity price agreed by the committee of the
+    // chain. If the feed is not available yet, a fixed price derived from the
+    // period is returned instead.
+    function getPrice(uint _period) public view returns (uint) {
+        try ISC.getOracleValue(PRICE_FEED) returns (ISCOracleValue memory v) {
+            if (v.value >= 0) {
+                return uint(int(v.value));
+            }
+        } catch {}
         return _period % 100;
     }
 }
@@ -29,6 +29,8 @@ type CommitteeInfoResponse struct {
 	ChainId string `json:"chainId"`
 	// A list of all committee nodes and their peering info.
 	CommitteeNodes []CommitteeNode `json:"committeeNodes"`
+	// The shared BLS public key of the committee (Hex). The encrypted requests are encrypted to it.
+	EncryptionKey string `json:"encryptionKey"`
 	StateAddress string `json:"stateAddress"`
 }
 
@@ -36,13 +38,14 @@ type CommitteeInfoResponse struct {
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewCommitteeInfoResponse(accessNodes []CommitteeNode, active bool, candidateNodes []CommitteeNode, chainId string, committeeNodes []CommitteeNode, stateAddress string) *CommitteeInfoResponse {
+func NewCommitteeInfoResponse(accessNodes []CommitteeNode, active bool, candidateNodes []CommitteeNode, chainId string, committeeNodes []CommitteeNode, encryptionKey string, stateAddress string) *CommitteeInfoResponse {
 	this := CommitteeInfoResponse{}
 	this.AccessNodes = accessNodes
 	this.Active = active
 	this.CandidateNodes = candidateNodes
 	this.ChainId = chainId
 	this.CommitteeNodes = committeeNodes
+	this.EncryptionKey = encryptionKey
 	this.StateAddress = stateAddress
 	return &this
 }
@@ -175,6 +178,30 @@ func (o *CommitteeInfoResponse) SetCommitteeNodes(v []CommitteeNode) {
 	o.CommitteeNodes = v
 }
 
+// GetEncryptionKey returns the EncryptionKey field value
+func (o *CommitteeInfoResponse) GetEncryptionKey() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.EncryptionKey
+}
+
+// GetEncryptionKeyOk returns a tuple with the EncryptionKey field value
+// and a boolean to check if the value has been set.
+func (o *CommitteeInfoResponse) GetEncryptionKeyOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.EncryptionKey, true
+}
+
+// SetEncryptionKey sets field value
+func (o *CommitteeInfoResponse) SetEncryptionKey(v string) {
+	o.EncryptionKey = v
+}
+
 // GetStateAddress returns the StateAddress field value
 func (o *CommitteeInfoResponse) GetStateAddress() string {
 	if o == nil {
@@ -214,6 +241,7 @@ func (o CommitteeInfoResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize["candidateNodes"] = o.CandidateNodes
 	toSerialize["chainId"] = o.ChainId
 	toSerialize["committeeNodes"] = o.CommitteeNodes
+	toSerialize["encryptionKey"] = o.EncryptionKey
 	toSerialize["stateAddress"] = o.StateAddress
 	return toSerialize, nil
 }
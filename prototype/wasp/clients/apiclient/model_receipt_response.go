@@ -20,6 +20,7 @@ var _ MappedNullable = &ReceiptResponse{}
 // ReceiptResponse struct for ReceiptResponse
 type ReceiptResponse struct {
 	BlockIndex uint32 `json:"blockIndex"`
+	ContractFees *ContractFees `json:"contractFees,omitempty"`
 	ErrorMessage *string `json:"errorMessage,omitempty"`
 	// The gas budget (uint64 as string)
 	GasBudget string `json:"gasBudget"`
@@ -84,6 +85,38 @@ func (o *ReceiptResponse) SetBlockIndex(v uint32) {
 	o.BlockIndex = v
 }
 
+// GetContractFees returns the ContractFees field value if set, zero value otherwise.
+func (o *ReceiptResponse) GetContractFees() ContractFees {
+	if o == nil || isNil(o.ContractFees) {
+		var ret ContractFees
+		return ret
+	}
+	return *o.ContractFees
+}
+
+// GetContractFeesOk returns a tuple with the ContractFees field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ReceiptResponse) GetContractFeesOk() (*ContractFees, bool) {
+	if o == nil || isNil(o.ContractFees) {
+		return nil, false
+	}
+	return o.ContractFees, true
+}
+
+// HasContractFees returns a boolean if a field has been set.
+func (o *ReceiptResponse) HasContractFees() bool {
+	if o != nil && !isNil(o.ContractFees) {
+		return true
+	}
+
+	return false
+}
+
+// SetContractFees gets a reference to the given ContractFees and assigns it to the ContractFees field.
+func (o *ReceiptResponse) SetContractFees(v ContractFees) {
+	o.ContractFees = &v
+}
+
 // GetErrorMessage returns the ErrorMessage field value if set, zero value otherwise.
 func (o *ReceiptResponse) GetErrorMessage() string {
 	if o == nil || isNil(o.ErrorMessage) {
@@ -327,6 +360,9 @@ func (o ReceiptResponse) MarshalJSON() ([]byte, error) {
 func (o ReceiptResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["blockIndex"] = o.BlockIndex
+	if !isNil(o.ContractFees) {
+		toSerialize["contractFees"] = o.ContractFees
+	}
 	if !isNil(o.ErrorMessage) {
 		toSerialize["errorMessage"] = o.ErrorMessage
 	}
@@ -47,6 +47,9 @@ type PostRequestParams struct {
 	Allowance                *isc.Assets
 	gasBudget                uint64
 	AutoAdjustStorageDeposit bool
+	// Deadline is the index of the last block, an encrypted request can be
+	// processed in. It is ignored by the other requests.
+	Deadline uint32
 }
 
 func (par *PostRequestParams) GasBudget() uint64 {
@@ -0,0 +1,95 @@
+package chainclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
+
+// DefaultEncryptedRequestDeadline is the number of blocks after the latest
+// one, in which an encrypted request can be processed, if the deadline is not
+// set in the PostRequestParams.
+const DefaultEncryptedRequestDeadline = 3
+
+// CommitteeEncryptionKey fetches the shared BLS public key of the current
+// committee of the chain, to which the encrypted requests are encrypted. The
+// key is only known by the nodes of the committee.
+func (c *Client) CommitteeEncryptionKey(ctx context.Context) (kyber.Point, error) {
+	committeeInfo, _, err := c.WaspClient.ChainsApi.GetCommitteeInfo(ctx, c.ChainID.String()).Execute()
+	if err != nil {
+		return nil, err
+	}
+	if committeeInfo.EncryptionKey == "" {
+		return nil, errors.New("the node is not a member of the committee of the chain")
+	}
+	keyBytes, err := iotago.DecodeHex(committeeInfo.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the encryption key: %w", err)
+	}
+	key := tcrypto.DefaultBLSSuite().Point()
+	if err := key.UnmarshalBinary(keyBytes); err != nil {
+		return nil, fmt.Errorf("cannot decode the encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// PostEncryptedOffLedgerRequest sends an off-ledger request with the call
+// encrypted to the committee of the chain, so that it is revealed only after
+// the request is ordered. The sender, the nonce and the gas budget are visible
+// before that. The request fails, if the committee is rotated before the
+// request is processed. The call is revealed once the request is ordered, even
+// if the block is then not produced, therefore the request is skipped after
+// the deadline (a block index) set in the params. By default, it is
+// DefaultEncryptedRequestDeadline blocks after the latest one.
+func (c *Client) PostEncryptedOffLedgerRequest(ctx context.Context,
+	contractHname isc.Hname,
+	entrypoint isc.Hname,
+	params ...PostRequestParams,
+) (isc.OffLedgerRequest, error) {
+	par := defaultParams(params...)
+	if par.Nonce == 0 {
+		nonce, err := c.ISCNonce(ctx)
+		if err != nil {
+			return nil, err
+		}
+		par.Nonce = nonce
+	}
+	if par.Deadline == 0 {
+		blockInfo, _, err := c.WaspClient.CorecontractsApi.BlocklogGetLatestBlockInfo(ctx, c.ChainID.String()).Execute() //nolint:bodyclose // false positive
+		if err != nil {
+			return nil, err
+		}
+		par.Deadline = blockInfo.BlockIndex + DefaultEncryptedRequestDeadline
+	}
+	encryptionKey, err := c.CommitteeEncryptionKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyEncapsulation, err := tcrypto.NewKeyEncapsulation(tcrypto.DefaultBLSSuite(), encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	req := isc.NewEncryptedOffLedgerRequest(c.ChainID, contractHname, entrypoint, par.Args, par.Nonce, par.GasBudget(), par.Deadline, keyEncapsulation)
+	if par.Allowance != nil {
+		req.WithAllowance(par.Allowance)
+	}
+	signed := req.Sign(c.KeyPair)
+
+	offLedgerRequest := apiclient.OffLedgerRequest{
+		ChainId: c.ChainID.String(),
+		Request: iotago.EncodeHex(signed.Bytes()),
+	}
+	_, err = c.WaspClient.RequestsApi.
+		OffLedger(ctx).
+		OffLedgerRequest(offLedgerRequest).
+		Execute()
+
+	return signed, err
+}
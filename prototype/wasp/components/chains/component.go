@@ -97,6 +97,8 @@ func provide(c *dig.Container) error {
 				deps.NodeConnection,
 				deps.ProcessorsConfig,
 				ParamsValidator.Address,
+				ParamsOracle.Feeds,
+				ParamsOracle.PollInterval,
 				ParamsChains.BroadcastUpToNPeers,
 				ParamsChains.BroadcastInterval,
 				ParamsChains.PullMissingRequestsFromCommittee,
@@ -110,6 +112,13 @@ func provide(c *dig.Container) error {
 				ParamsWAL.LoadToStore,
 				ParamsWAL.Enabled,
 				ParamsWAL.Path,
+				ParamsMempool.WALEnabled,
+				ParamsMempool.WALPath,
+				ParamsMempool.MaxOnLedgerInPool,
+				ParamsMempool.MaxOnLedgerPerAccount,
+				ParamsMempool.MaxOffLedgerInPool,
+				ParamsMempool.MaxOffLedgerPerAccount,
+				ParamsMempool.MaxProposalBytes,
 				ParamsStateManager.BlockCacheMaxSize,
 				ParamsStateManager.BlockCacheBlocksInCacheDuration,
 				ParamsStateManager.BlockCacheBlockCleaningPeriod,
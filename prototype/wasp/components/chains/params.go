@@ -27,10 +27,25 @@ type ParametersWAL struct {
 	Path        string `default:"waspdb/wal" usage:"the path to the \"write-ahead logging\" folder"`
 }
 
+type ParametersMempool struct {
+	WALEnabled             bool   `default:"true" usage:"whether the off-ledger requests in the mempool are persisted, so that they are not lost on node restart"`
+	WALPath                string `default:"waspdb/mempool" usage:"the path to the folder where the off-ledger requests in the mempool are persisted"`
+	MaxOnLedgerInPool      int    `default:"10000" usage:"how many on-ledger requests may be kept in the mempool, the ones above it stay on L1 until the node is restarted; 0 means unlimited"`
+	MaxOnLedgerPerAccount  int    `default:"1000" usage:"how many on-ledger requests of a single sender may be kept in the mempool, the ones above it stay on L1 until the node is restarted; 0 means unlimited"`
+	MaxOffLedgerInPool     int    `default:"10000" usage:"how many off-ledger requests may be kept in the mempool; 0 means unlimited"`
+	MaxOffLedgerPerAccount int    `default:"1000" usage:"how many off-ledger requests of a single sender may be kept in the mempool; 0 means unlimited"`
+	MaxProposalBytes       int    `default:"1048576" usage:"the maximal total size (in bytes) of the requests proposed for a block; 0 means unlimited"`
+}
+
 type ParametersValidator struct {
 	Address string `default:"" usage:"bech32 encoded address to identify the node (as access node on gov contract and to collect validator fee payments)"`
 }
 
+type ParametersOracle struct {
+	Feeds        []string      `default:"" usage:"list of oracle feeds proposed by this node as a committee member, as '<name>=<source>'; the source is an http(s) URL or a file path returning a decimal number"`
+	PollInterval time.Duration `default:"10s" usage:"how often the oracle feed sources are read"`
+}
+
 type ParametersStateManager struct {
 	BlockCacheMaxSize                 int           `default:"1000" usage:"how many blocks may be stored in cache before old ones start being deleted"`
 	BlockCacheBlocksInCacheDuration   time.Duration `default:"1h" usage:"how long should the block stay in block cache before being deleted"`
@@ -53,7 +68,9 @@ type ParametersSnapshotManager struct {
 var (
 	ParamsChains          = &ParametersChains{}
 	ParamsWAL             = &ParametersWAL{}
+	ParamsMempool         = &ParametersMempool{}
 	ParamsValidator       = &ParametersValidator{}
+	ParamsOracle          = &ParametersOracle{}
 	ParamsStateManager    = &ParametersStateManager{}
 	ParamsSnapshotManager = &ParametersSnapshotManager{}
 )
@@ -62,7 +79,9 @@ var params = &app.ComponentParams{
 	Params: map[string]any{
 		"chains":       ParamsChains,
 		"wal":          ParamsWAL,
+		"mempool":      ParamsMempool,
 		"validator":    ParamsValidator,
+		"oracle":       ParamsOracle,
 		"stateManager": ParamsStateManager,
 		"snapshots":    ParamsSnapshotManager,
 	},
@@ -269,6 +269,7 @@ func provide(c *dig.Container) error {
 		}
 
 		webapi.Init(
+			Component.Daemon().ContextStopped(),
 			logger,
 			echoSwagger,
 			deps.AppInfo.Version,
@@ -295,6 +296,8 @@ func provide(c *dig.Container) error {
 			jsonrpc.NewParameters(
 				ParamsWebAPI.Limits.Jsonrpc.MaxBlocksInLogsFilterRange,
 				ParamsWebAPI.Limits.Jsonrpc.MaxLogsInResult,
+				ParamsWebAPI.Limits.Jsonrpc.FilterTimeout,
+				ParamsWebAPI.Limits.Jsonrpc.IndexAddresses,
 			),
 		)
 
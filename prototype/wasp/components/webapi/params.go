@@ -27,8 +27,10 @@ type ParametersWebAPILimits struct {
 }
 
 type ParametersJSONRPC struct {
-	MaxBlocksInLogsFilterRange int `default:"1000" usage:"maximum amount of blocks in eth_getLogs filter range"`
-	MaxLogsInResult            int `default:"10000" usage:"maximum amount of logs in eth_getLogs result"`
+	MaxBlocksInLogsFilterRange int           `default:"1000" usage:"maximum amount of blocks in eth_getLogs filter range"`
+	MaxLogsInResult            int           `default:"10000" usage:"maximum amount of logs in eth_getLogs result"`
+	FilterTimeout              time.Duration `default:"5m" usage:"filters installed with eth_newFilter/eth_newBlockFilter are removed if not polled within this time"`
+	IndexAddresses             bool          `default:"false" usage:"whether to index the transactions of each address, needed by the ots_* endpoints (only archive nodes will create/use it)"`
 }
 
 var ParamsWebAPI = &ParametersWebAPI{
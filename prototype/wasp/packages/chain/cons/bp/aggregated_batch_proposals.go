@@ -23,6 +23,7 @@ type AggregatedBatchProposals struct {
 	decidedBaseAliasOutput *isc.AliasOutputWithID
 	decidedRequestRefs     []*isc.RequestRef
 	aggregatedTime         time.Time
+	aggregatedOracleValues isc.OracleValues
 }
 
 func AggregateBatchProposals(inputs map[gpa.NodeID][]byte, nodeIDs []gpa.NodeID, f int, log *logger.Logger) *AggregatedBatchProposals {
@@ -56,6 +57,7 @@ func AggregateBatchProposals(inputs map[gpa.NodeID][]byte, nodeIDs []gpa.NodeID,
 		decidedBaseAliasOutput: decidedBaseAliasOutput,
 		decidedRequestRefs:     bps.decidedRequestRefs(f, decidedBaseAliasOutput),
 		aggregatedTime:         aggregatedTime,
+		aggregatedOracleValues: bps.aggregatedOracleValues(f),
 	}
 	if abp.decidedBaseAliasOutput == nil || len(abp.decidedRequestRefs) == 0 || abp.aggregatedTime.IsZero() {
 		log.Debugf(
@@ -92,6 +94,13 @@ func (abp *AggregatedBatchProposals) AggregatedTime() time.Time {
 	return abp.aggregatedTime
 }
 
+func (abp *AggregatedBatchProposals) AggregatedOracleValues() isc.OracleValues {
+	if abp.shouldBeSkipped {
+		panic("trying to use aggregated proposal marked to be skipped")
+	}
+	return abp.aggregatedOracleValues
+}
+
 func (abp *AggregatedBatchProposals) ValidatorFeeTarget(randomness hashing.HashValue) isc.AgentID {
 	if abp.shouldBeSkipped {
 		panic("trying to use aggregated proposal marked to be skipped")
@@ -4,6 +4,7 @@
 package bp
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -12,6 +13,12 @@ import (
 	"github.com/iotaledger/wasp/packages/util/rwutil"
 )
 
+// batchProposalVersion is the version of the serialized BatchProposal. It must
+// be incremented whenever a field is added, so that nodes running different
+// versions fail explicitly instead of misinterpreting the proposals.
+//   - 1: added oracleValues.
+const batchProposalVersion byte = 1
+
 type BatchProposal struct {
 	nodeIndex               uint16                 // Just for a double-check.
 	baseAliasOutput         *isc.AliasOutputWithID // Proposed Base AliasOutput to use.
@@ -19,6 +26,7 @@ type BatchProposal struct {
 	timeData                time.Time              // Our view of time.
 	validatorFeeDestination isc.AgentID            // Proposed destination for fees.
 	requestRefs             []*isc.RequestRef      // Requests we propose to include into the execution.
+	oracleValues            isc.OracleValues       // Values of the oracle feeds, as observed by this node.
 }
 
 func NewBatchProposal(
@@ -28,6 +36,7 @@ func NewBatchProposal(
 	timeData time.Time,
 	validatorFeeDestination isc.AgentID,
 	requestRefs []*isc.RequestRef,
+	oracleValues isc.OracleValues,
 ) *BatchProposal {
 	return &BatchProposal{
 		nodeIndex:               nodeIndex,
@@ -36,6 +45,7 @@ func NewBatchProposal(
 		timeData:                timeData,
 		validatorFeeDestination: validatorFeeDestination,
 		requestRefs:             requestRefs,
+		oracleValues:            oracleValues,
 	}
 }
 
@@ -45,6 +55,10 @@ func (b *BatchProposal) Bytes() []byte {
 
 func (b *BatchProposal) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
+	version := rr.ReadByte()
+	if version != batchProposalVersion && rr.Err == nil {
+		return fmt.Errorf("unsupported batch proposal version: %d", version)
+	}
 	b.nodeIndex = rr.ReadUint16()
 	b.baseAliasOutput = new(isc.AliasOutputWithID)
 	rr.Read(b.baseAliasOutput)
@@ -59,11 +73,14 @@ func (b *BatchProposal) Read(r io.Reader) error {
 		rr.ReadN(b.requestRefs[i].ID[:])
 		rr.ReadN(b.requestRefs[i].Hash[:])
 	}
+	b.oracleValues = isc.OracleValues{}
+	rr.Read(&b.oracleValues)
 	return rr.Err
 }
 
 func (b *BatchProposal) Write(w io.Writer) error {
 	ww := rwutil.NewWriter(w)
+	ww.WriteByte(batchProposalVersion)
 	ww.WriteUint16(b.nodeIndex)
 	ww.Write(b.baseAliasOutput)
 	ww.Write(b.dssIndexProposal)
@@ -74,5 +91,6 @@ func (b *BatchProposal) Write(w io.Writer) error {
 		ww.WriteN(b.requestRefs[i].ID[:])
 		ww.WriteN(b.requestRefs[i].Hash[:])
 	}
+	ww.Write(&b.oracleValues)
 	return ww.Err
 }
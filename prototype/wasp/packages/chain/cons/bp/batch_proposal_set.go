@@ -112,6 +112,35 @@ func (bps batchProposalSet) aggregatedTime(f int) time.Time {
 	return ts[proposalCount-f-1] // Max(|acsProposals|-F Lowest) ~= 66 percentile.
 }
 
+// The value of a feed is the median of the values proposed for it, provided it
+// was proposed by at least 2F+1 nodes. Then the value is between the values
+// proposed by fair nodes. Feeds reported by fewer nodes are left out.
+func (bps batchProposalSet) aggregatedOracleValues(f int) isc.OracleValues {
+	valuesByFeed := map[string][]int64{}
+	for _, bp := range bps {
+		if bp.oracleValues.Validate() != nil {
+			continue
+		}
+		for name, value := range bp.oracleValues {
+			valuesByFeed[name] = append(valuesByFeed[name], value)
+		}
+	}
+	aggregated := isc.OracleValues{}
+	for name, values := range valuesByFeed {
+		if len(values) < 2*f+1 {
+			continue
+		}
+		slices.Sort(values)
+		aggregated[name] = values[(len(values)-1)/2]
+	}
+	if len(aggregated) > isc.MaxOracleFeeds {
+		for _, name := range aggregated.Names()[isc.MaxOracleFeeds:] {
+			delete(aggregated, name)
+		}
+	}
+	return aggregated
+}
+
 func (bps batchProposalSet) selectedProposal(aggregatedTime time.Time, randomness hashing.HashValue) gpa.NodeID {
 	peers := make([]gpa.NodeID, 0, len(bps))
 	for nid := range bps {
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/gpa"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -27,7 +28,7 @@ func TestBatchProposal1Serialization(t *testing.T) {
 		})
 	}
 
-	batchProposal1 := NewBatchProposal(10, isc.RandomAliasOutputWithID(), util.NewFixedSizeBitVector(11), time.Now(), isc.NewRandomAgentID(), reqRefs)
+	batchProposal1 := NewBatchProposal(10, isc.RandomAliasOutputWithID(), util.NewFixedSizeBitVector(11), time.Now(), isc.NewRandomAgentID(), reqRefs, isc.OracleValues{"price": 1234, "temp": -5})
 
 	b := rwutil.WriteToBytes(batchProposal1)
 	batchProposal2, err := rwutil.ReadFromBytes(b, new(BatchProposal))
@@ -38,4 +39,25 @@ func TestBatchProposal1Serialization(t *testing.T) {
 	require.Equal(t, batchProposal1.timeData.UnixNano(), batchProposal2.timeData.UnixNano())
 	require.Equal(t, batchProposal1.validatorFeeDestination, batchProposal2.validatorFeeDestination)
 	require.Equal(t, batchProposal1.requestRefs, batchProposal2.requestRefs)
+	require.Equal(t, batchProposal1.oracleValues, batchProposal2.oracleValues)
+
+	// proposals of an unknown version are rejected
+	b[0] = batchProposalVersion + 1
+	_, err = rwutil.ReadFromBytes(b, new(BatchProposal))
+	require.ErrorContains(t, err, "unsupported batch proposal version")
+}
+
+func TestAggregatedOracleValues(t *testing.T) {
+	bps := batchProposalSet{}
+	for i, values := range []isc.OracleValues{
+		{"price": 100, "temp": 7},
+		{"price": 102, "temp": 8},
+		{"price": 1_000_000, "wind": 3}, // Outlier.
+		{"price": 101},
+	} {
+		bps[gpa.NodeID{byte(i)}] = &BatchProposal{oracleValues: values}
+	}
+	// The median is taken for the feeds reported by at least 2F+1 nodes.
+	require.Equal(t, isc.OracleValues{"price": 101}, bps.aggregatedOracleValues(1))
+	require.Equal(t, isc.OracleValues{"price": 101, "temp": 7, "wind": 3}, bps.aggregatedOracleValues(0))
 }
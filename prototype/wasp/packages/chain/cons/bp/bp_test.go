@@ -67,6 +67,7 @@ func TestOffLedgerOrdering(t *testing.T) {
 		time.Now(),
 		isc.NewRandomAgentID(),
 		isc.RequestRefsFromRequests(rs),
+		nil,
 	)
 	bp0.Bytes()
 	abpInputs := map[gpa.NodeID][]byte{
@@ -23,10 +23,13 @@
 // > UPON Reception of ACS output:
 // >     IF result is possible THEN
 // >         Submit agreed NonceIndexes to DSS.
-// >         Send the BLS partial signature.
+// >         Send the BLS partial signature (on the base AO and the oracle values).
 // >     ELSE
 // >         OUTPUT SKIP
-// > UPON Reception of N-2F BLS partial signatures:
+// > UPON Reception of the decided requests from the Mempool:
+// >     Send the decryption shares for the encrypted requests, if any.
+// >     (The calls are revealed here, even if this instance outputs SKIP later.)
+// > UPON Reception of N-2F BLS partial signatures and BLSThreshold decryption shares:
 // >     Start VM.
 // > UPON Reception of VM Result:
 // >     IF result is non-empty THEN
@@ -52,9 +55,11 @@
 package cons
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"encoding/binary"
 	"fmt"
+	"slices"
 	"time"
 
 	"go.dedis.ch/kyber/v3"
@@ -156,6 +161,7 @@ type consImpl struct {
 	subDSS           SyncDSS        // Distributed Schnorr Signature.
 	subACS           SyncACS        // Asynchronous Common Subset.
 	subRND           SyncRND        // Randomness.
+	subDEC           SyncDEC        // Decryption of the encrypted requests.
 	subVM            SyncVM         // Virtual Machine.
 	subTX            SyncTX         // Building final TX.
 	term             *termCondition // To detect, when this instance can be terminated.
@@ -264,6 +270,11 @@ func New(
 		c.uponRNDInputsReady,
 		c.uponRNDSigSharesReady,
 	)
+	c.subDEC = NewSyncDEC(
+		int(dkShare.BLSThreshold()),
+		c.uponDECInputsReady,
+		c.uponDECSharesReady,
+	)
 	c.subVM = NewSyncVM(
 		c.uponVMInputsReceived,
 		c.uponVMOutputReceived,
@@ -325,6 +336,8 @@ func (c *consImpl) Input(input gpa.Input) gpa.OutMessages {
 		return c.subSM.BlockSaved(input.block)
 	case *inputTimeData:
 		return c.subACS.TimeDataReceived(input.timeData)
+	case *inputOracleValues:
+		return c.subACS.OracleValuesReceived(input.values)
 	case *inputVMResult:
 		return c.subVM.VMResultReceived(input.task)
 	}
@@ -337,6 +350,8 @@ func (c *consImpl) Message(msg gpa.Message) gpa.OutMessages {
 	switch msgT := msg.(type) {
 	case *msgBLSPartialSig:
 		return c.subRND.BLSPartialSigReceived(msgT.Sender(), msgT.partialSig)
+	case *msgDecryptionShares:
+		return c.subDEC.DecryptionSharesReceived(msgT.Sender(), msgT.decShares)
 	case *gpa.WrappingMsg:
 		sub, subMsgs, err := c.msgWrapper.DelegateMessage(msgT)
 		if err != nil {
@@ -363,12 +378,13 @@ func (c *consImpl) Output() gpa.Output {
 
 func (c *consImpl) StatusString() string {
 	// We con't include RND here, maybe that's less important, and visible from the VM status.
-	return fmt.Sprintf("{consImpl⟨%v⟩,%v,%v,%v,%v,%v,%v}",
+	return fmt.Sprintf("{consImpl⟨%v⟩,%v,%v,%v,%v,%v,%v,%v}",
 		c.output.Status,
 		c.subSM.String(),
 		c.subMP.String(),
 		c.subDSS.String(),
 		c.subACS.String(),
+		c.subDEC.String(),
 		c.subVM.String(),
 		c.subTX.String(),
 	)
@@ -394,7 +410,7 @@ func (c *consImpl) uponMPRequestsNeeded(requestRefs []*isc.RequestRef) gpa.OutMe
 
 func (c *consImpl) uponMPRequestsReceived(requests []isc.Request) gpa.OutMessages {
 	c.output.NeedMempoolRequests = nil
-	return c.subVM.RequestsReceived(requests)
+	return c.subDEC.RequestsReceived(requests)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -474,7 +490,7 @@ func (c *consImpl) uponDSSOutputReady(signature []byte) gpa.OutMessages {
 ////////////////////////////////////////////////////////////////////////////////
 // ACS
 
-func (c *consImpl) uponACSInputsReceived(baseAliasOutput *isc.AliasOutputWithID, requestRefs []*isc.RequestRef, dssIndexProposal []int, timeData time.Time) gpa.OutMessages {
+func (c *consImpl) uponACSInputsReceived(baseAliasOutput *isc.AliasOutputWithID, requestRefs []*isc.RequestRef, dssIndexProposal []int, timeData time.Time, oracleValues isc.OracleValues) gpa.OutMessages {
 	batchProposal := bp.NewBatchProposal(
 		*c.dkShare.GetIndex(),
 		baseAliasOutput,
@@ -482,6 +498,7 @@ func (c *consImpl) uponACSInputsReceived(baseAliasOutput *isc.AliasOutputWithID,
 		timeData,
 		c.validatorAgentID,
 		requestRefs,
+		oracleValues,
 	)
 	subACS, subMsgs, err := c.msgWrapper.DelegateInput(subsystemTypeACS, 0, batchProposal.Bytes())
 	if err != nil {
@@ -506,11 +523,17 @@ func (c *consImpl) uponACSOutputReceived(outputValues map[gpa.NodeID][]byte) gpa
 	baoID := bao.OutputID()
 	reqs := aggr.DecidedRequestRefs()
 	c.log.Debugf("ACS decision: baseAO=%v, requests=%v", bao, reqs)
+	// The BLS signature is used as a proof of provenance of the oracle values,
+	// if there are any. Otherwise, only the base AO is signed, as before.
+	dataToSign := baoID[:]
+	if oracleValues := aggr.AggregatedOracleValues(); len(oracleValues) > 0 {
+		dataToSign = isc.OracleSigningMessage(baoID, oracleValues)
+	}
 	return gpa.NoMessages().
 		AddAll(c.subMP.RequestsNeeded(reqs)).
 		AddAll(c.subSM.DecidedVirtualStateNeeded(bao)).
 		AddAll(c.subVM.DecidedBatchProposalsReceived(aggr)).
-		AddAll(c.subRND.CanProceed(baoID[:])).
+		AddAll(c.subRND.CanProceed(dataToSign)).
 		AddAll(c.subDSS.DecidedIndexProposalsReceived(aggr.DecidedDSSIndexProposals()))
 }
 
@@ -543,16 +566,103 @@ func (c *consImpl) uponRNDSigSharesReady(dataToSign []byte, partialSigs map[gpa.
 		c.log.Warnf("Cannot reconstruct BLS signature from %v/%v sigShares: %v", len(partialSigs), c.dkShare.GetN(), err)
 		return false, nil // Continue to wait for other sig shares.
 	}
-	return true, c.subVM.RandomnessReceived(hashing.HashDataBlake2b(sig.Signature.Bytes()))
+	return true, c.subVM.RandomnessReceived(hashing.HashDataBlake2b(sig.Signature.Bytes()), sig.Bytes())
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// DEC -- Decryption of the encrypted requests
+
+func (c *consImpl) uponDECInputsReady(encrypted []isc.EncryptedOffLedgerRequest) gpa.OutMessages {
+	decShares := map[isc.RequestID][]byte{}
+	for _, req := range encrypted {
+		decShare, err := c.dkShare.BLSDecryptionShare(req.EncapsulatedKey(), req.EncryptionLabel())
+		if err != nil {
+			// The request will be left encrypted by all the nodes, see uponDECSharesReady.
+			c.log.Warnf("Cannot produce a decryption share for request %v: %v", req.ID(), err)
+			continue
+		}
+		decShares[req.ID()] = decShare
+	}
+	msgs := gpa.NoMessages()
+	for _, nid := range c.nodeIDs {
+		msgs.Add(newMsgDecryptionShares(nid, decShares))
+	}
+	return msgs
+}
+
+// The requests, that can not be decrypted, are passed to the VM encrypted,
+// the VM fails them. All the nodes come to the same decision here: a request
+// is left encrypted if its encapsulated key is malformed or not bound to the
+// request (then no correct node produces a share for it), or if the key
+// recovered from the verified shares (which is unique) does not decrypt it,
+// e.g. because it was encrypted to another committee.
+func (c *consImpl) uponDECSharesReady(
+	requests []isc.Request,
+	encrypted []isc.EncryptedOffLedgerRequest,
+	decShares map[gpa.NodeID]map[isc.RequestID][]byte,
+) (bool, gpa.OutMessages) {
+	threshold := int(c.dkShare.BLSThreshold())
+	decrypted := map[isc.RequestID]isc.Request{}
+	for _, req := range encrypted {
+		reqID := req.ID()
+		if err := tcrypto.VerifyEncapsulatedKey(tcrypto.DefaultBLSSuite(), req.EncapsulatedKey(), req.EncryptionLabel()); err != nil {
+			c.log.Warnf("Request %v will not be decrypted: %v", reqID, err)
+			continue
+		}
+		// Valid shares of different nodes differ, so the duplicates are
+		// dropped to not count a share copied by a faulty node twice.
+		validShares := [][]byte{}
+		for nid, nodeShares := range decShares {
+			decShare, ok := nodeShares[reqID]
+			if !ok || slices.ContainsFunc(validShares, func(s []byte) bool { return bytes.Equal(s, decShare) }) {
+				continue
+			}
+			if err := c.dkShare.BLSVerifyDecryptionShare(req.EncapsulatedKey(), decShare); err != nil {
+				c.log.Warnf("Invalid decryption share for request %v from %v: %v", reqID, nid.ShortString(), err)
+				continue
+			}
+			validShares = append(validShares, decShare)
+			if len(validShares) == threshold {
+				break
+			}
+		}
+		if len(validShares) < threshold {
+			return false, nil // Continue to wait for other decryption shares.
+		}
+		key, err := c.dkShare.BLSRecoverDecryptionKey(validShares)
+		if err != nil {
+			panic(fmt.Errorf("cannot recover the decryption key from verified shares: %w", err))
+		}
+		decryptedReq, err := req.WithDecryptionKey(key)
+		if err != nil {
+			c.log.Warnf("Request %v will not be decrypted: %v", reqID, err)
+			continue
+		}
+		decrypted[reqID] = decryptedReq
+	}
+	result := make([]isc.Request, len(requests))
+	for i, req := range requests {
+		if decryptedReq, ok := decrypted[req.ID()]; ok {
+			result[i] = decryptedReq
+		} else {
+			result[i] = req
+		}
+	}
+	return true, c.subVM.RequestsReceived(result)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // VM
 
-func (c *consImpl) uponVMInputsReceived(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, requests []isc.Request) gpa.OutMessages {
+func (c *consImpl) uponVMInputsReceived(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, signature []byte, requests []isc.Request) gpa.OutMessages {
 	// TODO: chainState state.State is not used for now. That's because VM takes it form the store by itself.
 	// The decided base alias output can be different from that we have proposed!
 	decidedBaseAliasOutput := aggregatedProposals.DecidedBaseAliasOutput()
+	var oracleData *isc.OracleData
+	if oracleValues := aggregatedProposals.AggregatedOracleValues(); len(oracleValues) > 0 {
+		// The signature used for the randomness covers the oracle values as well.
+		oracleData = isc.NewOracleData(decidedBaseAliasOutput.OutputID(), oracleValues, signature)
+	}
 	c.output.NeedVMResult = &vm.VMTask{
 		Processors:           c.processorCache,
 		AnchorOutput:         decidedBaseAliasOutput.GetAliasOutput(),
@@ -562,6 +672,7 @@ func (c *consImpl) uponVMInputsReceived(aggregatedProposals *bp.AggregatedBatchP
 		TimeAssumption:       aggregatedProposals.AggregatedTime(),
 		Entropy:              *randomness,
 		ValidatorFeeTarget:   aggregatedProposals.ValidatorFeeTarget(*randomness),
+		OracleData:           oracleData,
 		EstimateGasMode:      false,
 		EnableGasBurnLogging: false,
 		Log:                  c.log.Named("VM"),
@@ -63,6 +63,12 @@ type StateMgr interface {
 	) <-chan state.Block
 }
 
+// Oracle provides the values of the oracle feeds as observed by this node.
+// It is optional, the node proposes no oracle values if it is not set.
+type Oracle interface {
+	Values() isc.OracleValues
+}
+
 type VM interface {
 	ConsensusRunTask(ctx context.Context, task *vm.VMTask) <-chan *vm.VMTaskResult
 }
@@ -109,6 +115,7 @@ type ConsGr struct {
 	stateMgrDecidedStateAsked   bool
 	stateMgrSaveBlockRespCh     <-chan state.Block
 	stateMgrSaveBlockAsked      bool
+	oracle                      Oracle
 	vm                          VM
 	vmRespCh                    <-chan *vm.VMTaskResult
 	vmAsked                     bool
@@ -132,6 +139,7 @@ func New(
 	procCache *processors.Cache,
 	mempool Mempool,
 	stateMgr StateMgr,
+	oracle Oracle,
 	net peering.NetworkProvider,
 	validatorAgentID isc.AgentID,
 	recoveryTimeout time.Duration,
@@ -159,6 +167,7 @@ func New(
 		printStatusPeriod: printStatusPeriod,
 		mempool:           mempool,
 		stateMgr:          stateMgr,
+		oracle:            oracle,
 		vm:                NewVMAsync(chainMetrics, log),
 		netRecvPipe:       pipe.NewInfinitePipe[*peering.PeerMessageIn](),
 		netPeeringID:      netPeeringID,
@@ -250,6 +259,10 @@ func (cgr *ConsGr) run() { //nolint:gocyclo,funlen
 			printStatusCh = time.After(cgr.printStatusPeriod)
 			cgr.outputCB = inp.outputCB
 			cgr.recoverCB = inp.recoverCB
+			if cgr.oracle != nil {
+				// Provided before the proposal, to be included into the batch proposal.
+				cgr.handleConsInput(cons.NewInputOracleValues(cgr.oracle.Values()))
+			}
 			cgr.handleConsInput(cons.NewInputProposal(inp.baseAliasOutput))
 		case t, ok := <-cgr.inputTimeCh:
 			if !ok {
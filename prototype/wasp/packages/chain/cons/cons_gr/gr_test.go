@@ -122,6 +122,7 @@ func testGrBasic(t *testing.T, n, f int, reliable bool) {
 		nodes[i] = consGR.New(
 			ctx, chainID, chainStore, dkShare, &logIndex, peerIdentities[i],
 			procCache, mempools[i], stateMgrs[i],
+			testOracle{"price": int64(100 + i)},
 			networkProviders[i],
 			accounts.CommonAccount(),
 			1*time.Minute, // RecoverTimeout
@@ -162,6 +163,15 @@ func testGrBasic(t *testing.T, n, f int, reliable bool) {
 	}
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// testOracle
+
+type testOracle isc.OracleValues
+
+func (o testOracle) Values() isc.OracleValues {
+	return isc.OracleValues(o)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // testMempool
 
@@ -26,6 +26,7 @@ import (
 	"github.com/iotaledger/wasp/packages/origin"
 	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/testutil/testchain"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/testutil/testpeers"
@@ -155,6 +156,17 @@ func testConsBasic(t *testing.T, n, f int) {
 		reqRefs = append(reqRefs, isc.RequestRefFromRequest(req))
 	}
 	//
+	// An encrypted off-ledger request, it is decrypted after the batch is decided.
+	committeeDKShare, err := dkShareProviders[0].LoadDKShare(committeeAddress)
+	require.NoError(t, err)
+	keyEncapsulation, err := tcrypto.NewKeyEncapsulation(tcrypto.DefaultBLSSuite(), committeeDKShare.BLSSharedPublic())
+	require.NoError(t, err)
+	encryptedReq := isc.NewEncryptedOffLedgerRequest(
+		chainID, inccounter.Contract.Hname(), inccounter.FuncIncCounter.Hname(), dict.New(), 0, 10_000, 1, keyEncapsulation,
+	).Sign(originator)
+	reqs = append(reqs, encryptedReq)
+	reqRefs = append(reqRefs, isc.RequestRefFromRequest(encryptedReq))
+	//
 	// Construct the nodes.
 	consInstID := []byte{1, 2, 3} // ID of the consensus.
 	chainStates := map[gpa.NodeID]state.Store{}
@@ -190,6 +202,7 @@ func testConsBasic(t *testing.T, n, f int) {
 		require.Equal(t, cons.Running, out.Status)
 		require.NotNil(t, out.NeedMempoolProposal)
 		require.NotNil(t, out.NeedStateMgrStateProposal)
+		tc.WithInput(nid, cons.NewInputOracleValues(isc.OracleValues{"price": 100}))
 		tc.WithInput(nid, cons.NewInputMempoolProposal(reqRefs))
 		tc.WithInput(nid, cons.NewInputStateMgrProposalConfirmed())
 		tc.WithInput(nid, cons.NewInputTimeData(now))
@@ -226,6 +239,18 @@ func testConsBasic(t *testing.T, n, f int) {
 		require.Nil(t, out.NeedMempoolRequests)
 		require.Nil(t, out.NeedStateMgrDecidedState)
 		require.NotNil(t, out.NeedVMResult)
+		require.NotNil(t, out.NeedVMResult.OracleData)
+		require.Equal(t, isc.OracleValues{"price": 100}, out.NeedVMResult.OracleData.Values)
+		require.NoError(t, out.NeedVMResult.OracleData.Verify())
+		decryptedCount := 0
+		for _, req := range out.NeedVMResult.Requests {
+			if decryptedReq, ok := req.(isc.EncryptedOffLedgerRequest); ok {
+				require.True(t, decryptedReq.IsDecrypted())
+				require.Equal(t, inccounter.FuncIncCounter.Hname(), decryptedReq.CallTarget().EntryPoint)
+				decryptedCount++
+			}
+		}
+		require.Equal(t, 1, decryptedCount)
 		out.NeedVMResult.Log = out.NeedVMResult.Log.Desugar().WithOptions(zap.IncreaseLevel(logger.LevelError)).Sugar() // Decrease VM logging.
 		vmResult, err := vmimpl.Run(out.NeedVMResult)
 		require.NoError(t, err)
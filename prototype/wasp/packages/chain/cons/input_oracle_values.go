@@ -0,0 +1,25 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package cons
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/wasp/packages/gpa"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+// The oracle values are optional. They are included in the batch proposal,
+// if received before all the other inputs for the proposal are ready.
+type inputOracleValues struct {
+	values isc.OracleValues
+}
+
+func NewInputOracleValues(values isc.OracleValues) gpa.Input {
+	return &inputOracleValues{values: values}
+}
+
+func (inp *inputOracleValues) String() string {
+	return fmt.Sprintf("{cons.inputOracleValues: %v}", inp.values)
+}
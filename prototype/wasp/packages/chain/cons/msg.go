@@ -10,11 +10,13 @@ import (
 const (
 	msgTypeBLSShare gpa.MessageType = iota
 	msgTypeWrapped
+	msgTypeDecryptionShares
 )
 
 func (c *consImpl) UnmarshalMessage(data []byte) (gpa.Message, error) {
 	return gpa.UnmarshalMessage(data, gpa.Mapper{
-		msgTypeBLSShare: func() gpa.Message { return &msgBLSPartialSig{blsSuite: c.blsSuite} },
+		msgTypeBLSShare:         func() gpa.Message { return &msgBLSPartialSig{blsSuite: c.blsSuite} },
+		msgTypeDecryptionShares: func() gpa.Message { return new(msgDecryptionShares) },
 	}, gpa.Fallback{
 		msgTypeWrapped: c.msgWrapper.UnmarshalMessage,
 	})
@@ -0,0 +1,58 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package cons
+
+import (
+	"bytes"
+	"io"
+	"slices"
+
+	"github.com/iotaledger/wasp/packages/gpa"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// The decryption shares of a node for all the encrypted requests in the decided batch.
+type msgDecryptionShares struct {
+	gpa.BasicMessage
+	decShares map[isc.RequestID][]byte
+}
+
+var _ gpa.Message = new(msgDecryptionShares)
+
+func newMsgDecryptionShares(recipient gpa.NodeID, decShares map[isc.RequestID][]byte) *msgDecryptionShares {
+	return &msgDecryptionShares{
+		BasicMessage: gpa.NewBasicMessage(recipient),
+		decShares:    decShares,
+	}
+}
+
+func (msg *msgDecryptionShares) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msgTypeDecryptionShares.ReadAndVerify(rr)
+	size := rr.ReadSize32()
+	msg.decShares = make(map[isc.RequestID][]byte, size)
+	for i := 0; i < size && rr.Err == nil; i++ {
+		var reqID isc.RequestID
+		rr.Read(&reqID)
+		msg.decShares[reqID] = rr.ReadBytes()
+	}
+	return rr.Err
+}
+
+func (msg *msgDecryptionShares) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	msgTypeDecryptionShares.Write(ww)
+	reqIDs := make([]isc.RequestID, 0, len(msg.decShares))
+	for reqID := range msg.decShares {
+		reqIDs = append(reqIDs, reqID)
+	}
+	slices.SortFunc(reqIDs, func(a, b isc.RequestID) int { return bytes.Compare(a[:], b[:]) })
+	ww.WriteSize32(len(reqIDs))
+	for i := range reqIDs {
+		ww.Write(&reqIDs[i])
+		ww.WriteBytes(msg.decShares[reqIDs[i]])
+	}
+	return ww.Err
+}
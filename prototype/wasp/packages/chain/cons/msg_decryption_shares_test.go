@@ -0,0 +1,33 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package cons
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/gpa"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+func TestMsgDecryptionSharesSerialization(t *testing.T) {
+	decShares := map[isc.RequestID][]byte{}
+	for i := 0; i < 3; i++ {
+		b := make([]byte, 10)
+		_, err := rand.Read(b)
+		require.NoError(t, err)
+		decShares[isc.NewRequestID(iotago.TransactionID(hashing.PseudoRandomHash(nil)), 0)] = b
+	}
+	msg := &msgDecryptionShares{
+		gpa.BasicMessage{},
+		decShares,
+	}
+
+	rwutil.ReadWriteTest(t, msg, new(msgDecryptionShares))
+}
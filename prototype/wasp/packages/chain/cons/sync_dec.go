@@ -0,0 +1,101 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package cons
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/wasp/packages/gpa"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+// SyncDEC waits for the decryption shares of the encrypted requests in the
+// decided batch. The requests are received from the mempool only after the
+// ACS has decided the batch, so the shares are never released earlier.
+//
+// The shares are needed to run the VM, so they are released before the block
+// is produced and committed. If the consensus fails after that (the VM or the
+// TX part outputs SKIP, or the anchor is consumed by another block), the
+// decrypted calls are known, while the requests are left in the mempool, to
+// be ordered again by a later instance. The front-running protection only
+// holds for the instance the request was decided in, the later instances can
+// process the request only up to the deadline set by the sender.
+type SyncDEC interface {
+	RequestsReceived(requests []isc.Request) gpa.OutMessages
+	DecryptionSharesReceived(sender gpa.NodeID, decShares map[isc.RequestID][]byte) gpa.OutMessages
+	String() string
+}
+
+type syncDECImpl struct {
+	blsThreshold     int
+	requests         []isc.Request
+	encrypted        []isc.EncryptedOffLedgerRequest
+	decShares        map[gpa.NodeID]map[isc.RequestID][]byte
+	inputsReadyCB    func(encrypted []isc.EncryptedOffLedgerRequest) gpa.OutMessages
+	decSharesReady   bool
+	decSharesReadyCB func(requests []isc.Request, encrypted []isc.EncryptedOffLedgerRequest, decShares map[gpa.NodeID]map[isc.RequestID][]byte) (bool, gpa.OutMessages)
+}
+
+func NewSyncDEC(
+	blsThreshold int,
+	inputsReadyCB func(encrypted []isc.EncryptedOffLedgerRequest) gpa.OutMessages,
+	decSharesReadyCB func(requests []isc.Request, encrypted []isc.EncryptedOffLedgerRequest, decShares map[gpa.NodeID]map[isc.RequestID][]byte) (bool, gpa.OutMessages),
+) SyncDEC {
+	return &syncDECImpl{
+		blsThreshold:     blsThreshold,
+		decShares:        map[gpa.NodeID]map[isc.RequestID][]byte{},
+		inputsReadyCB:    inputsReadyCB,
+		decSharesReadyCB: decSharesReadyCB,
+	}
+}
+
+func (sub *syncDECImpl) RequestsReceived(requests []isc.Request) gpa.OutMessages {
+	if sub.requests != nil || requests == nil {
+		return nil
+	}
+	sub.requests = requests
+	sub.encrypted = []isc.EncryptedOffLedgerRequest{}
+	for _, req := range requests {
+		if encryptedReq, ok := req.(isc.EncryptedOffLedgerRequest); ok && !encryptedReq.IsDecrypted() {
+			sub.encrypted = append(sub.encrypted, encryptedReq)
+		}
+	}
+	msgs := gpa.NoMessages()
+	if len(sub.encrypted) > 0 {
+		msgs.AddAll(sub.inputsReadyCB(sub.encrypted))
+	}
+	return msgs.AddAll(sub.tryComplete())
+}
+
+func (sub *syncDECImpl) DecryptionSharesReceived(sender gpa.NodeID, decShares map[isc.RequestID][]byte) gpa.OutMessages {
+	if _, ok := sub.decShares[sender]; ok {
+		return nil // Duplicate, ignore it.
+	}
+	sub.decShares[sender] = decShares
+	return sub.tryComplete()
+}
+
+func (sub *syncDECImpl) tryComplete() gpa.OutMessages {
+	if sub.decSharesReady || sub.requests == nil {
+		return nil
+	}
+	if len(sub.encrypted) > 0 && len(sub.decShares) < sub.blsThreshold {
+		return nil
+	}
+	done, msgs := sub.decSharesReadyCB(sub.requests, sub.encrypted, sub.decShares)
+	sub.decSharesReady = done
+	return msgs
+}
+
+// Try to provide useful human-readable compact status.
+func (sub *syncDECImpl) String() string {
+	str := "DEC"
+	if sub.decSharesReady {
+		return str + statusStrOK
+	}
+	if sub.requests == nil {
+		return str + "/WAIT[requests]"
+	}
+	return str + fmt.Sprintf("/WAIT[decShares: %v/%v, encrypted: %v]", len(sub.decShares), sub.blsThreshold, len(sub.encrypted))
+}
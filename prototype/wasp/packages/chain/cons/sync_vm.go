@@ -18,7 +18,7 @@ import (
 type SyncVM interface {
 	DecidedBatchProposalsReceived(aggregatedProposals *bp.AggregatedBatchProposals) gpa.OutMessages
 	DecidedStateReceived(chainState state.State) gpa.OutMessages
-	RandomnessReceived(randomness hashing.HashValue) gpa.OutMessages
+	RandomnessReceived(randomness hashing.HashValue, signature []byte) gpa.OutMessages
 	RequestsReceived(requests []isc.Request) gpa.OutMessages
 	VMResultReceived(vmResult *vm.VMTaskResult) gpa.OutMessages
 	String() string
@@ -28,15 +28,16 @@ type syncVMImpl struct {
 	aggregatedProposals *bp.AggregatedBatchProposals
 	chainState          state.State
 	randomness          *hashing.HashValue
+	signature           []byte // The BLS signature, the randomness is derived from.
 	requests            []isc.Request
 	inputsReady         bool
-	inputsReadyCB       func(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, requests []isc.Request) gpa.OutMessages
+	inputsReadyCB       func(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, signature []byte, requests []isc.Request) gpa.OutMessages
 	outputReady         bool
 	outputReadyCB       func(output *vm.VMTaskResult) gpa.OutMessages
 }
 
 func NewSyncVM(
-	inputsReadyCB func(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, requests []isc.Request) gpa.OutMessages,
+	inputsReadyCB func(aggregatedProposals *bp.AggregatedBatchProposals, chainState state.State, randomness *hashing.HashValue, signature []byte, requests []isc.Request) gpa.OutMessages,
 	outputReadyCB func(output *vm.VMTaskResult) gpa.OutMessages,
 ) SyncVM {
 	return &syncVMImpl{inputsReadyCB: inputsReadyCB, outputReadyCB: outputReadyCB}
@@ -58,11 +59,12 @@ func (sub *syncVMImpl) DecidedStateReceived(chainState state.State) gpa.OutMessa
 	return sub.tryCompleteInputs()
 }
 
-func (sub *syncVMImpl) RandomnessReceived(randomness hashing.HashValue) gpa.OutMessages {
+func (sub *syncVMImpl) RandomnessReceived(randomness hashing.HashValue, signature []byte) gpa.OutMessages {
 	if sub.randomness != nil {
 		return nil
 	}
 	sub.randomness = &randomness
+	sub.signature = signature
 	return sub.tryCompleteInputs()
 }
 
@@ -79,7 +81,7 @@ func (sub *syncVMImpl) tryCompleteInputs() gpa.OutMessages {
 		return nil
 	}
 	sub.inputsReady = true
-	return sub.inputsReadyCB(sub.aggregatedProposals, sub.chainState, sub.randomness, sub.requests)
+	return sub.inputsReadyCB(sub.aggregatedProposals, sub.chainState, sub.randomness, sub.signature, sub.requests)
 }
 
 func (sub *syncVMImpl) VMResultReceived(vmResult *vm.VMTaskResult) gpa.OutMessages {
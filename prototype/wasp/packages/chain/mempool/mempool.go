@@ -36,15 +36,35 @@
 // to the proposal based on a tangle time. The tangle time is received from the
 // L1 with the milestones.
 //
-// NOTE: A node looses its off-ledger requests on restart. The on-ledger requests
-// will be added back to the mempool by reading them from the L1 node.
+// The off-ledger requests are persisted in the OffLedgerWAL while they are in the
+// mempool. The WAL is written by a separate goroutine, in batches. On restart
+// they are read back and re-added to the mempool when the first chain head is
+// received, if they are still valid in that state. The on-ledger requests will
+// be added back to the mempool by reading them from the L1 node.
 //
-// TODO: Propose subset of the requests. That's for the next release.
+// The size of the off-ledger pool is bounded (see Parameters), the requests
+// paying the lowest tip (or the newest ones, if the tip is the same) are
+// evicted when a limit is exceeded. The on-ledger pool is bounded as well, but
+// its requests are never evicted, as they hold funds on L1: a new on-ledger
+// request above a limit is refused instead. It stays unconsumed on L1 and is
+// read again when the chain state is synced with L1 (e.g. on node restart).
+// The off-ledger requests are proposed ordered by the tip they pay (ISC tip or
+// EVM priority fee), while keeping the nonce order of the requests of each
+// sender.
+//
+// A proposal contains only the subset of the ready requests that is expected
+// to fit into a block: their gas is estimated from the declared gas budget, or
+// from the gas burned by a simulation of the request (see GasEstimated), or by
+// the previous requests with the same call target, and it must fit the
+// MaxGasPerBlock of the chain. If the estimate undershoots, the VM skips the
+// requests not fitting the block, they are proposed again later. The size of
+// the proposed requests is bounded by MaxProposalBytes as well.
 package mempool
 
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/samber/lo"
@@ -58,12 +78,14 @@ import (
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/util/pipe"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 )
 
 const (
@@ -105,6 +127,57 @@ type Mempool interface {
 	// These nodes should be used to disseminate the off-ledger requests.
 	ServerNodesUpdated(committeePubKeys []*cryptolib.PublicKey, serverNodePubKeys []*cryptolib.PublicKey)
 	AccessNodesUpdated(committeePubKeys []*cryptolib.PublicKey, accessNodePubKeys []*cryptolib.PublicKey)
+	// Returns a snapshot of the off-ledger requests currently in the pool,
+	// grouped by the sender account. Used for inspecting the mempool only.
+	OffLedgerRequestsAsync(ctx context.Context) <-chan []*AccountRequests
+	// Returns a page of the requests currently in the pools, in the order they
+	// were added: at most limit requests following the one with the sequence
+	// number after (0 for the first page). Used for inspecting the mempool by
+	// the node operators.
+	RequestsAsync(ctx context.Context, after uint64, limit int) <-chan *RequestsPage
+	// Removes the request from all the pools. Used by the node operators to
+	// drop requests manually. Responds with false, if there was no such request.
+	RemoveRequestAsync(ctx context.Context, requestID isc.RequestID) <-chan bool
+	// Invoked when the gas of a request was estimated by simulating it (e.g.
+	// on the estimate gas API). The gas burned is used to estimate the gas of
+	// that request and of the requests with the same call target, when they
+	// are proposed.
+	GasEstimated(request isc.Request, gasBurned uint64)
+}
+
+const (
+	PoolOnLedger   = "onLedger"
+	PoolOffLedger  = "offLedger"
+	PoolTimeLocked = "timeLocked"
+)
+
+// A request in one of the pools of the mempool: PoolOnLedger, PoolOffLedger
+// or PoolTimeLocked. The Timestamp is the time the request was added to the
+// pool, except for the time-locked requests, for which it is the time, when
+// the request will be unlocked. The Seq is the position of the request in
+// the RequestIndex.
+type PoolRequest struct {
+	Request   isc.Request
+	Pool      string
+	Timestamp time.Time
+	Seq       uint64
+}
+
+// A page of the requests in the mempool, along with the number of requests
+// in each of the pools.
+type RequestsPage struct {
+	Requests  []*PoolRequest
+	PoolSizes map[string]int
+}
+
+// Off-ledger requests of a single sender account. Pending requests have
+// consecutive nonces starting at the account nonce, so they can be proposed
+// in the next batch. Queued requests are waiting for a missing nonce.
+// Both lists are ordered by nonce.
+type AccountRequests struct {
+	Account isc.AgentID
+	Pending []isc.OffLedgerRequest
+	Queued  []isc.OffLedgerRequest
 }
 
 type RequestPool[V isc.Request] interface {
@@ -129,6 +202,12 @@ type mempoolImpl struct {
 	timePool                       TimePool
 	onLedgerPool                   RequestPool[isc.OnLedgerRequest]
 	offLedgerPool                  *TypedPoolByNonce[isc.OffLedgerRequest]
+	requestIndex                   *RequestIndex
+	offLedgerWAL                   *offLedgerWALWriter
+	offLedgerRestored              []isc.OffLedgerRequest // Read from the WAL, waiting for the chain head.
+	params                         Parameters
+	gasEstimates                   map[isc.CallTarget]uint64
+	requestGasEstimates            map[requestGasKey]uint64
 	distSync                       gpa.GPA
 	chainHeadAO                    *isc.AliasOutputWithID
 	chainHeadState                 state.State
@@ -145,6 +224,10 @@ type mempoolImpl struct {
 	reqReceiveOffLedgerRequestPipe pipe.Pipe[isc.OffLedgerRequest]
 	reqTangleTimeUpdatedPipe       pipe.Pipe[time.Time]
 	reqTrackNewChainHeadPipe       pipe.Pipe[*reqTrackNewChainHead]
+	reqOffLedgerRequestsPipe       pipe.Pipe[*reqOffLedgerRequests]
+	reqRequestsPipe                pipe.Pipe[*reqRequests]
+	reqRemoveRequestPipe           pipe.Pipe[*reqRemoveRequest]
+	reqGasEstimatedPipe            pipe.Pipe[*reqGasEstimated]
 	netRecvPipe                    pipe.Pipe[*peering.PeerMessageIn]
 	netPeeringID                   peering.PeeringID
 	netPeerPubs                    map[gpa.NodeID]*cryptolib.PublicKey
@@ -196,11 +279,36 @@ type reqTrackNewChainHead struct {
 	responseCh chan<- bool // only for tests, shouldn't be used in the chain package
 }
 
+type reqOffLedgerRequests struct {
+	ctx        context.Context
+	responseCh chan<- []*AccountRequests
+}
+
+type reqRequests struct {
+	ctx        context.Context
+	after      uint64
+	limit      int
+	responseCh chan<- *RequestsPage
+}
+
+type reqRemoveRequest struct {
+	ctx        context.Context
+	requestID  isc.RequestID
+	responseCh chan<- bool
+}
+
+type reqGasEstimated struct {
+	request   isc.Request
+	gasBurned uint64
+}
+
 func New(
 	ctx context.Context,
 	chainID isc.ChainID,
 	nodeIdentity *cryptolib.KeyPair,
 	net peering.NetworkProvider,
+	offLedgerWAL OffLedgerWAL,
+	params Parameters,
 	log *logger.Logger,
 	metrics *metrics.ChainMempoolMetrics,
 	pipeMetrics *metrics.ChainPipeMetrics,
@@ -208,12 +316,19 @@ func New(
 ) Mempool {
 	netPeeringID := peering.HashPeeringIDFromBytes(chainID.Bytes(), []byte("Mempool")) // ChainID × Mempool
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	requestIndex := NewRequestIndex()
 	mpi := &mempoolImpl{
 		chainID:                        chainID,
 		tangleTime:                     time.Time{},
-		timePool:                       NewTimePool(metrics.SetTimePoolSize, log.Named("TIM")),
-		onLedgerPool:                   NewTypedPool[isc.OnLedgerRequest](waitReq, metrics.SetOnLedgerPoolSize, metrics.SetOnLedgerReqTime, log.Named("ONL")),
-		offLedgerPool:                  NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.Named("OFF")),
+		timePool:                       NewTimePool(requestIndex.ForPool(PoolTimeLocked), metrics.SetTimePoolSize, log.Named("TIM")),
+		onLedgerPool:                   nil, // Set bellow.
+		offLedgerPool:                  nil, // Set bellow.
+		requestIndex:                   requestIndex,
+		offLedgerWAL:                   newOffLedgerWALWriter(ctx, offLedgerWAL, log.Named("WAL")),
+		offLedgerRestored:              []isc.OffLedgerRequest{},
+		params:                         params,
+		gasEstimates:                   map[isc.CallTarget]uint64{},
+		requestGasEstimates:            map[requestGasKey]uint64{},
 		chainHeadAO:                    nil,
 		serverNodesUpdatedPipe:         pipe.NewInfinitePipe[*reqServerNodesUpdated](),
 		serverNodes:                    []*cryptolib.PublicKey{},
@@ -228,6 +343,10 @@ func New(
 		reqReceiveOffLedgerRequestPipe: pipe.NewInfinitePipe[isc.OffLedgerRequest](),
 		reqTangleTimeUpdatedPipe:       pipe.NewInfinitePipe[time.Time](),
 		reqTrackNewChainHeadPipe:       pipe.NewInfinitePipe[*reqTrackNewChainHead](),
+		reqOffLedgerRequestsPipe:       pipe.NewInfinitePipe[*reqOffLedgerRequests](),
+		reqRequestsPipe:                pipe.NewInfinitePipe[*reqRequests](),
+		reqRemoveRequestPipe:           pipe.NewInfinitePipe[*reqRemoveRequest](),
+		reqGasEstimatedPipe:            pipe.NewInfinitePipe[*reqGasEstimated](),
 		netRecvPipe:                    pipe.NewInfinitePipe[*peering.PeerMessageIn](),
 		netPeeringID:                   netPeeringID,
 		netPeerPubs:                    map[gpa.NodeID]*cryptolib.PublicKey{},
@@ -237,14 +356,47 @@ func New(
 		listener:                       listener,
 	}
 
+	mpi.onLedgerPool = NewTypedPool[isc.OnLedgerRequest](
+		waitReq,
+		requestIndex.ForPool(PoolOnLedger),
+		params.MaxOnLedgerInPool,
+		params.MaxOnLedgerPerAccount,
+		metrics.SetOnLedgerPoolSize,
+		metrics.SetOnLedgerReqTime,
+		mpi.onLedgerRefused,
+		log.Named("ONL"),
+	)
+	mpi.offLedgerPool = NewTypedPoolByNonce[isc.OffLedgerRequest](
+		waitReq,
+		requestIndex.ForPool(PoolOffLedger),
+		params.MaxOffLedgerInPool,
+		params.MaxOffLedgerPerAccount,
+		metrics.SetOffLedgerPoolSize,
+		metrics.SetOffLedgerReqTime,
+		mpi.offLedgerEvicted,
+		log.Named("OFF"),
+	)
+
+	if err := offLedgerWAL.ReadAll(func(request isc.OffLedgerRequest) {
+		mpi.offLedgerRestored = append(mpi.offLedgerRestored, request)
+	}); err != nil {
+		log.Warnf("Cannot read the off-ledger requests from the WAL: %v", err)
+	}
+	log.Infof("Read %v off-ledger requests from the WAL", len(mpi.offLedgerRestored))
+
 	pipeMetrics.TrackPipeLen("mp-serverNodesUpdatedPipe", mpi.serverNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-accessNodesUpdatedPipe", mpi.accessNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqConsensusProposalPipe", mpi.reqConsensusProposalPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqConsensusRequestsPipe", mpi.reqConsensusRequestsPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOnLedgerRequestPipe", mpi.reqReceiveOnLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOffLedgerRequestPipe", mpi.reqReceiveOffLedgerRequestPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-offLedgerWALPipe", mpi.offLedgerWAL.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTangleTimeUpdatedPipe", mpi.reqTangleTimeUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTrackNewChainHeadPipe", mpi.reqTrackNewChainHeadPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqOffLedgerRequestsPipe", mpi.reqOffLedgerRequestsPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqRequestsPipe", mpi.reqRequestsPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqRemoveRequestPipe", mpi.reqRemoveRequestPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqGasEstimatedPipe", mpi.reqGasEstimatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-netRecvPipe", mpi.netRecvPipe.Len)
 
 	mpi.distSync = distsync.New(
@@ -325,6 +477,40 @@ func (mpi *mempoolImpl) ConsensusRequestsAsync(ctx context.Context, requestRefs
 	return res
 }
 
+func (mpi *mempoolImpl) OffLedgerRequestsAsync(ctx context.Context) <-chan []*AccountRequests {
+	res := make(chan []*AccountRequests, 1)
+	mpi.reqOffLedgerRequestsPipe.In() <- &reqOffLedgerRequests{
+		ctx:        ctx,
+		responseCh: res,
+	}
+	return res
+}
+
+func (mpi *mempoolImpl) RequestsAsync(ctx context.Context, after uint64, limit int) <-chan *RequestsPage {
+	res := make(chan *RequestsPage, 1)
+	mpi.reqRequestsPipe.In() <- &reqRequests{
+		ctx:        ctx,
+		after:      after,
+		limit:      limit,
+		responseCh: res,
+	}
+	return res
+}
+
+func (mpi *mempoolImpl) RemoveRequestAsync(ctx context.Context, requestID isc.RequestID) <-chan bool {
+	res := make(chan bool, 1)
+	mpi.reqRemoveRequestPipe.In() <- &reqRemoveRequest{
+		ctx:        ctx,
+		requestID:  requestID,
+		responseCh: res,
+	}
+	return res
+}
+
+func (mpi *mempoolImpl) GasEstimated(request isc.Request, gasBurned uint64) {
+	mpi.reqGasEstimatedPipe.In() <- &reqGasEstimated{request: request, gasBurned: gasBurned}
+}
+
 func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc) { //nolint:gocyclo
 	serverNodesUpdatedPipeOutCh := mpi.serverNodesUpdatedPipe.Out()
 	accessNodesUpdatedPipeOutCh := mpi.accessNodesUpdatedPipe.Out()
@@ -334,6 +520,10 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 	reqReceiveOffLedgerRequestPipeOutCh := mpi.reqReceiveOffLedgerRequestPipe.Out()
 	reqTangleTimeUpdatedPipeOutCh := mpi.reqTangleTimeUpdatedPipe.Out()
 	reqTrackNewChainHeadPipeOutCh := mpi.reqTrackNewChainHeadPipe.Out()
+	reqOffLedgerRequestsPipeOutCh := mpi.reqOffLedgerRequestsPipe.Out()
+	reqRequestsPipeOutCh := mpi.reqRequestsPipe.Out()
+	reqRemoveRequestPipeOutCh := mpi.reqRemoveRequestPipe.Out()
+	reqGasEstimatedPipeOutCh := mpi.reqGasEstimatedPipe.Out()
 	netRecvPipeOutCh := mpi.netRecvPipe.Out()
 	debugTicker := time.NewTicker(distShareDebugTick)
 	timeTicker := time.NewTicker(distShareTimeTick)
@@ -388,6 +578,30 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 				break
 			}
 			mpi.handleTrackNewChainHead(recv)
+		case recv, ok := <-reqOffLedgerRequestsPipeOutCh:
+			if !ok {
+				reqOffLedgerRequestsPipeOutCh = nil
+				break
+			}
+			mpi.handleOffLedgerRequests(recv)
+		case recv, ok := <-reqRequestsPipeOutCh:
+			if !ok {
+				reqRequestsPipeOutCh = nil
+				break
+			}
+			mpi.handleRequests(recv)
+		case recv, ok := <-reqRemoveRequestPipeOutCh:
+			if !ok {
+				reqRemoveRequestPipeOutCh = nil
+				break
+			}
+			mpi.handleRemoveRequest(recv)
+		case recv, ok := <-reqGasEstimatedPipeOutCh:
+			if !ok {
+				reqGasEstimatedPipeOutCh = nil
+				break
+			}
+			mpi.rememberGasEstimated(recv.request, recv.gasBurned)
 		case recv, ok := <-netRecvPipeOutCh:
 			if !ok {
 				netRecvPipeOutCh = nil
@@ -409,6 +623,10 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 			// mpi.reqReceiveOffLedgerRequestPipe.Close()
 			// mpi.reqTangleTimeUpdatedPipe.Close()
 			// mpi.reqTrackNewChainHeadPipe.Close()
+			// mpi.reqOffLedgerRequestsPipe.Close()
+			// mpi.reqRequestsPipe.Close()
+			// mpi.reqRemoveRequestPipe.Close()
+			// mpi.reqGasEstimatedPipe.Close()
 			// mpi.netRecvPipe.Close()
 			debugTicker.Stop()
 			timeTicker.Stop()
@@ -467,12 +685,30 @@ func (mpi *mempoolImpl) shouldAddOffledgerRequest(req isc.OffLedgerRequest) erro
 	if err := req.VerifySignature(); err != nil {
 		return fmt.Errorf("invalid signature")
 	}
+	if encryptedReq, ok := req.(isc.EncryptedOffLedgerRequest); ok && !encryptedReq.IsDecrypted() {
+		// Otherwise the committee would not be able to agree on its decryption.
+		if err := tcrypto.VerifyEncapsulatedKey(tcrypto.DefaultBLSSuite(), encryptedReq.EncapsulatedKey(), encryptedReq.EncryptionLabel()); err != nil {
+			return fmt.Errorf("invalid encrypted request: %w", err)
+		}
+	}
 	if mpi.offLedgerPool.Has(isc.RequestRefFromRequest(req)) {
 		return fmt.Errorf("already in mempool")
 	}
 	if mpi.chainHeadState == nil {
 		return fmt.Errorf("chainHeadState is nil")
 	}
+	if encryptedReq, ok := req.(isc.EncryptedOffLedgerRequest); ok {
+		if deadlinePassed(encryptedReq, mpi.chainHeadState) {
+			return fmt.Errorf("deadline passed: %d", encryptedReq.Deadline())
+		}
+		// The key of another request would be revealed along with it.
+		if mpi.requestIndex.HasEncapsulatedKey(encryptedReq.EncapsulatedKey()) {
+			return fmt.Errorf("encapsulated key already in mempool")
+		}
+		if used, err := blocklog.IsEncapsulatedKeyUsed(mpi.chainHeadState, encryptedReq.EncapsulatedKey()); err == nil && used {
+			return fmt.Errorf("encapsulated key already used")
+		}
+	}
 
 	accountNonce := mpi.nonce(req.SenderAccount())
 	if req.Nonce() < accountNonce {
@@ -486,19 +722,92 @@ func (mpi *mempoolImpl) shouldAddOffledgerRequest(req isc.OffLedgerRequest) erro
 		governanceState := governance.NewStateAccess(mpi.chainHeadState)
 		chainOwner := governanceState.ChainOwnerID()
 		isGovRequest := req.SenderAccount().Equals(chainOwner) && req.CallTarget().Contract == governance.Contract.Hname()
-		if !isGovRequest {
+		if !isGovRequest && !mpi.isGasSponsored(req) {
 			return fmt.Errorf("no funds on chain")
 		}
 	}
 	return nil
 }
 
+// isGasSponsored checks, if the gas fee of the request would be paid by a
+// sponsor, the same way the VM decides it: the sponsor must still be able to
+// pay at least the minimum fee, within its allowance for the sender.
+func (mpi *mempoolImpl) isGasSponsored(req isc.OffLedgerRequest) bool {
+	sender := req.SenderAccount()
+	sponsorshipState := sponsorship.NewStateAccess(mpi.chainHeadState)
+	s := sponsorshipState.Find(sender, isc.RequestTargetAgentID(req, mpi.chainID))
+	if s == nil || s.Sponsor.Equals(sender) {
+		return false
+	}
+	remaining := sponsorshipState.Remaining(s, sender, mpi.chainHeadState.Timestamp())
+	balance := accounts.NewStateAccess(mpi.chainHeadState).BaseTokensBalance(s.Sponsor, mpi.chainID)
+	gasFeePolicy := governance.NewStateAccess(mpi.chainHeadState).ChainInfo(mpi.chainID).GasFeePolicy
+	return gasFeePolicy.IsEnoughForMinimumFee(min(remaining, balance))
+}
+
 func (mpi *mempoolImpl) addOffledger(request isc.OffLedgerRequest) {
+	mpi.writeOffLedgerWAL(request)
 	mpi.offLedgerPool.Add(request)
 	mpi.metrics.IncRequestsReceived(request)
 	mpi.log.Debugf("accepted by the mempool, requestID: %s", request.ID().String())
 }
 
+func (mpi *mempoolImpl) removeOffLedger(request isc.OffLedgerRequest) {
+	mpi.offLedgerPool.Remove(request)
+	mpi.deleteOffLedgerWAL(request)
+}
+
+func (mpi *mempoolImpl) onLedgerRefused(request isc.OnLedgerRequest) {
+	mpi.log.Infof("on-ledger request %v refused, the mempool is full; it stays on L1 until the chain is synced with L1 again", request.ID())
+	mpi.metrics.IncOnLedgerRefused()
+}
+
+func (mpi *mempoolImpl) offLedgerEvicted(request isc.OffLedgerRequest) {
+	mpi.log.Infof("off-ledger request %v evicted from the mempool", request.ID())
+	mpi.deleteOffLedgerWAL(request)
+	mpi.metrics.IncOffLedgerEvicted()
+}
+
+// The WAL is written asynchronously, see offLedgerWALWriter.
+func (mpi *mempoolImpl) writeOffLedgerWAL(request isc.OffLedgerRequest) {
+	mpi.offLedgerWAL.Write(request)
+}
+
+func (mpi *mempoolImpl) deleteOffLedgerWAL(request isc.OffLedgerRequest) {
+	mpi.offLedgerWAL.Delete(request)
+}
+
+// Re-adds the requests read from the WAL on startup, if they are still valid
+// in the first chain head received. The rest are removed from the WAL.
+func (mpi *mempoolImpl) addRestoredOffLedger() {
+	restored := 0
+	for _, request := range mpi.offLedgerRestored {
+		if mpi.offLedgerPool.Has(isc.RequestRefFromRequest(request)) {
+			continue // Received again in the meantime.
+		}
+		processed, err := blocklog.IsRequestProcessed(mpi.chainHeadState, request.ID())
+		if err != nil {
+			// Keep it in the WAL, it will be retried on the next restart.
+			mpi.log.Warnf("cannot check if request %v read from the WAL was processed, ignoring it: %v", request.ID(), err)
+			continue
+		}
+		if processed {
+			mpi.deleteOffLedgerWAL(request)
+			continue
+		}
+		if err := mpi.shouldAddOffledgerRequest(request); err != nil {
+			mpi.log.Debugf("dropping request %v read from the WAL: %v", request.ID(), err)
+			mpi.deleteOffLedgerWAL(request)
+			continue
+		}
+		mpi.offLedgerPool.Add(request)
+		mpi.metrics.IncRequestsReceived(request)
+		restored++
+	}
+	mpi.log.Infof("Restored %v of %v off-ledger requests read from the WAL", restored, len(mpi.offLedgerRestored))
+	mpi.offLedgerRestored = nil
+}
+
 func (mpi *mempoolImpl) handleServerNodesUpdated(recv *reqServerNodesUpdated) {
 	mpi.serverNodes = recv.serverNodePubKeys
 	mpi.committeeNodes = recv.committeePubKeys
@@ -532,31 +841,42 @@ func (mpi *mempoolImpl) handleConsensusProposal(recv *reqConsensusProposal) {
 func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 	//
 	// The case for matching ChainHeadAO and request BaseAO
-	reqRefs := []*isc.RequestRef{}
+	reqs := []isc.Request{}
 	if !mpi.tangleTime.IsZero() { // Wait for tangle-time to process the on ledger requests.
 		mpi.onLedgerPool.Filter(func(request isc.OnLedgerRequest, _ time.Time) bool {
 			if isc.RequestIsExpired(request, mpi.tangleTime) {
 				return false // Drop it from the mempool
 			}
 			if isc.RequestIsUnlockable(request, mpi.chainID.AsAddress(), mpi.tangleTime) {
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(request))
+				reqs = append(reqs, request)
 			}
 			return true // Keep them for now
 		})
 	}
 
+	// The off-ledger requests are ordered by priority, but a request can not be
+	// proposed before the requests with lower nonces of the same account. So the
+	// requests are sorted by the lowest priority of the requests of the account
+	// up to (and including) the request.
+	type offLedgerCandidate struct {
+		req    isc.OffLedgerRequest
+		lowest isc.OffLedgerRequest
+	}
+	candidates := []*offLedgerCandidate{}
+	gasFeePolicy := mpi.offLedgerPool.GasFeePolicy()
 	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry[isc.OffLedgerRequest]) {
 		agentID, err := isc.AgentIDFromString(account)
 		if err != nil {
 			panic(fmt.Errorf("invalid agentID string: %s", err.Error()))
 		}
 		accountNonce := mpi.nonce(agentID)
+		var lowest isc.OffLedgerRequest
 		for _, e := range entries {
 			reqNonce := e.req.Nonce()
 			if reqNonce < accountNonce {
 				// nonce too old, delete
 				mpi.log.Debugf("refsToPropose, account: %s, removing request (%s) with old nonce (%d) from the pool", account, e.req.ID(), e.req.Nonce())
-				mpi.offLedgerPool.Remove(e.req)
+				mpi.removeOffLedger(e.req)
 				continue
 			}
 			if e.old {
@@ -567,7 +887,10 @@ func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 			if reqNonce == accountNonce {
 				// expected nonce, add it to the list to propose
 				mpi.log.Debugf("refsToPropose, account: %s, proposing reqID %s with nonce: %d", account, e.req.ID().String(), e.req.Nonce())
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(e.req))
+				if lowest == nil || comparePriority(e.req, lowest, gasFeePolicy) < 0 {
+					lowest = e.req
+				}
+				candidates = append(candidates, &offLedgerCandidate{req: e.req, lowest: lowest})
 				accountNonce++ // increment the account nonce to match the next valid request
 			}
 			if reqNonce > accountNonce {
@@ -576,8 +899,95 @@ func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 			}
 		}
 	})
+	slices.SortStableFunc(candidates, func(a, b *offLedgerCandidate) int {
+		return comparePriority(b.lowest, a.lowest, gasFeePolicy)
+	})
+	for _, c := range candidates {
+		reqs = append(reqs, c.req)
+	}
 
-	return reqRefs
+	return mpi.proposalSubset(reqs)
+}
+
+// Classifies the off-ledger requests the same way as refsToPropose does,
+// but without modifying the pool. Requests with already used nonces and
+// the ones replaced by a request with the same nonce are not reported.
+func (mpi *mempoolImpl) handleOffLedgerRequests(recv *reqOffLedgerRequests) {
+	res := []*AccountRequests{}
+	if recv.ctx.Err() != nil || mpi.chainHeadState == nil {
+		recv.responseCh <- res
+		close(recv.responseCh)
+		return
+	}
+	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry[isc.OffLedgerRequest]) {
+		agentID, err := isc.AgentIDFromString(account)
+		if err != nil {
+			panic(fmt.Errorf("invalid agentID string: %s", err.Error()))
+		}
+		accountReqs := &AccountRequests{
+			Account: agentID,
+			Pending: []isc.OffLedgerRequest{},
+			Queued:  []isc.OffLedgerRequest{},
+		}
+		accountNonce := mpi.nonce(agentID)
+		for _, e := range entries {
+			reqNonce := e.req.Nonce()
+			if reqNonce < accountNonce || e.old {
+				continue
+			}
+			if reqNonce == accountNonce && len(accountReqs.Queued) == 0 {
+				accountReqs.Pending = append(accountReqs.Pending, e.req)
+				accountNonce++
+				continue
+			}
+			accountReqs.Queued = append(accountReqs.Queued, e.req)
+		}
+		if len(accountReqs.Pending) > 0 || len(accountReqs.Queued) > 0 {
+			res = append(res, accountReqs)
+		}
+	})
+	recv.responseCh <- res
+	close(recv.responseCh)
+}
+
+func (mpi *mempoolImpl) handleRequests(recv *reqRequests) {
+	if recv.ctx.Err() != nil {
+		close(recv.responseCh)
+		return
+	}
+	recv.responseCh <- &RequestsPage{
+		Requests:  mpi.requestIndex.Page(recv.after, recv.limit),
+		PoolSizes: mpi.requestIndex.PoolSizes(),
+	}
+	close(recv.responseCh)
+}
+
+// The on-ledger requests removed here will be added back, if they are
+// still unprocessed when the node is restarted, because they are read
+// from L1 again.
+// The request is looked up in the RequestIndex, which knows the pool it is in.
+// A removed on-ledger request stays unconsumed on L1, so it is read again when
+// the chain is synced with L1 (e.g. on node restart).
+func (mpi *mempoolImpl) handleRemoveRequest(recv *reqRemoveRequest) {
+	var poolReq *PoolRequest
+	if recv.ctx.Err() == nil {
+		poolReq = mpi.requestIndex.Get(recv.requestID)
+	}
+	if poolReq != nil {
+		switch poolReq.Pool {
+		case PoolOnLedger:
+			mpi.onLedgerPool.Remove(poolReq.Request.(isc.OnLedgerRequest))
+		case PoolOffLedger:
+			mpi.removeOffLedger(poolReq.Request.(isc.OffLedgerRequest))
+		case PoolTimeLocked:
+			mpi.timePool.Remove(poolReq.Timestamp, poolReq.Request)
+		default:
+			panic(fmt.Errorf("unexpected pool: %v", poolReq.Pool))
+		}
+		mpi.log.Infof("Request %v removed from the mempool by the node operator.", recv.requestID)
+	}
+	recv.responseCh <- poolReq != nil
+	close(recv.responseCh)
 }
 
 func (mpi *mempoolImpl) handleConsensusProposalForChainHead(recv *reqConsensusProposal) {
@@ -756,6 +1166,7 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 		}
 		mpi.metrics.IncBlocksPerChain()
 		mpi.listener.BlockApplied(mpi.chainID, block)
+		mpi.rememberGasBurned(blockReceipts)
 		for _, receipt := range blockReceipts {
 			mpi.metrics.IncRequestsProcessed()
 			mpi.tryRemoveRequest(receipt.Request)
@@ -780,6 +1191,13 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	// Record the head state.
 	mpi.chainHeadState = req.st
 	mpi.chainHeadAO = req.till
+	mpi.offLedgerPool.SetGasFeePolicy(governance.NewStateAccess(req.st).ChainInfo(mpi.chainID).GasFeePolicy)
+	mpi.tryCleanupPastDeadline(req.st)
+	//
+	// Re-add the requests read from the WAL, now that they can be checked.
+	if mpi.offLedgerRestored != nil {
+		mpi.addRestoredOffLedger()
+	}
 	//
 	// Process the pending consensus proposal requests if any.
 	if len(mpi.waitChainHead) != 0 {
@@ -846,6 +1264,7 @@ func (mpi *mempoolImpl) tryReAddRequest(req isc.Request) {
 		mpi.onLedgerPool.Add(req)
 	case isc.OffLedgerRequest:
 		mpi.log.Debugf("re-adding off-ledger request to mempool: %s", req.ID())
+		mpi.writeOffLedgerWAL(req)
 		mpi.offLedgerPool.Add(req)
 	default:
 		panic(fmt.Errorf("unexpected request type: %T", req))
@@ -859,7 +1278,7 @@ func (mpi *mempoolImpl) tryRemoveRequest(req isc.Request) {
 		mpi.onLedgerPool.Remove(req)
 	case isc.OffLedgerRequest:
 		mpi.log.Debugf("removing off-ledger request from mempool: %s", req.ID())
-		mpi.offLedgerPool.Remove(req)
+		mpi.removeOffLedger(req)
 	default:
 		mpi.log.Warn("Trying to remove request of unexpected type %T: %+v", req, req)
 	}
@@ -867,7 +1286,14 @@ func (mpi *mempoolImpl) tryRemoveRequest(req isc.Request) {
 
 func (mpi *mempoolImpl) tryCleanupProcessed(chainState state.State) {
 	mpi.onLedgerPool.Filter(unprocessedPredicate[isc.OnLedgerRequest](chainState, mpi.log))
-	mpi.offLedgerPool.Filter(unprocessedPredicate[isc.OffLedgerRequest](chainState, mpi.log))
+	offLedgerPredicate := unprocessedPredicate[isc.OffLedgerRequest](chainState, mpi.log)
+	mpi.offLedgerPool.Filter(func(request isc.OffLedgerRequest, ts time.Time) bool {
+		if !offLedgerPredicate(request, ts) {
+			mpi.deleteOffLedgerWAL(request)
+			return false
+		}
+		return true
+	})
 	mpi.timePool.Filter(unprocessedPredicate[isc.Request](chainState, mpi.log))
 }
 
@@ -895,6 +1321,23 @@ func (mpi *mempoolImpl) pubKeyAsNodeIDMap(nodePubKey *cryptolib.PublicKey, _ int
 }
 
 // Have to have it as a separate function to be able to use type params.
+// The encrypted requests cannot be processed after their deadlines, the VM
+// would skip them.
+func (mpi *mempoolImpl) tryCleanupPastDeadline(chainState state.State) {
+	for _, req := range mpi.requestIndex.EncryptedRequests() {
+		if deadlinePassed(req, chainState) {
+			mpi.log.Debugf("Dropping encrypted request %v, its deadline %v has passed", req.ID(), req.Deadline())
+			mpi.removeOffLedger(req)
+		}
+	}
+}
+
+// deadlinePassed checks, if the encrypted request cannot be processed in the
+// block following the chain state anymore. The VM would skip it.
+func deadlinePassed(req isc.EncryptedOffLedgerRequest, chainState state.State) bool {
+	return chainState.BlockIndex() >= req.Deadline()
+}
+
 func unprocessedPredicate[V isc.Request](chainState state.State, log *logger.Logger) func(V, time.Time) bool {
 	return func(request V, ts time.Time) bool {
 		requestID := request.ID()
@@ -23,11 +23,13 @@ import (
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/origin"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/testutil"
 	"github.com/iotaledger/wasp/packages/testutil/testchain"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
@@ -38,6 +40,8 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/coreprocessors"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 	"github.com/iotaledger/wasp/packages/vm/vmimpl"
@@ -198,7 +202,22 @@ func blockFn(te *testEnv, reqs []isc.Request, ao *isc.AliasOutputWithID, tangleT
 	slices.SortFunc(reqs, func(a, b isc.Request) int {
 		return int(a.(isc.OffLedgerRequest).Nonce() - b.(isc.OffLedgerRequest).Nonce())
 	})
+	nextAO, receipts := runBlockFn(te, reqs, ao, tangleTime)
+	//
+	// Check if block has both requests as consumed.
+	require.Len(te.t, receipts, len(reqs))
+	blockReqs := []isc.Request{}
+	for i := range receipts {
+		blockReqs = append(blockReqs, receipts[i].Request)
+	}
+	for _, req := range reqs {
+		require.Contains(te.t, blockReqs, req)
+	}
+	return nextAO
+}
 
+// Runs the requests in a block, as they are, and tracks it as the new chain head.
+func runBlockFn(te *testEnv, reqs []isc.Request, ao *isc.AliasOutputWithID, tangleTime time.Time) (*isc.AliasOutputWithID, []*blocklog.RequestReceipt) {
 	store := te.stores[0]
 	vmTask := &vm.VMTask{
 		Processors:           processors.MustNew(coreprocessors.NewConfigWithCoreContracts().WithNativeContracts(inccounter.Processor)),
@@ -218,18 +237,8 @@ func blockFn(te *testEnv, reqs []isc.Request, ao *isc.AliasOutputWithID, tangleT
 	block := store.Commit(vmResult.StateDraft)
 	chainState, err := store.StateByTrieRoot(block.TrieRoot())
 	require.NoError(te.t, err)
-	//
-	// Check if block has both requests as consumed.
 	receipts, err := blocklog.RequestReceiptsFromBlock(block)
 	require.NoError(te.t, err)
-	require.Len(te.t, receipts, len(reqs))
-	blockReqs := []isc.Request{}
-	for i := range receipts {
-		blockReqs = append(blockReqs, receipts[i].Request)
-	}
-	for _, req := range reqs {
-		require.Contains(te.t, blockReqs, req)
-	}
 	nextAO := te.tcl.FakeStateTransition(ao, block.L1Commitment())
 
 	// sync mempools with new state
@@ -240,7 +249,7 @@ func blockFn(te *testEnv, reqs []isc.Request, ao *isc.AliasOutputWithID, tangleT
 	for i := range te.mempools {
 		<-awaitTrackHeadChannels[i]
 	}
-	return nextAO
+	return nextAO, receipts
 }
 
 func TestTimeLock(t *testing.T) {
@@ -511,6 +520,20 @@ func TestMempoolsNonceGaps(t *testing.T) {
 	}
 	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
 
+	// 0,1 are pending, 3,6,10 are queued because of the gap
+	accountReqs := <-te.mempools[chosenMempool].OffLedgerRequestsAsync(te.ctx)
+	require.Len(t, accountReqs, 1)
+	require.True(t, accountReqs[0].Account.Equals(isc.NewAgentID(te.governor.Address())))
+	require.Equal(t, []isc.OffLedgerRequest{
+		offLedgerReqs[0].(isc.OffLedgerRequest),
+		offLedgerReqs[1].(isc.OffLedgerRequest),
+	}, accountReqs[0].Pending)
+	require.Equal(t, []isc.OffLedgerRequest{
+		offLedgerReqs[2].(isc.OffLedgerRequest),
+		offLedgerReqs[3].(isc.OffLedgerRequest),
+		offLedgerReqs[4].(isc.OffLedgerRequest),
+	}, accountReqs[0].Queued)
+
 	askProposalExpectReqs := func(ao *isc.AliasOutputWithID, reqs ...isc.Request) *isc.AliasOutputWithID {
 		t.Log("Ask for proposals")
 		proposals := make([]<-chan []*isc.RequestRef, len(te.mempools))
@@ -663,6 +686,519 @@ func TestMempoolOverrideNonce(t *testing.T) {
 	require.NotEqual(t, initialReq, proposedReqs[0])
 }
 
+func TestMempoolSponsoredSender(t *testing.T) {
+	// 1 node setup
+	// an unfunded sender is rejected
+	// the governor deposits funds and sponsors the sender
+	// the request of the unfunded sender is accepted
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	for _, node := range te.mempools {
+		node.ServerNodesUpdated(te.peerPubKeys, te.peerPubKeys)
+		node.TangleTimeUpdated(tangleTime)
+	}
+	awaitTrackHeadChannels := make([]<-chan bool, len(te.mempools))
+	for i, node := range te.mempools {
+		awaitTrackHeadChannels[i] = node.TrackNewChainHead(te.stateForAO(i, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+	}
+	for i := range te.mempools {
+		<-awaitTrackHeadChannels[i]
+	}
+
+	sender := cryptolib.NewKeyPair()
+	senderAgentID := isc.NewAgentID(sender.Address())
+	newReq := func() isc.OffLedgerRequest {
+		return isc.NewOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			0,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(sender)
+	}
+	require.ErrorContains(t, te.mempools[0].ReceiveOffLedgerRequest(newReq()), "no funds on chain")
+
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+			Metadata: &isc.SendMetadata{
+				TargetContract: sponsorship.Contract.Hname(),
+				EntryPoint:     sponsorship.FuncSponsor.Hname(),
+				Params:         dict.Dict{sponsorship.ParamSender: codec.EncodeAgentID(senderAgentID)},
+				GasBudget:      gas.LimitsDefault.MaxGasPerRequest,
+			},
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	for _, node := range te.mempools {
+		node.ReceiveOnLedgerRequest(onLedgerReq)
+	}
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, tangleTime)
+
+	req := newReq()
+	require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	proposedReqs := <-te.mempools[0].ConsensusRequestsAsync(te.ctx, reqRefs)
+	require.Len(t, proposedReqs, 1)
+	require.Equal(t, req, proposedReqs[0])
+
+	// other senders are still rejected
+	require.ErrorContains(t, te.mempools[0].ReceiveOffLedgerRequest(isc.NewOffLedgerRequest(
+		te.chainID,
+		inccounter.Contract.Hname(),
+		inccounter.FuncIncCounter.Hname(),
+		dict.New(),
+		0,
+		gas.LimitsDefault.MaxGasPerRequest,
+	).Sign(cryptolib.NewKeyPair())), "no funds on chain")
+}
+
+func TestMempoolPriority(t *testing.T) {
+	// 1 node setup
+	// account A sends nonce 0 without a tip and nonce 1 with a high tip
+	// account B sends nonce 0 with a small tip
+	// assert B is proposed first, and the nonce order of A is preserved
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	kpA := te.governor
+	kpB := cryptolib.NewKeyPair()
+	currentAO := te.originAO
+	for i, kp := range []*cryptolib.KeyPair{kpA, kpB} {
+		output := transaction.BasicOutputFromPostData(
+			kp.Address(),
+			isc.EmptyContractIdentity(),
+			isc.RequestParameters{
+				TargetAddress: te.chainID.AsAddress(),
+				Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+			},
+		)
+		onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(i)))
+		require.NoError(t, err)
+		te.mempools[0].ReceiveOnLedgerRequest(onLedgerReq)
+		currentAO = blockFn(te, []isc.Request{onLedgerReq}, currentAO, tangleTime)
+	}
+
+	newReq := func(kp *cryptolib.KeyPair, nonce, tip uint64) isc.OffLedgerRequest {
+		return isc.NewOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			nonce,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).WithMaxFee(isc.Million, tip).Sign(kp)
+	}
+	reqA0 := newReq(kpA, 0, 0)
+	reqA1 := newReq(kpA, 1, 100)
+	reqB0 := newReq(kpB, 0, 10)
+	for _, req := range []isc.OffLedgerRequest{reqA1, reqB0, reqA0} {
+		require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+	}
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 3)
+	require.True(t, reqRefs[0].IsFor(reqB0))
+	require.True(t, reqRefs[1].IsFor(reqA0))
+	require.True(t, reqRefs[2].IsFor(reqA1))
+}
+
+func TestMempoolProposalSubset(t *testing.T) {
+	// 1 node setup
+	// send more requests than the gas limit of a block allows
+	// assert only the ones fitting into the block are proposed, in the nonce order
+	// restart the mempool with a small MaxProposalBytes, assert it limits the proposal
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	te.mempools[0].ReceiveOnLedgerRequest(onLedgerReq)
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, tangleTime)
+
+	fitGas := int(gas.LimitsDefault.MaxGasPerBlock / gas.LimitsDefault.MaxGasPerRequest)
+	offLedgerReqs := []isc.OffLedgerRequest{}
+	for nonce := 0; nonce < fitGas+5; nonce++ {
+		offLedgerReqs = append(offLedgerReqs, isc.NewOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			uint64(nonce),
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(te.governor))
+	}
+	addRequests := func() {
+		for _, req := range offLedgerReqs {
+			require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+		}
+		time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	}
+	addRequests()
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, fitGas)
+	for i, reqRef := range reqRefs {
+		require.True(t, reqRef.IsFor(offLedgerReqs[i]))
+	}
+
+	params := mempool.NewParameters()
+	params.MaxProposalBytes = 3 * len(offLedgerReqs[0].Bytes())
+	chainMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID())
+	te.mempools[0] = mempool.New(
+		te.ctx,
+		te.chainID,
+		te.peerIdentities[0],
+		te.networkProviders[0],
+		mempool.NewEmptyOffLedgerWAL(),
+		params,
+		te.log.Named("N#0"),
+		chainMetrics.Mempool,
+		chainMetrics.Pipe,
+		chain.NewEmptyChainListener(),
+	)
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, currentAO), nil, currentAO, []state.Block{}, []state.Block{})
+	addRequests()
+	reqRefs = <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 3)
+	for i, reqRef := range reqRefs {
+		require.True(t, reqRef.IsFor(offLedgerReqs[i]))
+	}
+}
+
+func TestMempoolGasEstimateUndershoot(t *testing.T) {
+	// 1 node setup
+	// set small gas limits, so that a few requests fill a block
+	// send requests, estimated to burn much less gas than they do
+	// assert all of them are proposed, but the block is not overfilled
+	// assert the skipped requests stay in the mempool and are proposed next
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	gasLimits := &gas.Limits{
+		MaxGasPerBlock:         100_000,
+		MinGasPerRequest:       10_000,
+		MaxGasPerRequest:       100_000,
+		MaxGasExternalViewCall: gas.LimitsDefault.MaxGasExternalViewCall,
+	}
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+			Metadata: &isc.SendMetadata{
+				TargetContract: governance.Contract.Hname(),
+				EntryPoint:     governance.FuncSetGasLimits.Hname(),
+				Params:         dict.Dict{governance.ParamGasLimitsBytes: gasLimits.Bytes()},
+				GasBudget:      gasLimits.MaxGasPerRequest,
+			},
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	te.mempools[0].ReceiveOnLedgerRequest(onLedgerReq)
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, tangleTime)
+
+	offLedgerReqs := []isc.OffLedgerRequest{}
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		req := isc.NewOffLedgerRequest( // burns the minimum gas per request
+			te.chainID,
+			accounts.Contract.Hname(),
+			accounts.FuncDeposit.Hname(),
+			dict.New(),
+			nonce,
+			gasLimits.MaxGasPerRequest,
+		).Sign(te.governor)
+		require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+		offLedgerReqs = append(offLedgerReqs, req)
+	}
+	// e.g. a simulation that burned much less, than the requests will
+	te.mempools[0].GasEstimated(offLedgerReqs[0], 1)
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, len(offLedgerReqs))
+	proposedReqs := <-te.mempools[0].ConsensusRequestsAsync(te.ctx, reqRefs)
+	currentAO, receipts := runBlockFn(te, proposedReqs, currentAO, tangleTime)
+	require.NotEmpty(t, receipts)
+	require.Less(t, len(receipts), len(offLedgerReqs))
+	var blockGas uint64
+	for i, receipt := range receipts {
+		require.Nil(t, receipt.Error)
+		require.Equal(t, offLedgerReqs[i], receipt.Request)
+		blockGas += receipt.GasBurned
+	}
+	require.LessOrEqual(t, blockGas, gasLimits.MaxGasPerBlock)
+
+	// the estimate follows the gas burned now, the rest is proposed in the next blocks
+	reqRefs = <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.NotEmpty(t, reqRefs)
+	require.True(t, reqRefs[0].IsFor(offLedgerReqs[len(receipts)]))
+}
+
+func TestMempoolInspectAndRemove(t *testing.T) {
+	// 1 node setup
+	// send an on-ledger and two off-ledger requests
+	// assert all of them are reported in the pools
+	// remove one of them, assert it is not reported and not proposed anymore
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	te.mempools[0].ReceiveOnLedgerRequest(onLedgerReq)
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, tangleTime)
+
+	onLedgerReqs := getRequestsOnLedger(t, te.chainID.AsAddress(), 1)
+	te.mempools[0].ReceiveOnLedgerRequest(onLedgerReqs[0])
+	offLedgerReqs := []isc.OffLedgerRequest{}
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		req := isc.NewOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			nonce,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(te.governor)
+		require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+		offLedgerReqs = append(offLedgerReqs, req)
+	}
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+
+	pools := func() map[isc.RequestID]string {
+		res := map[isc.RequestID]string{}
+		page := <-te.mempools[0].RequestsAsync(te.ctx, 0, 100)
+		for _, poolReq := range page.Requests {
+			res[poolReq.Request.ID()] = poolReq.Pool
+		}
+		return res
+	}
+	require.Equal(t, map[isc.RequestID]string{
+		onLedgerReqs[0].ID():  mempool.PoolOnLedger,
+		offLedgerReqs[0].ID(): mempool.PoolOffLedger,
+		offLedgerReqs[1].ID(): mempool.PoolOffLedger,
+	}, pools())
+
+	// the requests are listed in the order they were added, page by page
+	all := <-te.mempools[0].RequestsAsync(te.ctx, 0, 100)
+	require.Len(t, all.Requests, 3)
+	require.Equal(t, map[string]int{mempool.PoolOnLedger: 1, mempool.PoolOffLedger: 2}, all.PoolSizes)
+	require.Less(t, all.Requests[0].Seq, all.Requests[1].Seq)
+	require.Less(t, all.Requests[1].Seq, all.Requests[2].Seq)
+	page := <-te.mempools[0].RequestsAsync(te.ctx, 0, 2)
+	require.Equal(t, all.Requests[:2], page.Requests)
+	page = <-te.mempools[0].RequestsAsync(te.ctx, page.Requests[1].Seq, 2)
+	require.Equal(t, all.Requests[2:], page.Requests)
+
+	require.True(t, <-te.mempools[0].RemoveRequestAsync(te.ctx, offLedgerReqs[1].ID()))
+	require.False(t, <-te.mempools[0].RemoveRequestAsync(te.ctx, offLedgerReqs[1].ID()))
+	require.True(t, <-te.mempools[0].RemoveRequestAsync(te.ctx, onLedgerReqs[0].ID()))
+	require.Equal(t, map[isc.RequestID]string{
+		offLedgerReqs[0].ID(): mempool.PoolOffLedger,
+	}, pools())
+
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 1)
+	require.True(t, reqRefs[0].IsFor(offLedgerReqs[0]))
+}
+
+func TestMempoolEncryptedDeadline(t *testing.T) {
+	// 1 node setup
+	// an encrypted request with a passed deadline is rejected
+	// an encrypted request with the deadline in the next block is proposed
+	// once the next block is produced without it, it is dropped
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	deposit := func() isc.OnLedgerRequest {
+		output := transaction.BasicOutputFromPostData(
+			te.governor.Address(),
+			isc.EmptyContractIdentity(),
+			isc.RequestParameters{
+				TargetAddress: te.chainID.AsAddress(),
+				Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+			},
+		)
+		req, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+		require.NoError(t, err)
+		te.mempools[0].ReceiveOnLedgerRequest(req)
+		return req
+	}
+	currentAO := blockFn(te, []isc.Request{deposit()}, te.originAO, tangleTime)
+
+	suite := tcrypto.DefaultBLSSuite()
+	newReq := func(deadline uint32) isc.OffLedgerRequest {
+		keyEncapsulation, err := tcrypto.NewKeyEncapsulation(suite, suite.Point().Pick(suite.RandomStream()))
+		require.NoError(t, err)
+		return isc.NewEncryptedOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			0,
+			gas.LimitsDefault.MaxGasPerRequest,
+			deadline,
+			keyEncapsulation,
+		).Sign(te.governor)
+	}
+	require.ErrorContains(t, te.mempools[0].ReceiveOffLedgerRequest(newReq(currentAO.GetStateIndex())), "deadline passed")
+
+	req := newReq(currentAO.GetStateIndex() + 1)
+	require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 1)
+	require.True(t, reqRefs[0].IsFor(req))
+
+	// e.g. the consensus round, the request was revealed in, has failed
+	currentAO = blockFn(te, []isc.Request{deposit()}, currentAO, tangleTime)
+	page := <-te.mempools[0].RequestsAsync(te.ctx, 0, 100)
+	require.Empty(t, page.Requests)
+}
+
+func TestMempoolOffLedgerWAL(t *testing.T) {
+	// 1 node setup
+	// send requests with nonces 0 and 1
+	// restart the mempool, assert both requests are proposed
+	// process the request with nonce 0, assert it is removed from the WAL
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+
+	walDir := t.TempDir()
+	newMempool := func(ctx context.Context) mempool.Mempool {
+		wal, err := mempool.NewOffLedgerWAL(te.log, walDir, te.chainID)
+		require.NoError(t, err)
+		chainMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID())
+		return mempool.New(
+			ctx,
+			te.chainID,
+			te.peerIdentities[0],
+			te.networkProviders[0],
+			wal,
+			mempool.NewParameters(),
+			te.log.Named("N#0"),
+			chainMetrics.Mempool,
+			chainMetrics.Pipe,
+			chain.NewEmptyChainListener(),
+		)
+	}
+	walRequests := func() []isc.OffLedgerRequest {
+		wal, err := mempool.NewOffLedgerWAL(te.log, walDir, te.chainID)
+		require.NoError(t, err)
+		reqs := []isc.OffLedgerRequest{}
+		require.NoError(t, wal.ReadAll(func(request isc.OffLedgerRequest) {
+			reqs = append(reqs, request)
+		}))
+		return reqs
+	}
+
+	tangleTime := time.Now()
+	te.mempools[0].TangleTimeUpdated(tangleTime)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	te.mempools[0].ReceiveOnLedgerRequest(onLedgerReq)
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, tangleTime)
+
+	firstCtx, firstCancel := context.WithCancel(te.ctx)
+	te.mempools[0] = newMempool(firstCtx)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, currentAO), nil, currentAO, []state.Block{}, []state.Block{})
+	offLedgerReqs := []isc.Request{}
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		req := isc.NewOffLedgerRequest(
+			te.chainID,
+			inccounter.Contract.Hname(),
+			inccounter.FuncIncCounter.Hname(),
+			dict.New(),
+			nonce,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(te.governor)
+		require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(req))
+		offLedgerReqs = append(offLedgerReqs, req)
+	}
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 2)
+	// the WAL is written asynchronously
+	require.Eventually(t, func() bool { return len(walRequests()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	firstCancel()
+
+	// the requests are restored after the restart
+	te.mempools[0] = newMempool(te.ctx)
+	<-te.mempools[0].TrackNewChainHead(te.stateForAO(0, currentAO), nil, currentAO, []state.Block{}, []state.Block{})
+	reqRefs = <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 2)
+	proposedReqs := <-te.mempools[0].ConsensusRequestsAsync(te.ctx, reqRefs)
+	require.ElementsMatch(t, offLedgerReqs, proposedReqs)
+
+	// the processed request is removed from the WAL
+	currentAO = blockFn(te, offLedgerReqs[:1], currentAO, tangleTime)
+	require.Eventually(t, func() bool { return len(walRequests()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, offLedgerReqs[1], walRequests()[0])
+	reqRefs = <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
+	require.Len(t, reqRefs, 1)
+	require.True(t, reqRefs[0].IsFor(offLedgerReqs[1]))
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // testEnv
 
@@ -734,6 +1270,8 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			te.chainID,
 			te.peerIdentities[i],
 			te.networkProviders[i],
+			mempool.NewEmptyOffLedgerWAL(),
+			mempool.NewParameters(),
 			te.log.Named(fmt.Sprintf("N#%v", i)),
 			chainMetrics.Mempool,
 			chainMetrics.Pipe,
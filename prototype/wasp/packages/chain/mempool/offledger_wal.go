@@ -0,0 +1,169 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+// OffLedgerWAL persists the off-ledger requests accepted by the mempool, so
+// that they survive node restarts. Each request is stored in its own file,
+// until it is processed or it can not be processed anymore. The mempool calls
+// it from a dedicated goroutine, see offLedgerWALWriter.
+type OffLedgerWAL interface {
+	// Writes the requests, they survive a crash of the host once it returns.
+	Write(requests ...isc.OffLedgerRequest) error
+	Delete(reqRef *isc.RequestRef) error
+	// Reads all the requests stored in the WAL. The requests that can not be
+	// parsed are removed from the WAL.
+	ReadAll(cb func(request isc.OffLedgerRequest)) error
+}
+
+type offLedgerWAL struct {
+	dir string
+	log *logger.Logger
+}
+
+const (
+	constOffLedgerWALFileSuffix    = ".req"
+	constOffLedgerWALTmpFileSuffix = ".tmp"
+)
+
+var _ OffLedgerWAL = &offLedgerWAL{}
+
+func NewOffLedgerWAL(log *logger.Logger, baseDir string, chainID isc.ChainID) (OffLedgerWAL, error) {
+	dir := filepath.Join(baseDir, chainID.String())
+	if err := ioutils.CreateDirectory(dir, 0o777); err != nil {
+		return nil, fmt.Errorf("OffLedgerWAL cannot create folder %v: %w", dir, err)
+	}
+	log.Debugf("OffLedgerWAL created in folder %v", dir)
+	return &offLedgerWAL{dir: dir, log: log}, nil
+}
+
+// Each request is first written to a temporary file, which is then renamed,
+// so that a partially written request is never read back. The files are
+// synced one by one, the directory is synced once for all the requests.
+func (w *offLedgerWAL) Write(requests ...isc.OffLedgerRequest) error {
+	for _, request := range requests {
+		filePath := w.filePath(isc.RequestRefFromRequest(request))
+		tmpFilePath := filePath + constOffLedgerWALTmpFileSuffix
+		if err := writeFileSynced(tmpFilePath, request.Bytes()); err != nil {
+			return fmt.Errorf("failed to write request %v to file %s: %w", request.ID(), tmpFilePath, err)
+		}
+		if err := os.Rename(tmpFilePath, filePath); err != nil {
+			return fmt.Errorf("failed to move temporary file %s to %s: %w", tmpFilePath, filePath, err)
+		}
+	}
+	if err := syncDir(w.dir); err != nil {
+		return fmt.Errorf("failed to sync folder %s: %w", w.dir, err)
+	}
+	return nil
+}
+
+func writeFileSynced(filePath string, data []byte) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *offLedgerWAL) Delete(reqRef *isc.RequestRef) error {
+	filePath := w.filePath(reqRef)
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func (w *offLedgerWAL) ReadAll(cb func(request isc.OffLedgerRequest)) error {
+	dirEntries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+	for _, dirEntry := range dirEntries {
+		filePath := filepath.Join(w.dir, dirEntry.Name())
+		if dirEntry.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(filePath, constOffLedgerWALTmpFileSuffix) {
+			w.removeFile(filePath) // Leftover of an interrupted write.
+			continue
+		}
+		if !strings.HasSuffix(filePath, constOffLedgerWALFileSuffix) {
+			continue
+		}
+		request, err := offLedgerRequestFromFilePath(filePath)
+		if err != nil {
+			w.log.Warnf("Unable to read %v, removing it: %v", filePath, err)
+			w.removeFile(filePath)
+			continue
+		}
+		cb(request)
+	}
+	return nil
+}
+
+func (w *offLedgerWAL) removeFile(filePath string) {
+	if err := os.Remove(filePath); err != nil {
+		w.log.Warnf("Unable to remove %v: %v", filePath, err)
+	}
+}
+
+func (w *offLedgerWAL) filePath(reqRef *isc.RequestRef) string {
+	refKey := reqRef.AsKey()
+	return filepath.Join(w.dir, hex.EncodeToString(refKey[:])+constOffLedgerWALFileSuffix)
+}
+
+func offLedgerRequestFromFilePath(filePath string) (isc.OffLedgerRequest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	request, err := isc.RequestFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	offLedgerRequest, ok := request.(isc.OffLedgerRequest)
+	if !ok {
+		return nil, fmt.Errorf("not an off-ledger request: %T", request)
+	}
+	return offLedgerRequest, nil
+}
+
+// May be used in tests or if the persistence of the requests is disabled.
+type emptyOffLedgerWAL struct{}
+
+var _ OffLedgerWAL = &emptyOffLedgerWAL{}
+
+func NewEmptyOffLedgerWAL() OffLedgerWAL                            { return &emptyOffLedgerWAL{} }
+func (*emptyOffLedgerWAL) Write(...isc.OffLedgerRequest) error      { return nil }
+func (*emptyOffLedgerWAL) Delete(*isc.RequestRef) error             { return nil }
+func (*emptyOffLedgerWAL) ReadAll(func(isc.OffLedgerRequest)) error { return nil }
@@ -0,0 +1,108 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/pipe"
+)
+
+// At most that many requests are written to the WAL in a single batch.
+const offLedgerWALMaxBatchSize = 100
+
+// offLedgerWALWriter applies the writes and the deletes of the OffLedgerWAL
+// in a dedicated goroutine, so that the event loop of the mempool does not
+// wait for the disk. The operations are applied in the order they were
+// queued. The writes queued one after another are written in a single batch,
+// so the directory is synced once for all of them.
+//
+// A request is persisted a bit later than it is accepted by the mempool.
+// The requests not written yet are lost, if the node crashes in between, as
+// if they were received just before the crash.
+type offLedgerWALWriter struct {
+	wal OffLedgerWAL
+	ops pipe.Pipe[*offLedgerWALOp]
+	log *logger.Logger
+}
+
+// Either a write or a delete.
+type offLedgerWALOp struct {
+	write  isc.OffLedgerRequest
+	delete *isc.RequestRef
+}
+
+func newOffLedgerWALWriter(ctx context.Context, wal OffLedgerWAL, log *logger.Logger) *offLedgerWALWriter {
+	w := &offLedgerWALWriter{
+		wal: wal,
+		ops: pipe.NewInfinitePipe[*offLedgerWALOp](),
+		log: log,
+	}
+	go w.run(ctx)
+	return w
+}
+
+func (w *offLedgerWALWriter) Write(request isc.OffLedgerRequest) {
+	w.ops.In() <- &offLedgerWALOp{write: request}
+}
+
+func (w *offLedgerWALWriter) Delete(request isc.OffLedgerRequest) {
+	w.ops.In() <- &offLedgerWALOp{delete: isc.RequestRefFromRequest(request)}
+}
+
+func (w *offLedgerWALWriter) Len() int {
+	return w.ops.Len()
+}
+
+func (w *offLedgerWALWriter) run(ctx context.Context) {
+	opsCh := w.ops.Out()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case op := <-opsCh:
+			w.apply(w.batch(op, opsCh))
+		}
+	}
+}
+
+// Takes the operations queued already, along with the first one.
+func (w *offLedgerWALWriter) batch(first *offLedgerWALOp, opsCh <-chan *offLedgerWALOp) []*offLedgerWALOp {
+	ops := []*offLedgerWALOp{first}
+	for len(ops) < offLedgerWALMaxBatchSize {
+		select {
+		case op := <-opsCh:
+			ops = append(ops, op)
+		default:
+			return ops
+		}
+	}
+	return ops
+}
+
+func (w *offLedgerWALWriter) apply(ops []*offLedgerWALOp) {
+	writes := []isc.OffLedgerRequest{}
+	flushWrites := func() {
+		if len(writes) == 0 {
+			return
+		}
+		if err := w.wal.Write(writes...); err != nil {
+			w.log.Warnf("Cannot write %v requests to the WAL: %v", len(writes), err)
+		}
+		writes = []isc.OffLedgerRequest{}
+	}
+	for _, op := range ops {
+		if op.write != nil {
+			writes = append(writes, op.write)
+			continue
+		}
+		flushWrites()
+		if err := w.wal.Delete(op.delete); err != nil {
+			w.log.Warnf("Cannot delete request %v from the WAL: %v", op.delete, err)
+		}
+	}
+	flushWrites()
+}
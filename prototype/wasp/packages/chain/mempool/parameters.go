@@ -0,0 +1,27 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+type Parameters struct {
+	// How many on-ledger requests may be kept in the pool; 0 means unlimited
+	MaxOnLedgerInPool int
+	// How many on-ledger requests of a single sender may be kept in the pool; 0 means unlimited
+	MaxOnLedgerPerAccount int
+	// How many off-ledger requests may be kept in the pool; 0 means unlimited
+	MaxOffLedgerInPool int
+	// How many off-ledger requests of a single sender may be kept in the pool; 0 means unlimited
+	MaxOffLedgerPerAccount int
+	// The maximal total size (in bytes) of the requests proposed for a block; 0 means unlimited
+	MaxProposalBytes int
+}
+
+func NewParameters() Parameters {
+	return Parameters{
+		MaxOnLedgerInPool:      10000,
+		MaxOnLedgerPerAccount:  1000,
+		MaxOffLedgerInPool:     10000,
+		MaxOffLedgerPerAccount: 1000,
+		MaxProposalBytes:       1024 * 1024,
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"container/heap"
+	"math/big"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// All the requests are charged the same gas price (the current base gas price
+// of the chain), so the priority of a request is given by the tip it offers
+// to the validators, per unit of its gas budget:
+//   - ISC off-ledger requests pay their tip, as long as it fits in their max
+//     fee on top of the gas fee of the whole budget;
+//   - EVM transactions pay their priority fee (see evmutil.EffectiveTip) for
+//     their whole gas limit;
+//   - the rest of the requests have the lowest priority.
+func requestTip(req isc.Request, gasFeePolicy *gas.FeePolicy) (tip, gasBudget uint64) {
+	gasBudget, isEVM := req.GasBudget()
+	if offLedgerReq, ok := req.(isc.OffLedgerRequest); ok {
+		if tx := offLedgerReq.EVMTransaction(); tx != nil {
+			tip = evmutil.TipBaseTokens(tx, gasFeePolicy, tx.Gas())
+		} else {
+			tip = offLedgerReq.Tip()
+		}
+		if maxFee := offLedgerReq.MaxFee(); !isEVM && maxFee > 0 {
+			fee := gasFeePolicy.FeeFromGas(gasBudget)
+			if fee >= maxFee {
+				tip = 0
+			} else {
+				tip = min(tip, maxFee-fee)
+			}
+		}
+	}
+	if isEVM {
+		gasBudget = gas.EVMGasToISC(gasBudget, &gasFeePolicy.EVMGasRatio)
+	}
+	if gasBudget == 0 {
+		gasBudget = 1
+	}
+	return tip, gasBudget
+}
+
+// Returns a positive number if the request a has a higher priority than b,
+// negative if lower and 0 if both have the same priority.
+func comparePriority(a, b isc.Request, gasFeePolicy *gas.FeePolicy) int {
+	aTip, aGas := requestTip(a, gasFeePolicy)
+	bTip, bGas := requestTip(b, gasFeePolicy)
+	if aTip == bTip && aGas == bGas {
+		return 0
+	}
+	// aTip/aGas ? bTip/bGas
+	aValue := new(big.Int).Mul(new(big.Int).SetUint64(aTip), new(big.Int).SetUint64(bGas))
+	bValue := new(big.Int).Mul(new(big.Int).SetUint64(bTip), new(big.Int).SetUint64(aGas))
+	return aValue.Cmp(bValue)
+}
+
+// Returns true if the first request should be evicted before the second one:
+// the one with the lower priority, or the newer one if the priority is the
+// same, so that a request can't push out the ones that were there before it
+// without paying more.
+func evictBefore(a isc.Request, aTS time.Time, b isc.Request, bTS time.Time, gasFeePolicy *gas.FeePolicy) bool {
+	if cmp := comparePriority(a, b, gasFeePolicy); cmp != 0 {
+		return cmp < 0
+	}
+	return aTS.After(bTS)
+}
+
+// evictionHeap keeps the entries that can be evicted from a TypedPoolByNonce
+// (the highest nonce of each account), with the one to evict first on top.
+type evictionHeap[V isc.OffLedgerRequest] struct {
+	entries      []*OrderedPoolEntry[V]
+	gasFeePolicy *gas.FeePolicy
+}
+
+var _ heap.Interface = &evictionHeap[isc.OffLedgerRequest]{}
+
+func (h *evictionHeap[V]) Len() int { return len(h.entries) }
+
+func (h *evictionHeap[V]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	return evictBefore(a.req, a.ts, b.req, b.ts, h.gasFeePolicy)
+}
+
+func (h *evictionHeap[V]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].evictionIndex = i
+	h.entries[j].evictionIndex = j
+}
+
+func (h *evictionHeap[V]) Push(x any) {
+	entry := x.(*OrderedPoolEntry[V])
+	entry.evictionIndex = len(h.entries)
+	h.entries = append(h.entries, entry)
+}
+
+func (h *evictionHeap[V]) Pop() any {
+	last := len(h.entries) - 1
+	entry := h.entries[last]
+	h.entries[last] = nil
+	h.entries = h.entries[:last]
+	entry.evictionIndex = -1
+	return entry
+}
+
+func (h *evictionHeap[V]) top() *OrderedPoolEntry[V] {
+	if len(h.entries) == 0 {
+		return nil
+	}
+	return h.entries[0]
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/testutil/testkey"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+func TestComparePriority(t *testing.T) {
+	policy := gas.DefaultFeePolicy()
+	chainID := isc.RandomChainID()
+	gasPrice := policy.GasPriceWei(parameters.L1().BaseToken.Decimals)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmReq := func(tipCap, feeCap *big.Int) isc.Request {
+		tx, err2 := types.SignNewTx(key, evmutil.Signer(big.NewInt(1074)), &types.DynamicFeeTx{
+			GasTipCap: tipCap,
+			GasFeeCap: feeCap,
+			Gas:       100_000,
+			To:        &common.Address{},
+		})
+		require.NoError(t, err2)
+		req, err2 := isc.NewEVMOffLedgerTxRequest(chainID, tx)
+		require.NoError(t, err2)
+		return req
+	}
+	kp, _ := testkey.GenKeyAddr()
+	iscReq := func(maxFee, tip uint64) isc.Request {
+		return isc.NewOffLedgerRequest(chainID, isc.Hn("somecontract"), isc.Hn("someentrypoint"), dict.Dict{}, 0, 100_000).
+			WithMaxFee(maxFee, tip).
+			Sign(kp)
+	}
+
+	noTip := evmReq(big.NewInt(0), gasPrice)
+	require.Zero(t, comparePriority(noTip, iscReq(0, 0), policy))
+
+	// the priority fee counts only up to the fee cap
+	cappedTip := evmReq(gasPrice, gasPrice)
+	require.Zero(t, comparePriority(cappedTip, noTip, policy))
+	highTip := evmReq(gasPrice, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	require.Positive(t, comparePriority(highTip, noTip, policy))
+	require.Positive(t, comparePriority(highTip, iscReq(0, 1), policy))
+
+	// the ISC tip counts only up to the max fee
+	fee := policy.FeeFromGas(100_000)
+	require.Positive(t, comparePriority(iscReq(0, 10), iscReq(fee+5, 10), policy))
+	require.Zero(t, comparePriority(iscReq(fee, 10), iscReq(0, 0), policy))
+}
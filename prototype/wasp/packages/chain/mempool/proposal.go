@@ -0,0 +1,146 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// How many call targets (and requests) are remembered for estimating the gas
+// of the requests.
+const gasEstimatesMaxSize = 10000
+
+// The gas estimated for a particular off-ledger request is remembered by its
+// sender and nonce, as the request is usually estimated before it is signed,
+// or with another gas budget than the one it is sent with.
+type requestGasKey struct {
+	account string
+	nonce   uint64
+}
+
+func requestGasKeyOf(req isc.Request) (requestGasKey, bool) {
+	offLedgerReq, ok := req.(isc.OffLedgerRequest)
+	if !ok {
+		return requestGasKey{}, false
+	}
+	return requestGasKey{account: accountKey(req), nonce: offLedgerReq.Nonce()}, true
+}
+
+// Remembers the gas burned by the requests processed in the block, to estimate
+// the gas of the next requests with the same call target. The estimate decays
+// slowly, so that it follows the recent executions, but a single cheap call
+// does not make it too optimistic.
+func (mpi *mempoolImpl) rememberGasBurned(receipts []*blocklog.RequestReceipt) {
+	for _, receipt := range receipts {
+		if key, ok := requestGasKeyOf(receipt.Request); ok {
+			delete(mpi.requestGasEstimates, key) // The nonce is used now.
+		}
+		mpi.rememberTargetGas(receipt.Request, receipt.GasBurned)
+	}
+}
+
+// Remembers the gas burned by a simulation of the request, for the request
+// itself and for its call target, the latter the same way as by a processed
+// request.
+func (mpi *mempoolImpl) rememberGasEstimated(req isc.Request, gasBurned uint64) {
+	if key, ok := requestGasKeyOf(req); ok {
+		dropAnyIfFull(mpi.requestGasEstimates, key)
+		mpi.requestGasEstimates[key] = gasBurned
+	}
+	mpi.rememberTargetGas(req, gasBurned)
+}
+
+func (mpi *mempoolImpl) rememberTargetGas(req isc.Request, gasBurned uint64) {
+	if _, isEVM := req.GasBudget(); isEVM {
+		return // All the EVM transactions have the same call target.
+	}
+	target := req.CallTarget()
+	dropAnyIfFull(mpi.gasEstimates, target)
+	estimate := mpi.gasEstimates[target]
+	mpi.gasEstimates[target] = max(gasBurned, estimate-estimate/8)
+}
+
+// Makes room for the new key, if the map is full.
+func dropAnyIfFull[K comparable](estimates map[K]uint64, key K) {
+	if _, ok := estimates[key]; ok || len(estimates) < gasEstimatesMaxSize {
+		return
+	}
+	for k := range estimates {
+		delete(estimates, k) // Drop any of them.
+		break
+	}
+}
+
+// Estimates the gas a request will burn: the declared budget (capped by the
+// gas limit for a request), or less if the request was estimated to burn less
+// than that, or else if the previous requests with the same call target burned
+// less than that. The estimates can undershoot, the VM skips the requests that
+// do not fit into the block then, they stay in the mempool for the next one.
+func (mpi *mempoolImpl) estimateGas(req isc.Request, chainInfo *isc.ChainInfo) uint64 {
+	budget, isEVM := req.GasBudget()
+	if isEVM {
+		// The gas limit of the EVM transactions is usually estimated by the wallet already.
+		budget = gas.EVMGasToISC(budget, &chainInfo.GasFeePolicy.EVMGasRatio)
+	} else if estimate, ok := mpi.requestGasEstimate(req); ok && estimate < budget {
+		budget = estimate
+	}
+	return min(budget, chainInfo.GasLimits.MaxGasPerRequest)
+}
+
+func (mpi *mempoolImpl) requestGasEstimate(req isc.Request) (uint64, bool) {
+	if key, ok := requestGasKeyOf(req); ok {
+		if estimate, ok := mpi.requestGasEstimates[key]; ok {
+			return estimate, true
+		}
+	}
+	estimate, ok := mpi.gasEstimates[req.CallTarget()]
+	return estimate, ok
+}
+
+// Selects the requests to propose, in the given order, while their estimated
+// gas fits the gas limit of the block, and their size fits MaxProposalBytes.
+// The requests that do not fit are skipped, as well as the following off-ledger
+// requests of the same sender, so that no nonce gaps are introduced. The first
+// request is always proposed, even if it does not fit alone.
+func (mpi *mempoolImpl) proposalSubset(reqs []isc.Request) []*isc.RequestRef {
+	chainInfo := governance.NewStateAccess(mpi.chainHeadState).ChainInfo(mpi.chainID)
+	reqRefs := []*isc.RequestRef{}
+	skippedAccounts := map[string]struct{}{}
+	var totalGas uint64
+	var totalBytes int
+	for _, req := range reqs {
+		account := accountKey(req)
+		if req.IsOffLedger() {
+			if _, skipped := skippedAccounts[account]; skipped {
+				continue
+			}
+		}
+		reqGas := mpi.estimateGas(req, chainInfo)
+		reqBytes := len(req.Bytes())
+		fits := totalGas+reqGas <= chainInfo.GasLimits.MaxGasPerBlock &&
+			(mpi.params.MaxProposalBytes <= 0 || totalBytes+reqBytes <= mpi.params.MaxProposalBytes)
+		if !fits && len(reqRefs) > 0 {
+			mpi.log.Debugf("proposalSubset, request %v does not fit the block, gas=%v, bytes=%v", req.ID(), reqGas, reqBytes)
+			if req.IsOffLedger() {
+				skippedAccounts[account] = struct{}{}
+			}
+			continue
+		}
+		totalGas += reqGas
+		totalBytes += reqBytes
+		reqRefs = append(reqRefs, isc.RequestRefFromRequest(req))
+	}
+	mpi.log.Debugf("proposalSubset, proposing %v of %v requests, estimated gas=%v, bytes=%v", len(reqRefs), len(reqs), totalGas, totalBytes)
+	return reqRefs
+}
+
+func accountKey(request isc.Request) string {
+	if sender := request.SenderAccount(); sender != nil {
+		return sender.String()
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
+
+// Keeps track of the requests added to and removed from a pool.
+type PoolIndex interface {
+	Added(request isc.Request, ts time.Time)
+	Removed(request isc.Request)
+}
+
+// RequestIndex keeps all the requests in the pools in the order they were
+// added, so that the node operators can page over them without copying the
+// pools. Each request is given an increasing sequence number when it is
+// added to a pool, the page following a request starts after its number.
+// A request moved from one pool to another is indexed again. The index also
+// keeps the encapsulated keys of the encrypted requests, to detect the reused
+// keys.
+type RequestIndex struct {
+	entries          []*requestIndexEntry // ordered by PoolRequest.Seq
+	byRef            map[isc.RequestRefKey]*requestIndexEntry
+	byID             map[isc.RequestID]*requestIndexEntry
+	encapsulatedKeys map[hashing.HashValue]isc.RequestRefKey
+	poolSizes        map[string]int
+	lastSeq          uint64
+	removed          int
+}
+
+type requestIndexEntry struct {
+	req     *PoolRequest
+	removed bool
+}
+
+func NewRequestIndex() *RequestIndex {
+	return &RequestIndex{
+		entries:          []*requestIndexEntry{},
+		byRef:            map[isc.RequestRefKey]*requestIndexEntry{},
+		byID:             map[isc.RequestID]*requestIndexEntry{},
+		encapsulatedKeys: map[hashing.HashValue]isc.RequestRefKey{},
+		poolSizes:        map[string]int{},
+	}
+}
+
+// ForPool returns the PoolIndex to be used by the specified pool.
+func (ri *RequestIndex) ForPool(pool string) PoolIndex {
+	return &poolIndex{index: ri, pool: pool}
+}
+
+func (ri *RequestIndex) add(pool string, request isc.Request, ts time.Time) {
+	ri.remove("", request)
+	ri.lastSeq++
+	entry := &requestIndexEntry{req: &PoolRequest{Request: request, Pool: pool, Timestamp: ts, Seq: ri.lastSeq}}
+	ri.entries = append(ri.entries, entry)
+	refKey := isc.RequestRefFromRequest(request).AsKey()
+	ri.byRef[refKey] = entry
+	ri.byID[request.ID()] = entry
+	ri.poolSizes[pool]++
+	if keyID, ok := encapsulatedKeyID(request); ok {
+		ri.encapsulatedKeys[keyID] = refKey
+	}
+}
+
+// Removes the request, if it is indexed in the specified pool (or in any, if empty).
+func (ri *RequestIndex) remove(pool string, request isc.Request) {
+	refKey := isc.RequestRefFromRequest(request).AsKey()
+	entry, ok := ri.byRef[refKey]
+	if !ok || (pool != "" && entry.req.Pool != pool) {
+		return
+	}
+	delete(ri.byRef, refKey)
+	if ri.byID[request.ID()] == entry {
+		delete(ri.byID, request.ID())
+	}
+	if keyID, ok := encapsulatedKeyID(request); ok && ri.encapsulatedKeys[keyID] == refKey {
+		delete(ri.encapsulatedKeys, keyID)
+	}
+	ri.poolSizes[entry.req.Pool]--
+	if ri.poolSizes[entry.req.Pool] == 0 {
+		delete(ri.poolSizes, entry.req.Pool)
+	}
+	entry.removed = true
+	ri.removed++
+	if ri.removed > len(ri.entries)/2 {
+		ri.compact()
+	}
+}
+
+// The removed entries are only marked, and dropped once they are the majority.
+func (ri *RequestIndex) compact() {
+	entries := make([]*requestIndexEntry, 0, len(ri.entries)-ri.removed)
+	for _, entry := range ri.entries {
+		if !entry.removed {
+			entries = append(entries, entry)
+		}
+	}
+	ri.entries = entries
+	ri.removed = 0
+}
+
+// Page returns at most limit requests added after the one with the sequence
+// number after, in the order they were added. Use 0 to get the first page.
+func (ri *RequestIndex) Page(after uint64, limit int) []*PoolRequest {
+	res := []*PoolRequest{}
+	i := sort.Search(len(ri.entries), func(i int) bool {
+		return ri.entries[i].req.Seq > after
+	})
+	for ; i < len(ri.entries) && len(res) < limit; i++ {
+		if !ri.entries[i].removed {
+			res = append(res, ri.entries[i].req)
+		}
+	}
+	return res
+}
+
+// Get returns the request with the ID, and the pool it is in, or nil.
+func (ri *RequestIndex) Get(requestID isc.RequestID) *PoolRequest {
+	if entry, ok := ri.byID[requestID]; ok {
+		return entry.req
+	}
+	return nil
+}
+
+// PoolSizes returns the number of requests in each of the pools.
+func (ri *RequestIndex) PoolSizes() map[string]int {
+	res := make(map[string]int, len(ri.poolSizes))
+	for pool, size := range ri.poolSizes {
+		res[pool] = size
+	}
+	return res
+}
+
+// HasEncapsulatedKey checks, if an encrypted request with the same key is
+// in the pools already.
+func (ri *RequestIndex) HasEncapsulatedKey(encapsulatedKey []byte) bool {
+	keyID, err := tcrypto.EncapsulatedKeyID(tcrypto.DefaultBLSSuite(), encapsulatedKey)
+	if err != nil {
+		return false
+	}
+	_, ok := ri.encapsulatedKeys[keyID]
+	return ok
+}
+
+// EncryptedRequests returns the encrypted requests in the pools.
+func (ri *RequestIndex) EncryptedRequests() []isc.EncryptedOffLedgerRequest {
+	res := make([]isc.EncryptedOffLedgerRequest, 0, len(ri.encapsulatedKeys))
+	for _, refKey := range ri.encapsulatedKeys {
+		res = append(res, ri.byRef[refKey].req.Request.(isc.EncryptedOffLedgerRequest))
+	}
+	return res
+}
+
+func encapsulatedKeyID(request isc.Request) (hashing.HashValue, bool) {
+	encryptedReq, ok := request.(isc.EncryptedOffLedgerRequest)
+	if !ok {
+		return hashing.NilHash, false
+	}
+	keyID, err := tcrypto.EncapsulatedKeyID(tcrypto.DefaultBLSSuite(), encryptedReq.EncapsulatedKey())
+	return keyID, err == nil
+}
+
+type poolIndex struct {
+	index *RequestIndex
+	pool  string
+}
+
+func (pi *poolIndex) Added(request isc.Request, ts time.Time) {
+	pi.index.add(pi.pool, request, ts)
+}
+
+func (pi *poolIndex) Removed(request isc.Request) {
+	pi.index.remove(pi.pool, request)
+}
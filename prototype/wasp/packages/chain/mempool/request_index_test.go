@@ -0,0 +1,88 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/testutil"
+	"github.com/iotaledger/wasp/packages/testutil/testkey"
+)
+
+func TestRequestIndex(t *testing.T) {
+	index := NewRequestIndex()
+	onLedger := index.ForPool(PoolOnLedger)
+	offLedger := index.ForPool(PoolOffLedger)
+
+	kp, _ := testkey.GenKeyAddr()
+	reqs := []isc.Request{}
+	for nonce := uint64(0); nonce < 6; nonce++ {
+		req := testutil.DummyOffledgerRequestForAccount(isc.RandomChainID(), nonce, kp)
+		reqs = append(reqs, req)
+		offLedger.Added(req, time.Now())
+	}
+	require.Equal(t, map[string]int{PoolOffLedger: 6}, index.PoolSizes())
+	require.Equal(t, reqs[3], index.Get(reqs[3].ID()).Request)
+	require.Equal(t, PoolOffLedger, index.Get(reqs[3].ID()).Pool)
+
+	// a request indexed in another pool is not removed
+	onLedger.Removed(reqs[0])
+	require.Equal(t, map[string]int{PoolOffLedger: 6}, index.PoolSizes())
+
+	page := index.Page(0, 4)
+	require.Len(t, page, 4)
+	for i, poolReq := range page {
+		require.Equal(t, reqs[i], poolReq.Request)
+	}
+
+	// removed requests are skipped, also after the entries are compacted
+	for _, req := range reqs[1:5] {
+		offLedger.Removed(req)
+	}
+	require.Equal(t, map[string]int{PoolOffLedger: 2}, index.PoolSizes())
+	require.Nil(t, index.Get(reqs[3].ID()))
+	page = index.Page(page[0].Seq, 4)
+	require.Len(t, page, 1)
+	require.Equal(t, reqs[5], page[0].Request)
+
+	// a request moved to another pool is listed again, after the rest
+	onLedger.Added(reqs[0], time.Now())
+	require.Equal(t, map[string]int{PoolOffLedger: 1, PoolOnLedger: 1}, index.PoolSizes())
+	page = index.Page(0, 4)
+	require.Len(t, page, 2)
+	require.Equal(t, reqs[5], page[0].Request)
+	require.Equal(t, reqs[0], page[1].Request)
+	require.Equal(t, PoolOnLedger, page[1].Pool)
+}
+
+func TestRequestIndexEncapsulatedKeys(t *testing.T) {
+	index := NewRequestIndex()
+	offLedger := index.ForPool(PoolOffLedger)
+
+	suite := tcrypto.DefaultBLSSuite()
+	keyEncapsulation, err := tcrypto.NewKeyEncapsulation(suite, suite.Point().Pick(suite.RandomStream()))
+	require.NoError(t, err)
+	kp, _ := testkey.GenKeyAddr()
+	req := isc.NewEncryptedOffLedgerRequest(isc.RandomChainID(), 3, 14, dict.New(), 0, 100, 10, keyEncapsulation).Sign(kp)
+	require.False(t, index.HasEncapsulatedKey(req.EncapsulatedKey()))
+
+	offLedger.Added(req, time.Now())
+	require.True(t, index.HasEncapsulatedKey(req.EncapsulatedKey()))
+	require.Equal(t, []isc.EncryptedOffLedgerRequest{req}, index.EncryptedRequests())
+
+	// the same key is detected in another request of another sender
+	otherKP, _ := testkey.GenKeyAddr()
+	otherReq := isc.NewEncryptedOffLedgerRequest(isc.RandomChainID(), 3, 15, dict.New(), 0, 100, 10, keyEncapsulation).Sign(otherKP)
+	require.True(t, index.HasEncapsulatedKey(otherReq.EncapsulatedKey()))
+
+	offLedger.Removed(req)
+	require.False(t, index.HasEncapsulatedKey(req.EncapsulatedKey()))
+	require.Empty(t, index.EncryptedRequests())
+}
@@ -17,6 +17,8 @@ type TimePool interface {
 	AddRequest(timestamp time.Time, request isc.Request)
 	TakeTill(timestamp time.Time) []isc.Request
 	Has(reqID *isc.RequestRef) bool
+	// Removes the request added with the timestamp.
+	Remove(timestamp time.Time, request isc.Request)
 	Filter(predicate func(request isc.Request, ts time.Time) bool)
 }
 
@@ -26,6 +28,7 @@ type TimePool interface {
 type timePoolImpl struct {
 	requests   *shrinkingmap.ShrinkingMap[isc.RequestRefKey, isc.Request] // All the requests in this pool.
 	slots      *timeSlot                                                  // Structure to fetch them fast by their time.
+	index      PoolIndex
 	sizeMetric func(int)
 	log        *logger.Logger
 }
@@ -41,10 +44,11 @@ const slotPrecision = time.Minute
 
 var _ TimePool = &timePoolImpl{}
 
-func NewTimePool(sizeMetric func(int), log *logger.Logger) TimePool {
+func NewTimePool(index PoolIndex, sizeMetric func(int), log *logger.Logger) TimePool {
 	return &timePoolImpl{
 		requests:   shrinkingmap.New[isc.RequestRefKey, isc.Request](),
 		slots:      nil,
+		index:      index,
 		sizeMetric: sizeMetric,
 		log:        log,
 	}
@@ -59,6 +63,7 @@ func (tpi *timePoolImpl) AddRequest(timestamp time.Time, request isc.Request) {
 
 	if tpi.requests.Set(reqRefKey, request) {
 		tpi.log.Debugf("ADD %v as key=%v", request.ID(), reqRefKey)
+		tpi.index.Added(request, timestamp)
 	}
 	tpi.sizeMetric(tpi.requests.Size())
 
@@ -101,6 +106,7 @@ func (tpi *timePoolImpl) TakeTill(timestamp time.Time) []isc.Request {
 					reqRefKey := isc.RequestRefFromRequest(req).AsKey()
 					if tpi.requests.Delete(reqRefKey) {
 						tpi.log.Debugf("DEL %v as key=%v", req.ID(), reqRefKey)
+						tpi.index.Removed(req)
 					}
 				}
 				tpi.sizeMetric(tpi.requests.Size())
@@ -121,6 +127,38 @@ func (tpi *timePoolImpl) Has(reqRef *isc.RequestRef) bool {
 	return tpi.requests.Has(reqRef.AsKey())
 }
 
+func (tpi *timePoolImpl) Remove(timestamp time.Time, request isc.Request) {
+	reqRefKey := isc.RequestRefFromRequest(request).AsKey()
+	if !tpi.requests.Delete(reqRefKey) {
+		return
+	}
+	tpi.log.Debugf("DEL %v as key=%v", request.ID(), reqRefKey)
+	tpi.index.Removed(request)
+	tpi.sizeMetric(tpi.requests.Size())
+
+	reqFrom, _ := tpi.timestampSlotBounds(timestamp)
+	prevNext := &tpi.slots
+	for slot := tpi.slots; slot != nil && !slot.from.After(reqFrom); slot = slot.next {
+		if slot.from != reqFrom {
+			prevNext = &slot.next
+			continue
+		}
+		requests, _ := slot.reqs.Get(timestamp)
+		requests = slices.DeleteFunc(slices.Clone(requests), func(req isc.Request) bool {
+			return isc.RequestRefFromRequest(req).AsKey() == reqRefKey
+		})
+		if len(requests) != 0 {
+			slot.reqs.Set(timestamp, requests)
+		} else {
+			slot.reqs.Delete(timestamp)
+		}
+		if slot.reqs.Size() == 0 {
+			*prevNext = slot.next
+		}
+		return
+	}
+}
+
 func (tpi *timePoolImpl) Filter(predicate func(request isc.Request, ts time.Time) bool) {
 	prevNext := &tpi.slots
 	for slot := tpi.slots; slot != nil; slot = slot.next {
@@ -132,6 +170,7 @@ func (tpi *timePoolImpl) Filter(predicate func(request isc.Request, ts time.Time
 					reqRefKey := isc.RequestRefFromRequest(req).AsKey()
 					if tpi.requests.Delete(reqRefKey) {
 						tpi.log.Debugf("DEL %v as key=%v", req.ID(), reqRefKey)
+						tpi.index.Removed(req)
 					}
 				}
 			}
@@ -21,7 +21,7 @@ import (
 func TestTimePoolBasic(t *testing.T) {
 	log := testlogger.NewLogger(t)
 	kp := cryptolib.NewKeyPair()
-	tp := mempool.NewTimePool(func(i int) {}, log)
+	tp := mempool.NewTimePool(mempool.NewRequestIndex().ForPool(mempool.PoolTimeLocked), func(i int) {}, log)
 	t0 := time.Now()
 	t1 := t0.Add(17 * time.Nanosecond)
 	t2 := t0.Add(17 * time.Minute)
@@ -74,6 +74,37 @@ func TestTimePoolBasic(t *testing.T) {
 	require.False(t, tp.Has(isc.RequestRefFromRequest(r3)))
 }
 
+func TestTimePoolRemove(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	kp := cryptolib.NewKeyPair()
+	index := mempool.NewRequestIndex()
+	tp := mempool.NewTimePool(index.ForPool(mempool.PoolTimeLocked), func(i int) {}, log)
+	t0 := time.Now()
+	t1 := t0.Add(17 * time.Nanosecond)
+	t2 := t0.Add(17 * time.Minute)
+	r0 := isc.NewOffLedgerRequest(isc.RandomChainID(), governance.Contract.Hname(), governance.FuncAddCandidateNode.Hname(), nil, 0, gas.LimitsDefault.MaxGasPerRequest).Sign(kp)
+	r1 := isc.NewOffLedgerRequest(isc.RandomChainID(), governance.Contract.Hname(), governance.FuncAddCandidateNode.Hname(), nil, 1, gas.LimitsDefault.MaxGasPerRequest).Sign(kp)
+	r2 := isc.NewOffLedgerRequest(isc.RandomChainID(), governance.Contract.Hname(), governance.FuncAddCandidateNode.Hname(), nil, 2, gas.LimitsDefault.MaxGasPerRequest).Sign(kp)
+	tp.AddRequest(t0, r0)
+	tp.AddRequest(t1, r1)
+	tp.AddRequest(t2, r2)
+	require.Equal(t, t1, index.Get(r1.ID()).Timestamp)
+
+	// from a slot with other requests
+	tp.Remove(t1, r1)
+	require.False(t, tp.Has(isc.RequestRefFromRequest(r1)))
+	require.Nil(t, index.Get(r1.ID()))
+	// the last one of a slot
+	tp.Remove(t2, r2)
+	require.False(t, tp.Has(isc.RequestRefFromRequest(r2)))
+	// not in the pool anymore
+	tp.Remove(t2, r2)
+	require.Equal(t, map[string]int{mempool.PoolTimeLocked: 1}, index.PoolSizes())
+
+	taken := tp.TakeTill(t0.Add(time.Hour))
+	require.Equal(t, []isc.Request{r0}, taken)
+}
+
 func TestTimePoolRapid(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		sm := newtimePoolSM(t)
@@ -93,7 +124,7 @@ var _ rapid.StateMachine = &timePoolSM{}
 func newtimePoolSM(t *rapid.T) *timePoolSM {
 	sm := new(timePoolSM)
 	log := testlogger.NewLogger(t)
-	sm.tp = mempool.NewTimePool(func(i int) {}, log)
+	sm.tp = mempool.NewTimePool(mempool.NewRequestIndex().ForPool(mempool.PoolTimeLocked), func(i int) {}, log)
 	sm.kp = cryptolib.NewKeyPair()
 	sm.added = 0
 	sm.taken = 0
@@ -13,11 +13,16 @@ import (
 )
 
 type typedPool[V isc.Request] struct {
-	waitReq    WaitReq
-	requests   *shrinkingmap.ShrinkingMap[isc.RequestRefKey, *typedPoolEntry[V]]
-	sizeMetric func(int)
-	timeMetric func(time.Duration)
-	log        *logger.Logger
+	waitReq        WaitReq
+	index          PoolIndex
+	requests       *shrinkingmap.ShrinkingMap[isc.RequestRefKey, *typedPoolEntry[V]]
+	countByAccount *shrinkingmap.ShrinkingMap[string, int] // string is isc.AgentID.String()
+	maxSize        int
+	maxPerAccount  int
+	sizeMetric     func(int)
+	timeMetric     func(time.Duration)
+	refusedCB      func(V)
+	log            *logger.Logger
 }
 
 type typedPoolEntry[V isc.Request] struct {
@@ -27,13 +32,29 @@ type typedPoolEntry[V isc.Request] struct {
 
 var _ RequestPool[isc.OffLedgerRequest] = &typedPool[isc.OffLedgerRequest]{}
 
-func NewTypedPool[V isc.Request](waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) RequestPool[V] {
+// The pool keeps at most maxSize requests and at most maxPerAccount requests
+// of a single sender (0 means unlimited). Nothing is ever evicted: a request
+// exceeding a limit is not added and is passed to refusedCB instead.
+func NewTypedPool[V isc.Request](
+	waitReq WaitReq,
+	index PoolIndex,
+	maxSize, maxPerAccount int,
+	sizeMetric func(int),
+	timeMetric func(time.Duration),
+	refusedCB func(V),
+	log *logger.Logger,
+) RequestPool[V] {
 	return &typedPool[V]{
-		waitReq:    waitReq,
-		requests:   shrinkingmap.New[isc.RequestRefKey, *typedPoolEntry[V]](),
-		sizeMetric: sizeMetric,
-		timeMetric: timeMetric,
-		log:        log,
+		waitReq:        waitReq,
+		index:          index,
+		requests:       shrinkingmap.New[isc.RequestRefKey, *typedPoolEntry[V]](),
+		countByAccount: shrinkingmap.New[string, int](),
+		maxSize:        maxSize,
+		maxPerAccount:  maxPerAccount,
+		sizeMetric:     sizeMetric,
+		timeMetric:     timeMetric,
+		refusedCB:      refusedCB,
+		log:            log,
 	}
 }
 
@@ -51,8 +72,20 @@ func (olp *typedPool[V]) Get(reqRef *isc.RequestRef) V {
 
 func (olp *typedPool[V]) Add(request V) {
 	refKey := isc.RequestRefFromRequest(request).AsKey()
-	if olp.requests.Set(refKey, &typedPoolEntry[V]{req: request, ts: time.Now()}) {
+	if !olp.requests.Has(refKey) {
+		account := accountKey(request)
+		count, _ := olp.countByAccount.Get(account)
+		if (olp.maxSize > 0 && olp.requests.Size() >= olp.maxSize) ||
+			(olp.maxPerAccount > 0 && count >= olp.maxPerAccount) {
+			olp.log.Debugf("REFUSE %v", request.ID())
+			olp.refusedCB(request)
+			return
+		}
+		entry := &typedPoolEntry[V]{req: request, ts: time.Now()}
+		olp.requests.Set(refKey, entry)
+		olp.countByAccount.Set(account, count+1)
 		olp.log.Debugf("ADD %v as key=%v", request.ID(), refKey)
+		olp.index.Added(request, entry.ts)
 		olp.sizeMetric(olp.requests.Size())
 	}
 	olp.waitReq.MarkAvailable(request)
@@ -63,6 +96,8 @@ func (olp *typedPool[V]) Remove(request V) {
 	if entry, ok := olp.requests.Get(refKey); ok {
 		if olp.requests.Delete(refKey) {
 			olp.log.Debugf("DEL %v as key=%v", request.ID(), refKey)
+			olp.index.Removed(request)
+			olp.decAccountCount(request)
 		}
 		olp.sizeMetric(olp.requests.Size())
 		olp.timeMetric(time.Since(entry.ts))
@@ -74,6 +109,8 @@ func (olp *typedPool[V]) Filter(predicate func(request V, ts time.Time) bool) {
 		if !predicate(entry.req, entry.ts) {
 			if olp.requests.Delete(refKey) {
 				olp.log.Debugf("DEL %v as key=%v", entry.req.ID(), refKey)
+				olp.index.Removed(entry.req)
+				olp.decAccountCount(entry.req)
 				olp.timeMetric(time.Since(entry.ts))
 			}
 		}
@@ -82,6 +119,16 @@ func (olp *typedPool[V]) Filter(predicate func(request V, ts time.Time) bool) {
 	olp.sizeMetric(olp.requests.Size())
 }
 
+func (olp *typedPool[V]) decAccountCount(request V) {
+	account := accountKey(request)
+	count, _ := olp.countByAccount.Get(account)
+	if count <= 1 {
+		olp.countByAccount.Delete(account)
+		return
+	}
+	olp.countByAccount.Set(account, count-1)
+}
+
 func (olp *typedPool[V]) StatusString() string {
 	return fmt.Sprintf("{|req|=%d}", olp.requests.Size())
 }
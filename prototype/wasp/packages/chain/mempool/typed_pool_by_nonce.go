@@ -4,6 +4,8 @@
 package mempool
 
 import (
+	"bytes"
+	"container/heap"
 	"fmt"
 	"slices"
 	"time"
@@ -11,28 +13,57 @@ import (
 	"github.com/iotaledger/hive.go/ds/shrinkingmap"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 // keeps a map of requests ordered by nonce for each account
 type TypedPoolByNonce[V isc.OffLedgerRequest] struct {
 	waitReq WaitReq
+	index   PoolIndex
 	refLUT  *shrinkingmap.ShrinkingMap[isc.RequestRefKey, *OrderedPoolEntry[V]]
 	// reqsByAcountOrdered keeps an ordered map of reqsByAcountOrdered for each account by nonce
 	reqsByAcountOrdered *shrinkingmap.ShrinkingMap[string, []*OrderedPoolEntry[V]] // string is isc.AgentID.String()
-	sizeMetric          func(int)
-	timeMetric          func(time.Duration)
-	log                 *logger.Logger
+	// evictable keeps the highest nonce of each account, ordered for eviction
+	evictable     evictionHeap[V]
+	maxSize       int
+	maxPerAccount int
+	sizeMetric    func(int)
+	timeMetric    func(time.Duration)
+	evictedCB     func(V)
+	log           *logger.Logger
 }
 
 var _ RequestPool[isc.OffLedgerRequest] = &TypedPoolByNonce[isc.OffLedgerRequest]{}
 
-func NewTypedPoolByNonce[V isc.OffLedgerRequest](waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) *TypedPoolByNonce[V] {
+// The pool keeps at most maxSize requests and at most maxPerAccount requests
+// of a single sender (0 means unlimited). When a limit is exceeded, a request
+// is evicted and passed to evictedCB. Only the request with the highest nonce
+// of an account can be evicted, so that no nonce gaps are introduced:
+//   - if the account has too many requests, its highest nonce is evicted;
+//   - if the pool is full, the one with the lowest priority (or the newest
+//     one) among the highest nonces of all the accounts is evicted.
+//
+// The priority depends on the gas fee policy of the chain, see SetGasFeePolicy.
+func NewTypedPoolByNonce[V isc.OffLedgerRequest](
+	waitReq WaitReq,
+	index PoolIndex,
+	maxSize, maxPerAccount int,
+	sizeMetric func(int),
+	timeMetric func(time.Duration),
+	evictedCB func(V),
+	log *logger.Logger,
+) *TypedPoolByNonce[V] {
 	return &TypedPoolByNonce[V]{
 		waitReq:             waitReq,
+		index:               index,
 		reqsByAcountOrdered: shrinkingmap.New[string, []*OrderedPoolEntry[V]](),
 		refLUT:              shrinkingmap.New[isc.RequestRefKey, *OrderedPoolEntry[V]](),
+		evictable:           evictionHeap[V]{gasFeePolicy: gas.DefaultFeePolicy()},
+		maxSize:             maxSize,
+		maxPerAccount:       maxPerAccount,
 		sizeMetric:          sizeMetric,
 		timeMetric:          timeMetric,
+		evictedCB:           evictedCB,
 		log:                 log,
 	}
 }
@@ -41,6 +72,8 @@ type OrderedPoolEntry[V isc.OffLedgerRequest] struct {
 	req V
 	old bool
 	ts  time.Time
+	// position in the eviction heap, -1 if not there
+	evictionIndex int
 }
 
 func (p *TypedPoolByNonce[V]) Has(reqRef *isc.RequestRef) bool {
@@ -57,7 +90,7 @@ func (p *TypedPoolByNonce[V]) Get(reqRef *isc.RequestRef) V {
 
 func (p *TypedPoolByNonce[V]) Add(request V) {
 	ref := isc.RequestRefFromRequest(request)
-	entry := &OrderedPoolEntry[V]{req: request, ts: time.Now()}
+	entry := &OrderedPoolEntry[V]{req: request, ts: time.Now(), evictionIndex: -1}
 	account := request.SenderAccount().String()
 
 	if !p.refLUT.Set(ref.AsKey(), entry) {
@@ -65,10 +98,16 @@ func (p *TypedPoolByNonce[V]) Add(request V) {
 		return // not added already exists
 	}
 
+	p.index.Added(request, entry.ts)
+	prevLast := p.lastOfAccount(account)
 	defer func() {
 		p.log.Debugf("ADD %v as key=%v, senderAccount: %s", request.ID(), ref, account)
+		p.updateEvictable(prevLast, p.lastOfAccount(account))
+		p.evictOverLimit(account)
 		p.sizeMetric(p.refLUT.Size())
-		p.waitReq.MarkAvailable(request)
+		if p.refLUT.Has(ref.AsKey()) {
+			p.waitReq.MarkAvailable(request)
+		}
 	}()
 
 	reqsForAcount, exists := p.reqsByAcountOrdered.Get(account)
@@ -110,20 +149,77 @@ func (p *TypedPoolByNonce[V]) Add(request V) {
 	p.reqsByAcountOrdered.Set(account, reqsForAcount)
 }
 
+func (p *TypedPoolByNonce[V]) evictOverLimit(account string) {
+	if reqsForAccount, exists := p.reqsByAcountOrdered.Get(account); exists && p.maxPerAccount > 0 && len(reqsForAccount) > p.maxPerAccount {
+		p.evict(reqsForAccount[len(reqsForAccount)-1])
+	}
+	if p.maxSize <= 0 || p.refLUT.Size() <= p.maxSize {
+		return
+	}
+	if evict := p.evictable.top(); evict != nil {
+		p.evict(evict)
+	}
+}
+
+func (p *TypedPoolByNonce[V]) lastOfAccount(account string) *OrderedPoolEntry[V] {
+	entries, exists := p.reqsByAcountOrdered.Get(account)
+	if !exists || len(entries) == 0 {
+		return nil
+	}
+	return entries[len(entries)-1]
+}
+
+// updateEvictable replaces the highest nonce entry of an account in the eviction heap
+func (p *TypedPoolByNonce[V]) updateEvictable(prevLast, last *OrderedPoolEntry[V]) {
+	if prevLast == last {
+		return
+	}
+	if prevLast != nil && prevLast.evictionIndex >= 0 {
+		heap.Remove(&p.evictable, prevLast.evictionIndex)
+	}
+	if last != nil {
+		heap.Push(&p.evictable, last)
+	}
+}
+
+// SetGasFeePolicy sets the gas fee policy used to compare the priority of the
+// requests, it should be the one of the latest chain state.
+func (p *TypedPoolByNonce[V]) SetGasFeePolicy(gasFeePolicy *gas.FeePolicy) {
+	if bytes.Equal(p.evictable.gasFeePolicy.Bytes(), gasFeePolicy.Bytes()) {
+		return
+	}
+	p.evictable.gasFeePolicy = gasFeePolicy
+	heap.Init(&p.evictable)
+}
+
+// GasFeePolicy returns the gas fee policy used to compare the priority of the requests
+func (p *TypedPoolByNonce[V]) GasFeePolicy() *gas.FeePolicy {
+	return p.evictable.gasFeePolicy
+}
+
+func (p *TypedPoolByNonce[V]) evict(entry *OrderedPoolEntry[V]) {
+	p.log.Debugf("EVICT %v, senderAccount: %s", entry.req.ID(), entry.req.SenderAccount().String())
+	p.Remove(entry.req)
+	p.evictedCB(entry.req)
+}
+
 func (p *TypedPoolByNonce[V]) Remove(request V) {
 	refKey := isc.RequestRefFromRequest(request).AsKey()
 	entry, exists := p.refLUT.Get(refKey)
 	if !exists {
 		return // does not exist
 	}
+	account := entry.req.SenderAccount().String()
+	prevLast := p.lastOfAccount(account)
 	defer func() {
+		p.updateEvictable(prevLast, p.lastOfAccount(account))
 		p.sizeMetric(p.refLUT.Size())
 		p.timeMetric(time.Since(entry.ts))
 	}()
 	if p.refLUT.Delete(refKey) {
 		p.log.Debugf("DEL %v as key=%v", request.ID(), refKey)
+		p.index.Removed(request)
 	}
-	account := entry.req.SenderAccount().String()
 	reqsByAccount, exists := p.reqsByAcountOrdered.Get(account)
 	if !exists {
 		p.log.Error("inconsistency trying to DEL %v as key=%v, no request list for account %s", request.ID(), refKey, account)
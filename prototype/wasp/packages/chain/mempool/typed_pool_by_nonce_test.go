@@ -6,15 +6,18 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/testutil"
 	"github.com/iotaledger/wasp/packages/testutil/testkey"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 func TestSomething(t *testing.T) {
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
-	pool := NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
+	pool := NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, NewRequestIndex().ForPool(PoolOffLedger), 0, 0, func(int) {}, func(time.Duration) {}, func(isc.OffLedgerRequest) {}, testlogger.NewSilentLogger("", true))
 
 	// generate a bunch of requests for the same account
 	kp, addr := testkey.GenKeyAddr()
@@ -49,3 +52,54 @@ func TestSomething(t *testing.T) {
 	require.EqualValues(t, 0, pool.refLUT.Size())
 	require.EqualValues(t, 0, pool.reqsByAcountOrdered.Size())
 }
+
+func TestTypedPoolByNonceLimits(t *testing.T) {
+	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	evicted := []isc.OffLedgerRequest{}
+	pool := NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, NewRequestIndex().ForPool(PoolOffLedger), 4, 3, func(int) {}, func(time.Duration) {}, func(req isc.OffLedgerRequest) {
+		evicted = append(evicted, req)
+	}, testlogger.NewSilentLogger("", true))
+
+	withTip := func(kp *cryptolib.KeyPair, nonce, tip uint64) isc.OffLedgerRequest {
+		return isc.NewOffLedgerRequest(isc.RandomChainID(), isc.Hn("somecontract"), isc.Hn("someentrypoint"), dict.Dict{}, nonce, gas.LimitsDefault.MaxGasPerRequest).
+			WithMaxFee(isc.Million, tip).
+			Sign(kp)
+	}
+	kp1, _ := testkey.GenKeyAddr()
+	kp2, _ := testkey.GenKeyAddr()
+
+	// too many requests for a single account: the highest nonce is evicted
+	reqs1 := []isc.OffLedgerRequest{withTip(kp1, 0, 10), withTip(kp1, 1, 10), withTip(kp1, 3, 10)}
+	for _, req := range reqs1 {
+		pool.Add(req)
+	}
+	req12 := withTip(kp1, 2, 10)
+	pool.Add(req12)
+	require.Equal(t, []isc.OffLedgerRequest{reqs1[2]}, evicted)
+	require.EqualValues(t, 3, pool.refLUT.Size())
+
+	// the pool is full: the highest nonce of the account paying less is evicted
+	req20 := withTip(kp2, 0, 5)
+	pool.Add(req20)
+	require.EqualValues(t, 4, pool.refLUT.Size())
+	pool.Add(withTip(kp2, 1, 20))
+	require.Len(t, evicted, 2)
+	require.Equal(t, req12, evicted[1]) // req20 pays less, but it is not the highest nonce of kp2
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req20)))
+
+	// a request paying less than the others is evicted right away
+	lowTip := withTip(kp1, 2, 1)
+	pool.Add(lowTip)
+	require.Len(t, evicted, 3)
+	require.Equal(t, lowTip, evicted[2])
+	require.False(t, pool.Has(isc.RequestRefFromRequest(lowTip)))
+	require.EqualValues(t, 4, pool.refLUT.Size())
+
+	// a request paying the same as the lowest one is evicted instead of it
+	kp3, _ := testkey.GenKeyAddr()
+	sameTip := withTip(kp3, 0, 10)
+	pool.Add(sameTip)
+	require.Len(t, evicted, 4)
+	require.Equal(t, sameTip, evicted[3])
+	require.EqualValues(t, 4, pool.refLUT.Size())
+}
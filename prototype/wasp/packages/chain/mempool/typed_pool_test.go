@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/tpkg"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/transaction"
+)
+
+func TestTypedPoolLimits(t *testing.T) {
+	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	refused := []isc.OnLedgerRequest{}
+	pool := NewTypedPool[isc.OnLedgerRequest](waitReq, NewRequestIndex().ForPool(PoolOnLedger), 4, 3, func(int) {}, func(time.Duration) {}, func(req isc.OnLedgerRequest) {
+		refused = append(refused, req)
+	}, testlogger.NewSilentLogger("", true))
+
+	chainAddress := tpkg.RandAliasAddress()
+	outputIndex := uint16(0)
+	onLedgerFrom := func(sender iotago.Address) isc.OnLedgerRequest {
+		output := transaction.BasicOutputFromPostData(
+			sender,
+			isc.EmptyContractIdentity(),
+			isc.RequestParameters{
+				TargetAddress: chainAddress,
+				Metadata: &isc.SendMetadata{
+					TargetContract: isc.Hn("dummyTargetContract"),
+					EntryPoint:     isc.Hn("dummyEP"),
+					Params:         dict.New(),
+					GasBudget:      1000,
+				},
+				AdjustToMinimumStorageDeposit: true,
+			},
+		)
+		outputIndex++
+		req, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(outputIndex))
+		require.NoError(t, err)
+		return req
+	}
+	sender1 := tpkg.RandEd25519Address()
+	sender2 := tpkg.RandEd25519Address()
+
+	// too many requests of a single sender: the new one is refused
+	reqs1 := []isc.OnLedgerRequest{onLedgerFrom(sender1), onLedgerFrom(sender1), onLedgerFrom(sender1)}
+	for _, req := range reqs1 {
+		pool.Add(req)
+	}
+	req14 := onLedgerFrom(sender1)
+	pool.Add(req14)
+	require.Equal(t, []isc.OnLedgerRequest{req14}, refused)
+	require.False(t, pool.Has(isc.RequestRefFromRequest(req14)))
+	for _, req := range reqs1 {
+		require.True(t, pool.Has(isc.RequestRefFromRequest(req)))
+	}
+
+	// re-adding a request already in the pool is not refused
+	pool.Add(reqs1[0])
+	require.Len(t, refused, 1)
+
+	// the pool is full: the new one is refused, nothing is evicted
+	req21 := onLedgerFrom(sender2)
+	pool.Add(req21)
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req21)))
+	req22 := onLedgerFrom(sender2)
+	pool.Add(req22)
+	require.Equal(t, []isc.OnLedgerRequest{req14, req22}, refused)
+	require.False(t, pool.Has(isc.RequestRefFromRequest(req22)))
+	for _, req := range append(reqs1, req21) {
+		require.True(t, pool.Has(isc.RequestRefFromRequest(req)))
+	}
+
+	// once some requests are removed, new ones are accepted again
+	pool.Remove(reqs1[0])
+	pool.Filter(func(req isc.OnLedgerRequest, _ time.Time) bool { return req.ID() != reqs1[1].ID() })
+	pool.Add(req14)
+	pool.Add(req22)
+	require.Len(t, refused, 2)
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req14)))
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req22)))
+}
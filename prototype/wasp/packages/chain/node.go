@@ -31,6 +31,7 @@ import (
 	"github.com/iotaledger/wasp/packages/chain/cons"
 	consGR "github.com/iotaledger/wasp/packages/chain/cons/cons_gr"
 	"github.com/iotaledger/wasp/packages/chain/mempool"
+	"github.com/iotaledger/wasp/packages/chain/oraclefeed"
 	"github.com/iotaledger/wasp/packages/chain/statemanager"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
@@ -68,6 +69,15 @@ var (
 type ChainRequests interface {
 	ReceiveOffLedgerRequest(request isc.OffLedgerRequest, sender *cryptolib.PublicKey) error
 	AwaitRequestProcessed(ctx context.Context, requestID isc.RequestID, confirmed bool) <-chan *blocklog.RequestReceipt
+	// Returns the off-ledger requests waiting in the mempool, grouped by the sender.
+	MempoolOffLedgerRequests(ctx context.Context) []*mempool.AccountRequests
+	// Returns a page of the requests waiting in the mempool, see mempool.Mempool.RequestsAsync.
+	MempoolRequests(ctx context.Context, after uint64, limit int) *mempool.RequestsPage
+	// Removes the request from the mempool, returns false if it was not there.
+	MempoolRemoveRequest(ctx context.Context, requestID isc.RequestID) bool
+	// Passes the gas burned by a simulation of the request to the mempool, see
+	// mempool.Mempool.GasEstimated.
+	GasEstimated(request isc.Request, gasBurned uint64)
 }
 
 type Chain interface {
@@ -171,6 +181,7 @@ type chainNodeImpl struct {
 	consensusDelay   time.Duration
 	recoveryTimeout  time.Duration
 	validatorAgentID isc.AgentID
+	oracle           consGR.Oracle
 	//
 	// Information for other components.
 	listener               ChainListener          // Object expecting event notifications.
@@ -266,6 +277,7 @@ func New(
 	consensusStateRegistry cmt_log.ConsensusStateRegistry,
 	recoverFromWAL bool,
 	blockWAL sm_gpa_utils.BlockWAL,
+	offLedgerWAL mempool.OffLedgerWAL,
 	snapshotManager sm_snapshots.SnapshotManager,
 	listener ChainListener,
 	accessNodesFromNode []*cryptolib.PublicKey,
@@ -279,7 +291,9 @@ func New(
 	consensusDelay time.Duration,
 	recoveryTimeout time.Duration,
 	validatorAgentID isc.AgentID,
+	oracleSource oraclefeed.Source,
 	smParameters sm_gpa.StateManagerParameters,
+	mempoolParameters mempool.Parameters,
 ) (Chain, error) {
 	log.Debugf("Starting the chain, chainID=%v", chainID)
 	if listener == nil {
@@ -313,6 +327,7 @@ func New(
 		consensusDelay:         consensusDelay,
 		recoveryTimeout:        recoveryTimeout,
 		validatorAgentID:       validatorAgentID,
+		oracle:                 nil, // Set bellow.
 		listener:               listener,
 		accessLock:             &sync.RWMutex{},
 		activeCommitteeDKShare: nil,
@@ -423,6 +438,8 @@ func New(
 		chainID,
 		nodeIdentity,
 		net,
+		offLedgerWAL,
+		mempoolParameters,
 		cni.log.Named("MP"),
 		chainMetrics.Mempool,
 		chainMetrics.Pipe,
@@ -431,6 +448,9 @@ func New(
 	cni.chainMgr = gpa.NewAckHandler(cni.me, chainMgr.AsGPA(), RedeliveryPeriod)
 	cni.stateMgr = stateMgr
 	cni.mempool = mempool
+	if oracleSource != nil {
+		cni.oracle = newChainOracle(oracleSource, cni)
+	}
 	cni.stateTrackerAct = NewStateTracker(ctx, stateMgr, cni.handleStateTrackerActCB, chainMetrics.StateManager.SetChainActiveStateWant, chainMetrics.StateManager.SetChainActiveStateHave, cni.log.Named("ST.ACT"))
 	cni.stateTrackerCnf = NewStateTracker(ctx, stateMgr, cni.handleStateTrackerCnfCB, chainMetrics.StateManager.SetChainConfirmedStateWant, chainMetrics.StateManager.SetChainConfirmedStateHave, cni.log.Named("ST.CNF"))
 	cni.updateAccessNodes(func() {
@@ -494,6 +514,37 @@ func (cni *chainNodeImpl) ReceiveOffLedgerRequest(request isc.OffLedgerRequest,
 	return cni.mempool.ReceiveOffLedgerRequest(request)
 }
 
+func (cni *chainNodeImpl) MempoolOffLedgerRequests(ctx context.Context) []*mempool.AccountRequests {
+	select {
+	case res := <-cni.mempool.OffLedgerRequestsAsync(ctx):
+		return res
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (cni *chainNodeImpl) MempoolRequests(ctx context.Context, after uint64, limit int) *mempool.RequestsPage {
+	select {
+	case res := <-cni.mempool.RequestsAsync(ctx, after, limit):
+		return res
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (cni *chainNodeImpl) MempoolRemoveRequest(ctx context.Context, requestID isc.RequestID) bool {
+	select {
+	case res := <-cni.mempool.RemoveRequestAsync(ctx, requestID):
+		return res
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (cni *chainNodeImpl) GasEstimated(request isc.Request, gasBurned uint64) {
+	cni.mempool.GasEstimated(request, gasBurned)
+}
+
 func (cni *chainNodeImpl) AwaitRequestProcessed(ctx context.Context, requestID isc.RequestID, confirmed bool) <-chan *blocklog.RequestReceipt {
 	query, responseCh := newAwaitReceiptReq(ctx, requestID, cni.log)
 	if confirmed {
@@ -873,7 +924,7 @@ func (cni *chainNodeImpl) ensureConsensusInst(ctx context.Context, needConsensus
 			logIndexCopy := addLogIndex
 			cgr := consGR.New(
 				consGrCtx, cni.chainID, cni.chainStore, dkShare, &logIndexCopy, cni.nodeIdentity,
-				cni.procCache, cni.mempool, cni.stateMgr, cni.net,
+				cni.procCache, cni.mempool, cni.stateMgr, cni.oracle, cni.net,
 				cni.validatorAgentID,
 				cni.recoveryTimeout, RedeliveryPeriod, PrintStatusPeriod,
 				cni.chainMetrics.Consensus,
@@ -19,6 +19,7 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
@@ -454,6 +455,7 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			testutil.NewConsensusStateRegistry(),
 			false,
 			sm_gpa_utils.NewMockedTestBlockWAL(),
+			mempool.NewEmptyOffLedgerWAL(),
 			sm_snapshots.NewEmptySnapshotManager(),
 			chain.NewEmptyChainListener(),
 			[]*cryptolib.PublicKey{}, // Access nodes.
@@ -467,7 +469,9 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			10*time.Millisecond,
 			10*time.Second,
 			accounts.CommonAccount(),
+			nil,
 			sm_gpa.NewStateManagerParameters(),
+			mempool.NewParameters(),
 		)
 		require.NoError(t, err)
 		te.nodes[i].ServersUpdated(te.peerPubKeys)
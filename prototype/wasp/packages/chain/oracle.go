@@ -0,0 +1,30 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chain
+
+import (
+	"github.com/iotaledger/wasp/packages/chain/oraclefeed"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+)
+
+// chainOracle provides the values of the oracle feeds observed by the node,
+// scaled by the decimals of each feed as set in the latest state of the chain.
+type chainOracle struct {
+	source oraclefeed.Source
+	chain  *chainNodeImpl
+}
+
+func newChainOracle(source oraclefeed.Source, chain *chainNodeImpl) *chainOracle {
+	return &chainOracle{source: source, chain: chain}
+}
+
+func (co *chainOracle) Values() isc.OracleValues {
+	chainState, err := co.chain.LatestState(ActiveOrCommittedState)
+	if err != nil {
+		co.chain.log.Warnf("cannot get the decimals of the oracle feeds, no values proposed: %v", err)
+		return isc.OracleValues{}
+	}
+	return co.source.Values(oracle.NewStateAccess(chainState).Decimals)
+}
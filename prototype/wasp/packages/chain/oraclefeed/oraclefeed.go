@@ -0,0 +1,256 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oraclefeed provides the values of the oracle feeds observed by a
+// committee node. The values are proposed by the node in each consensus
+// round, aggregated by median and written on-chain in the oracle core
+// contract, along with the threshold signature of the committee.
+//
+// Each feed has a name and a source, which is either an http(s) URL or a
+// local file. The source must return a decimal number (e.g. "0.2315"), which
+// is scaled by 10^decimals to get the integer value of the feed. The decimals
+// of each feed are set on-chain, in the oracle core contract, so that all the
+// committee nodes use the same scale.
+//
+// The feeds are polled concurrently, each of them with a timeout of one
+// polling interval, so that a slow source does not delay the other ones.
+package oraclefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+const maxResponseSize = 1024
+
+// Source provides the latest values of the oracle feeds, scaled by the
+// decimals of each feed.
+type Source interface {
+	Values(decimals func(feed string) uint8) isc.OracleValues
+}
+
+type Feed struct {
+	Name   string
+	Source string
+}
+
+// ParseFeeds parses the feed definitions, in the form "<name>=<source>".
+func ParseFeeds(defs []string) ([]*Feed, error) {
+	feeds := make([]*Feed, 0, len(defs))
+	names := map[string]bool{}
+	for _, def := range defs {
+		name, source, ok := strings.Cut(def, "=")
+		name = strings.TrimSpace(name)
+		source = strings.TrimSpace(source)
+		if !ok || name == "" || source == "" {
+			return nil, fmt.Errorf("invalid oracle feed %q, expected <name>=<source>", def)
+		}
+		if len(name) > isc.MaxOracleFeedNameLength {
+			return nil, fmt.Errorf("oracle feed name %q is too long", name)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate oracle feed %q", name)
+		}
+		names[name] = true
+		feeds = append(feeds, &Feed{Name: name, Source: source})
+	}
+	if len(feeds) > isc.MaxOracleFeeds {
+		return nil, fmt.Errorf("too many oracle feeds: %d > %d", len(feeds), isc.MaxOracleFeeds)
+	}
+	return feeds, nil
+}
+
+// ParseValue parses a decimal number and scales it to an integer value with
+// the given amount of decimals. Extra decimals are truncated.
+func ParseValue(s string, decimals uint8) (int64, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	return scaleValue(r, decimals)
+}
+
+func parseDecimal(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return nil, fmt.Errorf("invalid oracle value %q", s)
+	}
+	return r, nil
+}
+
+func scaleValue(r *big.Rat, decimals uint8) (int64, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	v := new(big.Int).Mul(r.Num(), scale)
+	v.Quo(v, r.Denom())
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("oracle value %s out of range", r.FloatString(int(decimals)))
+	}
+	return v.Int64(), nil
+}
+
+type sample struct {
+	value *big.Rat
+	time  time.Time
+}
+
+type poller struct {
+	feeds    []*Feed
+	interval time.Duration
+	client   *http.Client
+	mutex    sync.RWMutex
+	latest   map[string]*sample
+	log      *logger.Logger
+}
+
+var _ Source = &poller{}
+
+// New starts polling the feeds at the given interval, until the context is
+// done. The values not refreshed in the last 3 intervals are considered stale
+// and are not returned.
+func New(ctx context.Context, feeds []*Feed, interval time.Duration, log *logger.Logger) Source {
+	p := &poller{
+		feeds:    feeds,
+		interval: interval,
+		client:   &http.Client{},
+		latest:   map[string]*sample{},
+		log:      log,
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *poller) Values(decimals func(feed string) uint8) isc.OracleValues {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	values := isc.OracleValues{}
+	for name, s := range p.latest {
+		if time.Since(s.time) > 3*p.interval {
+			continue
+		}
+		value, err := scaleValue(s.value, decimals(name))
+		if err != nil {
+			p.log.Warnf("cannot scale the value of the oracle feed %s: %v", name, err)
+			continue
+		}
+		values[name] = value
+	}
+	return values
+}
+
+func (p *poller) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *poller) poll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, feed := range p.feeds {
+		wg.Add(1)
+		go func(feed *Feed) {
+			defer wg.Done()
+			p.pollFeed(ctx, feed)
+		}(feed)
+	}
+	wg.Wait()
+}
+
+func (p *poller) pollFeed(ctx context.Context, feed *Feed) {
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+	value, err := p.fetch(ctx, feed.Source)
+	if err != nil {
+		p.log.Warnf("cannot get the value of the oracle feed %s: %v", feed.Name, err)
+		return
+	}
+	p.mutex.Lock()
+	p.latest[feed.Name] = &sample{value: value, time: time.Now()}
+	p.mutex.Unlock()
+}
+
+// fetch returns once the context is done, even if the source is still being
+// read (e.g. a local file that blocks).
+func (p *poller) fetch(ctx context.Context, source string) (*big.Rat, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		data, err := p.read(ctx, source)
+		resultCh <- result{data: data, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resultCh:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return parseDecimal(string(res.data))
+	}
+}
+
+func (p *poller) read(ctx context.Context, source string) ([]byte, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	case "file":
+		return readFile(u.Path)
+	case "":
+		return readFile(source)
+	default:
+		return nil, fmt.Errorf("unsupported oracle source %q", source)
+	}
+}
+
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty oracle source")
+	}
+	return data, nil
+}
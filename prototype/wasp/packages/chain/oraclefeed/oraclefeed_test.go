@@ -0,0 +1,115 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package oraclefeed_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/chain/oraclefeed"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+func TestParseFeeds(t *testing.T) {
+	feeds, err := oraclefeed.ParseFeeds([]string{"price=https://example.com/price", " temp = /tmp/temp "})
+	require.NoError(t, err)
+	require.Equal(t, []*oraclefeed.Feed{
+		{Name: "price", Source: "https://example.com/price"},
+		{Name: "temp", Source: "/tmp/temp"},
+	}, feeds)
+
+	_, err = oraclefeed.ParseFeeds([]string{"price"})
+	require.Error(t, err)
+	_, err = oraclefeed.ParseFeeds([]string{"price=a", "price=b"})
+	require.Error(t, err)
+}
+
+func TestParseValue(t *testing.T) {
+	for _, test := range []struct {
+		s        string
+		decimals uint8
+		expected int64
+	}{
+		{"42", 0, 42},
+		{"0.2315", 6, 231500},
+		{"-1.5", 2, -150},
+		{"1.23456789\n", 4, 12345},
+	} {
+		v, err := oraclefeed.ParseValue(test.s, test.decimals)
+		require.NoError(t, err)
+		require.EqualValues(t, test.expected, v, test.s)
+	}
+
+	_, err := oraclefeed.ParseValue("abc", 6)
+	require.Error(t, err)
+	_, err = oraclefeed.ParseValue("100000000000000", 6)
+	require.Error(t, err)
+}
+
+func TestSource(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0.25")
+	}))
+	defer srv.Close()
+	// The sources that never answer do not delay the other feeds.
+	blocked := make(chan struct{})
+	slowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-blocked:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slowSrv.Close()
+	defer close(blocked)
+	file := filepath.Join(t.TempDir(), "temp")
+	require.NoError(t, os.WriteFile(file, []byte("21.5\n"), 0o600))
+
+	feeds, err := oraclefeed.ParseFeeds([]string{
+		"price=" + srv.URL,
+		"temp=file://" + file,
+		"slow1=" + slowSrv.URL,
+		"slow2=" + slowSrv.URL,
+		"slow3=" + slowSrv.URL,
+		"slow4=" + slowSrv.URL,
+		"missing=" + filepath.Join(t.TempDir(), "missing"),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := oraclefeed.New(ctx, feeds, 100*time.Millisecond, log)
+	decimals := func(feed string) uint8 {
+		if feed == "price" {
+			return 4
+		}
+		return 2
+	}
+	require.Eventually(t, func() bool {
+		return len(source.Values(decimals)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, isc.OracleValues{"price": 2500, "temp": 2150}, source.Values(decimals))
+	// The values are refreshed on each interval, despite the slow source.
+	require.Never(t, func() bool {
+		return len(source.Values(decimals)) != 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The values become stale, if the sources cannot be read anymore.
+	srv.Close()
+	require.NoError(t, os.Remove(file))
+	require.Eventually(t, func() bool {
+		return len(source.Values(decimals)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
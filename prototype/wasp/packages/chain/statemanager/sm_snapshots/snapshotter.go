@@ -57,6 +57,27 @@ func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader)
 	return nil
 }
 
+// WriteSnapshot writes the snapshot of the given block of the store to w, in
+// the same format as the snapshot files produced by the nodes.
+func WriteSnapshot(snapshotInfo SnapshotInfo, store state.Store, w io.Writer) error {
+	return newSnapshotter(store).storeSnapshot(snapshotInfo, w)
+}
+
+// ReadSnapshot reads a snapshot file produced by the nodes from r and saves
+// its block and state to the store. The latest state of the store is not
+// changed.
+func ReadSnapshot(store state.Store, r io.Reader) (SnapshotInfo, error) {
+	snapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading snapshot info: %w", err)
+	}
+	err = store.RestoreSnapshot(snapshotInfo.TrieRoot(), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed restoring snapshot: %w", err)
+	}
+	return snapshotInfo, nil
+}
+
 func readSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
 	indexArray, err := readBytes(r)
 	if err != nil {
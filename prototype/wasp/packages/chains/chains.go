@@ -17,6 +17,8 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
+	"github.com/iotaledger/wasp/packages/chain/oraclefeed"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
@@ -61,6 +63,13 @@ type Chains struct {
 	walLoadToStore                      bool
 	walEnabled                          bool
 	walFolderPath                       string
+	mempoolWALEnabled                   bool
+	mempoolWALFolderPath                string
+	mempoolMaxOnLedgerInPool            int
+	mempoolMaxOnLedgerPerAccount        int
+	mempoolMaxOffLedgerInPool           int
+	mempoolMaxOffLedgerPerAccount       int
+	mempoolMaxProposalBytes             int
 	smBlockCacheMaxSize                 int
 	smBlockCacheBlocksInCacheDuration   time.Duration
 	smBlockCacheBlockCleaningPeriod     time.Duration
@@ -92,6 +101,10 @@ type Chains struct {
 	chainMetricsProvider *metrics.ChainMetricsProvider
 
 	validatorFeeAddr iotago.Address
+
+	oracleFeeds        []*oraclefeed.Feed
+	oraclePollInterval time.Duration
+	oracleSource       oraclefeed.Source
 }
 
 type activeChain struct {
@@ -104,6 +117,8 @@ func New(
 	nodeConnection chain.NodeConnection,
 	processorConfig *processors.Config,
 	validatorAddrStr string,
+	oracleFeedDefs []string,
+	oraclePollInterval time.Duration,
 	offledgerBroadcastUpToNPeers int, // TODO: Unused for now.
 	offledgerBroadcastInterval time.Duration, // TODO: Unused for now.
 	pullMissingRequestsFromCommittee bool, // TODO: Unused for now.
@@ -117,6 +132,13 @@ func New(
 	walLoadToStore bool,
 	walEnabled bool,
 	walFolderPath string,
+	mempoolWALEnabled bool,
+	mempoolWALFolderPath string,
+	mempoolMaxOnLedgerInPool int,
+	mempoolMaxOnLedgerPerAccount int,
+	mempoolMaxOffLedgerInPool int,
+	mempoolMaxOffLedgerPerAccount int,
+	mempoolMaxProposalBytes int,
 	smBlockCacheMaxSize int,
 	smBlockCacheBlocksInCacheDuration time.Duration,
 	smBlockCacheBlockCleaningPeriod time.Duration,
@@ -149,6 +171,10 @@ func New(
 		}
 		validatorFeeAddr = addr
 	}
+	oracleFeeds, err := oraclefeed.ParseFeeds(oracleFeedDefs)
+	if err != nil {
+		panic(fmt.Errorf("error parsing oracle.feeds: %w", err))
+	}
 	ret := &Chains{
 		log:                                 log,
 		mutex:                               &sync.RWMutex{},
@@ -168,6 +194,13 @@ func New(
 		walLoadToStore:                      walLoadToStore,
 		walEnabled:                          walEnabled,
 		walFolderPath:                       walFolderPath,
+		mempoolWALEnabled:                   mempoolWALEnabled,
+		mempoolWALFolderPath:                mempoolWALFolderPath,
+		mempoolMaxOnLedgerInPool:            mempoolMaxOnLedgerInPool,
+		mempoolMaxOnLedgerPerAccount:        mempoolMaxOnLedgerPerAccount,
+		mempoolMaxOffLedgerInPool:           mempoolMaxOffLedgerInPool,
+		mempoolMaxOffLedgerPerAccount:       mempoolMaxOffLedgerPerAccount,
+		mempoolMaxProposalBytes:             mempoolMaxProposalBytes,
 		smBlockCacheMaxSize:                 smBlockCacheMaxSize,
 		smBlockCacheBlocksInCacheDuration:   smBlockCacheBlocksInCacheDuration,
 		smBlockCacheBlockCleaningPeriod:     smBlockCacheBlockCleaningPeriod,
@@ -188,6 +221,8 @@ func New(
 		shutdownCoordinator:                 shutdownCoordinator,
 		chainMetricsProvider:                chainMetricsProvider,
 		validatorFeeAddr:                    validatorFeeAddr,
+		oracleFeeds:                         oracleFeeds,
+		oraclePollInterval:                  oraclePollInterval,
 	}
 	ret.initSnapshotsToLoad(snapshotsToLoad)
 	ret.chainListener = NewChainsListener(chainListener, ret.chainAccessUpdatedCB)
@@ -236,6 +271,10 @@ func (c *Chains) Run(ctx context.Context) error {
 	}
 	c.ctx = ctx
 
+	if len(c.oracleFeeds) > 0 {
+		c.oracleSource = oraclefeed.New(ctx, c.oracleFeeds, c.oraclePollInterval, c.log.Named("Oracle"))
+	}
+
 	c.accessMgr = access_mgr.New(ctx, c.chainServersUpdatedCB, c.nodeIdentityProvider.NodeIdentity(), c.networkProvider, c.log.Named("AM"))
 	c.trustedNetworkListenerCancel = c.trustedNetworkManager.TrustedPeersListener(c.trustedPeersUpdatedCB)
 
@@ -346,6 +385,15 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	} else {
 		chainWAL = sm_gpa_utils.NewEmptyBlockWAL()
 	}
+	var offLedgerWAL mempool.OffLedgerWAL
+	if c.mempoolWALEnabled {
+		offLedgerWAL, err = mempool.NewOffLedgerWAL(chainLog.Named("MP-WAL"), c.mempoolWALFolderPath, chainID)
+		if err != nil {
+			panic(fmt.Errorf("cannot create mempool WAL: %w", err))
+		}
+	} else {
+		offLedgerWAL = mempool.NewEmptyOffLedgerWAL()
+	}
 
 	stateManagerParameters := sm_gpa.NewStateManagerParameters()
 	stateManagerParameters.BlockCacheMaxSize = c.smBlockCacheMaxSize
@@ -357,6 +405,13 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	stateManagerParameters.PruningMinStatesToKeep = c.smPruningMinStatesToKeep
 	stateManagerParameters.PruningMaxStatesToDelete = c.smPruningMaxStatesToDelete
 
+	mempoolParameters := mempool.NewParameters()
+	mempoolParameters.MaxOnLedgerInPool = c.mempoolMaxOnLedgerInPool
+	mempoolParameters.MaxOnLedgerPerAccount = c.mempoolMaxOnLedgerPerAccount
+	mempoolParameters.MaxOffLedgerInPool = c.mempoolMaxOffLedgerInPool
+	mempoolParameters.MaxOffLedgerPerAccount = c.mempoolMaxOffLedgerPerAccount
+	mempoolParameters.MaxProposalBytes = c.mempoolMaxProposalBytes
+
 	// Initialize Snapshotter
 	chainStore := indexedstore.New(state.NewStoreWithMetrics(chainKVStore, writeMutex, chainMetrics.State))
 	chainCtx, chainCancel := context.WithCancel(c.ctx)
@@ -401,6 +456,7 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		c.consensusStateRegistry,
 		c.walLoadToStore,
 		chainWAL,
+		offLedgerWAL,
 		chainSnapshotManager,
 		c.chainListener,
 		chainRecord.AccessNodes,
@@ -414,7 +470,9 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		c.consensusDelay,
 		c.recoveryTimeout,
 		validatorAgentID,
+		c.oracleSource,
 		stateManagerParameters,
+		mempoolParameters,
 	)
 	if err != nil {
 		chainCancel()
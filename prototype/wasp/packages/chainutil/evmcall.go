@@ -13,8 +13,9 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-// EVMCall executes an EVM contract call and returns its output, discarding any state changes
-func EVMCall(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call ethereum.CallMsg) ([]byte, error) {
+// EVMCall executes an EVM contract call and returns its output, discarding any state changes.
+// If overrides is not nil, the call is executed on top of the modified state and block context.
+func EVMCall(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call ethereum.CallMsg, overrides *isc.EVMCallOverrides) ([]byte, error) {
 	info := getChainInfo(ch)
 
 	// 0 means view call
@@ -29,7 +30,7 @@ func EVMCall(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call ethere
 
 	iscReq := isc.NewEVMOffLedgerCallRequest(ch.ID(), call)
 	// TODO: setting EstimateGasMode = true feels wrong here
-	res, err := runISCRequest(ch, aliasOutput, time.Now(), iscReq, true)
+	res, err := runISCRequest(ch, aliasOutput, time.Now(), iscReq, true, overrides)
 	if err != nil {
 		return nil, err
 	}
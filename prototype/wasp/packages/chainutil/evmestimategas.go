@@ -19,8 +19,9 @@ import (
 var evmErrOutOfGasRegex = regexp.MustCompile("out of gas|intrinsic gas too low")
 
 // EVMEstimateGas executes the given request and discards the resulting chain state. It is useful
-// for estimating gas.
-func EVMEstimateGas(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call ethereum.CallMsg) (uint64, error) { //nolint:gocyclo
+// for estimating gas. If overrides is not nil, the call is executed on top of the modified state and
+// block context.
+func EVMEstimateGas(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call ethereum.CallMsg, overrides *isc.EVMCallOverrides) (uint64, error) { //nolint:gocyclo
 	// Determine the lowest and highest possible gas limits to binary search in between
 	var (
 		lo     uint64 = params.TxGas - 1
@@ -48,7 +49,7 @@ func EVMEstimateGas(ch chain.ChainCore, aliasOutput *isc.AliasOutputWithID, call
 	executable := func(gas uint64) (failed bool, result *vm.RequestResult, err error) {
 		call.Gas = gas
 		iscReq := isc.NewEVMOffLedgerCallRequest(ch.ID(), call)
-		res, err := runISCRequest(ch, aliasOutput, blockTime, iscReq, true)
+		res, err := runISCRequest(ch, aliasOutput, blockTime, iscReq, true, overrides)
 		if err != nil {
 			return true, nil, err
 		}
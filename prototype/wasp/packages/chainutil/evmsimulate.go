@@ -0,0 +1,191 @@
+package chainutil
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/vm/core/errors"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/evm/emulator"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// EVMSimulatedBlock is a block of EVM calls to be executed by EVMSimulateCalls.
+type EVMSimulatedBlock struct {
+	// Time is the timestamp of the block (see SimulatedBlock.Time)
+	Time      time.Time
+	Calls     []ethereum.CallMsg
+	Overrides *isc.EVMCallOverrides
+}
+
+// EVMSimulatedCallResult contains the outcome of a simulated EVM call.
+type EVMSimulatedCallResult struct {
+	// Tx is the (impersonated) transaction built from the call
+	Tx         *types.Transaction
+	ReturnData []byte
+	// Receipt contains the status and logs of the call, or nil if the call
+	// failed (in which case Err is set)
+	Receipt *types.Receipt
+	GasUsed uint64
+	Err     error
+}
+
+// EVMSimulatedBlockResult contains the outcome of a block executed by
+// EVMSimulateCalls.
+type EVMSimulatedBlockResult struct {
+	Calls []*EVMSimulatedCallResult
+	// State is the chain state after the block was produced
+	State state.State
+}
+
+// EVMSimulateCalls executes the given blocks of EVM calls in order on top of
+// the given AliasOutput. Unlike EVMCall, the state changes of each call are
+// visible to the following calls and blocks. Nothing is persisted.
+func EVMSimulateCalls(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blocks []*EVMSimulatedBlock,
+) ([]*EVMSimulatedBlockResult, error) {
+	info := getChainInfo(ch)
+	gasLimit := gas.EVMCallGasLimit(info.GasLimits, &info.GasFeePolicy.EVMGasRatio)
+
+	iscBlocks := make([]*SimulatedBlock, len(blocks))
+	calls := make([][]ethereum.CallMsg, len(blocks))
+	for i, block := range blocks {
+		reqs := make([]isc.Request, len(block.Calls))
+		calls[i] = make([]ethereum.CallMsg, len(block.Calls))
+		for j, call := range block.Calls {
+			if call.Gas == 0 || call.Gas > gasLimit {
+				call.Gas = gasLimit
+			}
+			if call.GasPrice == nil {
+				call.GasPrice = info.GasFeePolicy.GasPriceWei(parameters.L1().BaseToken.Decimals)
+			}
+			calls[i][j] = call
+			reqs[j] = isc.NewEVMOffLedgerCallRequest(ch.ID(), call)
+		}
+		iscBlocks[i] = &SimulatedBlock{
+			Time:             block.Time,
+			Requests:         reqs,
+			EVMCallOverrides: block.Overrides,
+		}
+	}
+
+	results, err := SimulateBlocks(ch, aliasOutput, iscBlocks)
+	if err != nil {
+		return nil, err
+	}
+	prevState, err := stateFromAliasOutput(ch.Store(), aliasOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*EVMSimulatedBlockResult, len(results))
+	for i, res := range results {
+		if len(res.RequestResults) != len(blocks[i].Calls) {
+			return nil, fmt.Errorf("simulated block %d: some calls were skipped", i)
+		}
+		nonces := newSimulatedNonces(prevState, blocks[i].Overrides)
+		callResults := make([]*EVMSimulatedCallResult, len(res.RequestResults))
+		for j, reqResult := range res.RequestResults {
+			call := calls[i][j]
+			if reqResult.Receipt.Error != nil {
+				vmerr, resolvingErr := errors.ResolveFromState(res.State, reqResult.Receipt.Error)
+				if resolvingErr != nil {
+					return nil, fmt.Errorf("error resolving vmerror: %w", resolvingErr)
+				}
+				// the failed request was reverted, so the nonce was not used
+				callResults[j] = &EVMSimulatedCallResult{
+					Tx:      simulatedCallTx(call, nonces.get(call.From)),
+					GasUsed: gas.ISCGasBudgetToEVM(reqResult.Receipt.GasBurned, &info.GasFeePolicy.EVMGasRatio),
+					Err:     vmerr,
+				}
+				continue
+			}
+			receipt, err := evmtypes.DecodeReceipt(reqResult.Return[evm.FieldReceipt])
+			if err != nil {
+				return nil, err
+			}
+			tx, err := evmtypes.DecodeTransaction(reqResult.Return[evm.FieldTransaction])
+			if err != nil {
+				return nil, err
+			}
+			nonces.set(call.From, tx.Nonce()+1)
+			callResults[j] = &EVMSimulatedCallResult{
+				Tx:         tx,
+				ReturnData: reqResult.Return[evm.FieldResult],
+				Receipt:    receipt,
+				GasUsed:    receipt.CumulativeGasUsed,
+			}
+		}
+		ret[i] = &EVMSimulatedBlockResult{
+			Calls: callResults,
+			State: res.State,
+		}
+		prevState = res.State
+	}
+	return ret, nil
+}
+
+// simulatedNonces keeps track of the nonces of the senders within a
+// simulated block, starting from the state of the previous block with the
+// state overrides of the block applied.
+type simulatedNonces struct {
+	prevState kv.KVStoreReader
+	nonces    map[common.Address]uint64
+}
+
+func newSimulatedNonces(prevState state.State, overrides *isc.EVMCallOverrides) *simulatedNonces {
+	n := &simulatedNonces{
+		prevState: emulator.StateDBSubrealmR(evm.EmulatorStateSubrealmR(evm.ContractPartitionR(prevState))),
+		nonces:    make(map[common.Address]uint64),
+	}
+	if overrides != nil {
+		for addr, account := range overrides.State {
+			if account.Nonce != nil {
+				n.nonces[addr] = *account.Nonce
+			}
+		}
+	}
+	return n
+}
+
+func (n *simulatedNonces) get(addr common.Address) uint64 {
+	if nonce, ok := n.nonces[addr]; ok {
+		return nonce
+	}
+	return emulator.GetNonce(n.prevState, addr)
+}
+
+func (n *simulatedNonces) set(addr common.Address, nonce uint64) {
+	n.nonces[addr] = nonce
+}
+
+// simulatedCallTx builds the transaction of a call in the same way as the
+// emulator does in EVMEmulator.SimulateCall.
+func simulatedCallTx(call ethereum.CallMsg, nonce uint64) *types.Transaction {
+	value := call.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return evmutil.NewImpersonatedTransaction(call.From, &types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: call.GasPrice,
+		Gas:      call.Gas,
+		To:       call.To,
+		Value:    value,
+		Data:     call.Data,
+	})
+}
@@ -3,10 +3,13 @@ package chainutil
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 func EVMTraceTransaction(
@@ -27,6 +30,66 @@ func EVMTraceTransaction(
 			Tracer:  tracer,
 			TxIndex: txIndex,
 		},
+		nil,
+	)
+	return err
+}
+
+// EVMTraceBlock re-executes all requests of an ISC block once, tracing each
+// EVM tx with the tracer returned by newTracer.
+func EVMTraceBlock(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	newTracer func(txIndex uint64) tracers.Tracer,
+) error {
+	_, err := runISCTask(
+		ch,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		false,
+		&isc.EVMTracer{
+			NewTracer: newTracer,
+		},
+		nil,
+	)
+	return err
+}
+
+// EVMTraceCall executes an EVM contract call with the given tracer,
+// discarding any state changes.
+func EVMTraceCall(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	call ethereum.CallMsg,
+	tracer tracers.Tracer,
+) error {
+	info := getChainInfo(ch)
+
+	// 0 means view call
+	gasLimit := gas.EVMCallGasLimit(info.GasLimits, &info.GasFeePolicy.EVMGasRatio)
+	if call.Gas != 0 && call.Gas > gasLimit {
+		call.Gas = gasLimit
+	}
+	if call.GasPrice == nil {
+		call.GasPrice = info.GasFeePolicy.GasPriceWei(parameters.L1().BaseToken.Decimals)
+	}
+
+	iscReq := isc.NewEVMOffLedgerCallRequest(ch.ID(), call)
+	// the request is not part of any block, so the call is the first EVM tx
+	_, err := runISCTask(
+		ch,
+		aliasOutput,
+		time.Now(),
+		[]isc.Request{iscReq},
+		true,
+		&isc.EVMTracer{
+			Tracer:  tracer,
+			TxIndex: 0,
+		},
+		nil,
 	)
 	return err
 }
@@ -21,8 +21,26 @@ func runISCTask(
 	reqs []isc.Request,
 	estimateGasMode bool,
 	evmTracer *isc.EVMTracer,
+	evmCallOverrides *isc.EVMCallOverrides,
 ) ([]*vm.RequestResult, error) {
-	task := &vm.VMTask{
+	task := newISCTask(ch, aliasOutput, blockTime, reqs, estimateGasMode)
+	task.EVMTracer = evmTracer
+	task.EVMCallOverrides = evmCallOverrides
+	res, err := vmimpl.Run(task)
+	if err != nil {
+		return nil, err
+	}
+	return res.RequestResults, nil
+}
+
+func newISCTask(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	reqs []isc.Request,
+	estimateGasMode bool,
+) *vm.VMTask {
+	return &vm.VMTask{
 		Processors:           ch.Processors(),
 		AnchorOutput:         aliasOutput.GetAliasOutput(),
 		AnchorOutputID:       aliasOutput.OutputID(),
@@ -33,14 +51,8 @@ func runISCTask(
 		ValidatorFeeTarget:   accounts.CommonAccount(),
 		EnableGasBurnLogging: estimateGasMode,
 		EstimateGasMode:      estimateGasMode,
-		EVMTracer:            evmTracer,
 		Log:                  ch.Log().Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar(),
 	}
-	res, err := vmimpl.Run(task)
-	if err != nil {
-		return nil, err
-	}
-	return res.RequestResults, nil
 }
 
 func runISCRequest(
@@ -49,6 +61,7 @@ func runISCRequest(
 	blockTime time.Time,
 	req isc.Request,
 	estimateGasMode bool,
+	evmCallOverrides *isc.EVMCallOverrides,
 ) (*vm.RequestResult, error) {
 	results, err := runISCTask(
 		ch,
@@ -57,6 +70,7 @@ func runISCRequest(
 		[]isc.Request{req},
 		estimateGasMode,
 		nil,
+		evmCallOverrides,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,102 @@
+package chainutil
+
+import (
+	"fmt"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/vmimpl"
+)
+
+// SimulatedBlock is a block of requests to be executed by SimulateBlocks.
+type SimulatedBlock struct {
+	// Time is the timestamp of the block. If zero, the block is produced one
+	// second after the previous one (or after the block of the AliasOutput,
+	// for the first block).
+	Time     time.Time
+	Requests []isc.Request
+	// EVMCallOverrides, if not nil, are applied once before the requests of
+	// the block (state overrides) and to all of the EVM calls in the block
+	// (block overrides).
+	EVMCallOverrides *isc.EVMCallOverrides
+}
+
+// SimulatedBlockResult contains the outcome of a block executed by
+// SimulateBlocks.
+type SimulatedBlockResult struct {
+	RequestResults []*vm.RequestResult
+	// State is the chain state after the block was produced
+	State state.State
+}
+
+// SimulateBlocks executes the given blocks in order on top of the given
+// AliasOutput, carrying over the state changes from each block to the
+// following ones. The requests are executed in estimate gas mode, EVM calls
+// keep their changes to the state, and nothing is persisted to the chain DB.
+func SimulateBlocks(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blocks []*SimulatedBlock,
+) ([]*SimulatedBlockResult, error) {
+	store := indexedstore.NewBuffered(ch.Store())
+	parentState, err := stateFromAliasOutput(store, aliasOutput)
+	if err != nil {
+		return nil, err
+	}
+	blockTime := parentState.Timestamp()
+	ret := make([]*SimulatedBlockResult, len(blocks))
+	for i, block := range blocks {
+		if !block.Time.IsZero() {
+			blockTime = block.Time
+		} else {
+			blockTime = blockTime.Add(1 * time.Second)
+		}
+
+		task := newISCTask(ch, aliasOutput, blockTime, block.Requests, true)
+		task.Store = store
+		task.EVMCallOverrides = block.EVMCallOverrides
+		task.SimulationMode = true
+		res, err := vmimpl.Run(task)
+		if err != nil {
+			return nil, fmt.Errorf("simulated block %d: %w", i, err)
+		}
+		if res.RotationAddress != nil {
+			return nil, fmt.Errorf("simulated block %d: cannot simulate a committee rotation", i)
+		}
+
+		committed := store.Commit(res.StateDraft)
+		chainState, err := store.StateByTrieRoot(committed.TrieRoot())
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = &SimulatedBlockResult{
+			RequestResults: res.RequestResults,
+			State:          chainState,
+		}
+
+		// the anchor transaction is never signed nor published; it is only
+		// needed to obtain the AliasOutput for the next block
+		aliasOutput, err = isc.AliasOutputWithIDFromTx(
+			&iotago.Transaction{Essence: res.TransactionEssence},
+			ch.ID().AsAddress(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("simulated block %d: %w", i, err)
+		}
+	}
+	return ret, nil
+}
+
+func stateFromAliasOutput(store state.Store, aliasOutput *isc.AliasOutputWithID) (state.State, error) {
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, err
+	}
+	return store.StateByTrieRoot(l1Commitment.TrieRoot())
+}
@@ -18,7 +18,7 @@ func SimulateRequest(
 	if err != nil {
 		return nil, fmt.Errorf("could not get latest AliasOutput: %w", err)
 	}
-	res, err := runISCRequest(ch, aliasOutput, time.Now(), req, estimateGas)
+	res, err := runISCRequest(ch, aliasOutput, time.Now(), req, estimateGas, nil)
 	if err != nil {
 		return nil, err
 	}
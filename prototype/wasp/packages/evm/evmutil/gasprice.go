@@ -2,16 +2,46 @@ package evmutil
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-func CheckGasPrice(tx *types.Transaction, gasFeePolicy *gas.FeePolicy) error {
+// CheckGasPrice checks that the tx pays the gas price set by the fee policy.
+// Dynamic fee txs are accepted if the fee cap covers it: the price set by the
+// fee policy acts as the base fee, and the priority fee (see EffectiveTip) is
+// paid to the validators on top of it. Legacy and access list txs must set
+// exactly that price, unless the gas price is dynamic (see
+// gas.BaseFeeConfig): then, like in EIP-1559, any higher price is accepted,
+// and the excess is the priority fee.
+func CheckGasPrice(tx *types.Transaction, gasFeePolicy *gas.FeePolicy, dynamicGasPrice bool) error {
 	expectedGasPrice := gasFeePolicy.GasPriceWei(parameters.L1().BaseToken.Decimals)
+	if tx.Type() == types.DynamicFeeTxType {
+		if tx.GasFeeCap().Cmp(expectedGasPrice) < 0 {
+			return fmt.Errorf(
+				"invalid gas fee cap: got %s, want at least %s",
+				tx.GasFeeCap().Text(10),
+				expectedGasPrice.Text(10),
+			)
+		}
+		return nil
+	}
 	gasPrice := tx.GasPrice()
+	if dynamicGasPrice {
+		if gasPrice.Cmp(expectedGasPrice) < 0 {
+			return fmt.Errorf(
+				"invalid gas price: got %s, want at least %s",
+				gasPrice.Text(10),
+				expectedGasPrice.Text(10),
+			)
+		}
+		return nil
+	}
 	if gasPrice.Cmp(expectedGasPrice) != 0 {
 		return fmt.Errorf(
 			"invalid gas price: got %s, want %s",
@@ -21,3 +51,40 @@ func CheckGasPrice(tx *types.Transaction, gasFeePolicy *gas.FeePolicy) error {
 	}
 	return nil
 }
+
+// EffectiveTip returns the priority fee per unit of gas paid by the tx to the
+// validators, given the base gas price in wei: the gas tip cap, limited by the
+// fee cap minus the base gas price. For legacy and access list txs both are
+// the gas price, so their tip is the excess over the base gas price (always 0
+// if the gas price is not dynamic).
+func EffectiveTip(tx *types.Transaction, baseGasPrice *big.Int) *big.Int {
+	tip := new(big.Int).Sub(tx.GasFeeCap(), baseGasPrice)
+	if tip.Cmp(tx.GasTipCap()) > 0 {
+		tip.Set(tx.GasTipCap())
+	}
+	if tip.Sign() < 0 {
+		return new(big.Int)
+	}
+	return tip
+}
+
+// EffectiveGasPrice returns the gas price actually paid by the tx, given the
+// base gas price in wei
+func EffectiveGasPrice(tx *types.Transaction, baseGasPrice *big.Int) *big.Int {
+	if tx.Type() != types.DynamicFeeTxType {
+		return tx.GasPrice()
+	}
+	return new(big.Int).Add(baseGasPrice, EffectiveTip(tx, baseGasPrice))
+}
+
+// TipBaseTokens returns the priority fee paid by the tx for the given amount
+// of EVM gas at the gas price of the fee policy, converted to base tokens
+func TipBaseTokens(tx *types.Transaction, gasFeePolicy *gas.FeePolicy, evmGas uint64) uint64 {
+	decimals := parameters.L1().BaseToken.Decimals
+	tip := EffectiveTip(tx, gasFeePolicy.GasPriceWei(decimals))
+	tip.Mul(tip, new(big.Int).SetUint64(evmGas))
+	if tip.Cmp(util.BaseTokensDecimalsToEthereumDecimals(math.MaxUint64, decimals)) > 0 {
+		return math.MaxUint64
+	}
+	return util.EthereumDecimalsToBaseTokenDecimals(tip, decimals)
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package evmutil
+
+import (
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NewImpersonatedTransaction returns an unsigned transaction that carries the
+// sender address in place of the signature (V = S = 0, R = sender).
+//
+// Impersonated transactions are recorded in the EVM blockchain when executing
+// EVM calls on behalf of an impersonated account (only possible in Solo).
+// They are never accepted by GetSender, and thus can never be sent to a chain.
+// Their sender is only recognized after calling EnableImpersonatedSenders.
+func NewImpersonatedTransaction(from common.Address, tx *types.LegacyTx) *types.Transaction {
+	tx.V = new(big.Int)
+	tx.R = new(big.Int).SetBytes(from.Bytes())
+	tx.S = new(big.Int)
+	return types.NewTx(tx)
+}
+
+var impersonatedSendersEnabled atomic.Bool
+
+// EnableImpersonatedSenders makes ImpersonatedSender and GetTransactionSender
+// recognize the sender of impersonated transactions. It must only be called
+// by Solo: a node never records nor accepts impersonated transactions.
+func EnableImpersonatedSenders() {
+	impersonatedSendersEnabled.Store(true)
+}
+
+// ImpersonatedSender returns the sender of a transaction created with
+// NewImpersonatedTransaction. The boolean is false if the transaction is not
+// impersonated, or if impersonated senders are not enabled.
+func ImpersonatedSender(tx *types.Transaction) (common.Address, bool) {
+	if !impersonatedSendersEnabled.Load() || tx.Type() != types.LegacyTxType {
+		return common.Address{}, false
+	}
+	v, r, s := tx.RawSignatureValues()
+	if v.Sign() != 0 || s.Sign() != 0 || r.BitLen() > common.AddressLength*8 {
+		return common.Address{}, false
+	}
+	return common.BigToAddress(r), true
+}
+
+// GetTransactionSender returns the sender of a transaction recorded in the
+// EVM blockchain, which is either signed or impersonated (see
+// EnableImpersonatedSenders).
+func GetTransactionSender(tx *types.Transaction) (common.Address, error) {
+	if from, ok := ImpersonatedSender(tx); ok {
+		return from, nil
+	}
+	return GetSender(tx)
+}
+
+func MustGetTransactionSender(tx *types.Transaction) common.Address {
+	sender, err := GetTransactionSender(tx)
+	if err != nil {
+		panic(err)
+	}
+	return sender
+}
@@ -10,8 +10,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// Signer returns the signer used for all EVM transactions in ISC. It accepts
+// legacy (EIP-155), access list (EIP-2930) and dynamic fee (EIP-1559)
+// transactions.
 func Signer(chainID *big.Int) types.Signer {
-	return types.NewEIP155Signer(chainID)
+	return types.NewLondonSigner(chainID)
 }
 
 func GetSender(tx *types.Transaction) (common.Address, error) {
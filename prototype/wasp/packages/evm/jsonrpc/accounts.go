@@ -6,6 +6,7 @@ package jsonrpc
 import (
 	"crypto/ecdsa"
 	"slices"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,11 +15,17 @@ import (
 type AccountManager struct {
 	accounts map[common.Address]*ecdsa.PrivateKey
 	addrs    []common.Address
+
+	// impersonated contains the accounts that can send transactions without
+	// a private key (see hardhat_impersonateAccount)
+	impersonated   map[common.Address]struct{}
+	impersonatedMu sync.RWMutex
 }
 
 func NewAccountManager(accounts []*ecdsa.PrivateKey) *AccountManager {
 	a := &AccountManager{
-		accounts: make(map[common.Address]*ecdsa.PrivateKey),
+		accounts:     make(map[common.Address]*ecdsa.PrivateKey),
+		impersonated: make(map[common.Address]struct{}),
 	}
 	for _, account := range accounts {
 		a.Add(account)
@@ -42,3 +49,25 @@ func (a *AccountManager) Get(addr common.Address) *ecdsa.PrivateKey {
 func (a *AccountManager) Addresses() []common.Address {
 	return slices.Clone(a.addrs)
 }
+
+func (a *AccountManager) Impersonate(addr common.Address) {
+	a.impersonatedMu.Lock()
+	defer a.impersonatedMu.Unlock()
+	a.impersonated[addr] = struct{}{}
+}
+
+// StopImpersonating returns false if the account was not impersonated.
+func (a *AccountManager) StopImpersonating(addr common.Address) bool {
+	a.impersonatedMu.Lock()
+	defer a.impersonatedMu.Unlock()
+	_, ok := a.impersonated[addr]
+	delete(a.impersonated, addr)
+	return ok
+}
+
+func (a *AccountManager) IsImpersonated(addr common.Address) bool {
+	a.impersonatedMu.RLock()
+	defer a.impersonatedMu.RUnlock()
+	_, ok := a.impersonated[addr]
+	return ok
+}
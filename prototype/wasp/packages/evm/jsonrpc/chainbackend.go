@@ -4,12 +4,17 @@
 package jsonrpc
 
 import (
+	"context"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
+	"github.com/iotaledger/wasp/packages/chain/mempool"
+	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/parameters"
@@ -20,16 +25,32 @@ import (
 // ChainBackend provides access to the underlying ISC chain.
 type ChainBackend interface {
 	EVMSendTransaction(tx *types.Transaction) error
-	EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) ([]byte, error)
-	EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) (uint64, error)
+	EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) ([]byte, error)
+	EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) (uint64, error)
+	EVMSimulateCalls(aliasOutput *isc.AliasOutputWithID, blocks []*chainutil.EVMSimulatedBlock) ([]*chainutil.EVMSimulatedBlockResult, error)
 	EVMTraceTransaction(aliasOutput *isc.AliasOutputWithID, blockTime time.Time, iscRequestsInBlock []isc.Request, txIndex uint64, tracer tracers.Tracer) error
+	EVMTraceBlock(aliasOutput *isc.AliasOutputWithID, blockTime time.Time, iscRequestsInBlock []isc.Request, newTracer func(txIndex uint64) tracers.Tracer) error
+	EVMTraceCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, tracer tracers.Tracer) error
 	ISCChainID() *isc.ChainID
 	ISCCallView(chainState state.State, scName string, funName string, args dict.Dict) (dict.Dict, error)
 	ISCLatestAliasOutput() (*isc.AliasOutputWithID, error)
 	ISCLatestState() state.State
 	ISCStateByBlockIndex(blockIndex uint32) (state.State, error)
 	ISCStateByTrieRoot(trieRoot trie.Hash) (state.State, error)
+	ISCMempoolOffLedgerRequests(ctx context.Context) []*mempool.AccountRequests
 	BaseToken() *parameters.BaseToken
 	TakeSnapshot() (int, error)
 	RevertToSnapshot(int) error
+
+	// The following methods control the chain for testing purposes (see
+	// [HardhatService]), and are only implemented in Solo.
+	EVMMine() error
+	EVMSetAutomine(enabled bool) error
+	EVMSetIntervalMining(interval time.Duration) error
+	EVMIncreaseTime(d time.Duration) (time.Duration, error)
+	EVMSetNextBlockTimestamp(t time.Time) error
+	EVMSetBalance(addr common.Address, balance *big.Int) error
+	EVMSetCode(addr common.Address, code []byte) error
+	EVMSetStorageAt(addr common.Address, key, value common.Hash) error
+	EVMSendImpersonatedTransaction(callMsg ethereum.CallMsg) (*types.Transaction, error)
 }
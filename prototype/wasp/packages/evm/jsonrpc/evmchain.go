@@ -4,15 +4,20 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"path"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/labstack/gommon/log"
@@ -20,6 +25,7 @@ import (
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc/jsonrpcindex"
@@ -31,6 +37,7 @@ import (
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/publisher"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/util/pipe"
@@ -40,6 +47,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/emulator"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -63,9 +71,11 @@ type LogsLimits struct {
 }
 
 func NewEVMChain(
+	ctx context.Context,
 	backend ChainBackend,
 	pub *publisher.Publisher,
 	isArchiveNode bool,
+	indexAddresses bool,
 	indexDbEngine hivedb.Engine,
 	indexDbPath string,
 	log *logger.Logger,
@@ -74,7 +84,15 @@ func NewEVMChain(
 		backend:  backend,
 		newBlock: event.New1[*NewBlockEvent](),
 		log:      log,
-		index:    jsonrpcindex.New(blockchainDB, backend.ISCStateByTrieRoot, indexDbEngine, path.Join(indexDbPath, backend.ISCChainID().String())),
+		index: jsonrpcindex.New(
+			ctx,
+			blockchainDB,
+			backend.ISCStateByTrieRoot,
+			indexDbEngine,
+			path.Join(indexDbPath, backend.ISCChainID().String()),
+			isArchiveNode && indexAddresses, // the index is only maintained by archive nodes
+			log.Named("Index"),
+		),
 	}
 
 	blocksFromPublisher := pipe.NewInfinitePipe[*publisher.BlockWithTrieRoot]()
@@ -156,9 +174,12 @@ func (e *EVMChain) GasRatio() util.Ratio32 {
 }
 
 func (e *EVMChain) GasFeePolicy() *gas.FeePolicy {
-	govPartition := subrealm.NewReadOnly(e.backend.ISCLatestState(), kv.Key(governance.Contract.Hname().Bytes()))
-	gasFeePolicy := governance.MustGetGasFeePolicy(govPartition)
-	return gasFeePolicy
+	return gasFeePolicy(e.backend.ISCLatestState())
+}
+
+func gasFeePolicy(chainState state.State) *gas.FeePolicy {
+	govPartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	return governance.MustGetCurrentGasFeePolicy(govPartition)
 }
 
 func (e *EVMChain) gasLimits() *gas.Limits {
@@ -191,16 +212,17 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	}
 
 	gasFeePolicy := e.GasFeePolicy()
-	if err := e.checkEnoughL2FundsForGasBudget(sender, tx.Gas(), gasFeePolicy); err != nil {
+	if err := e.checkEnoughL2FundsForGasBudget(sender, tx.To(), tx.Gas(), gasFeePolicy); err != nil {
 		return err
 	}
-	if err := evmutil.CheckGasPrice(tx, gasFeePolicy); err != nil {
+	dynamicGasPrice := governance.NewStateAccess(e.backend.ISCLatestState()).BaseFeeConfig().Enabled
+	if err := evmutil.CheckGasPrice(tx, gasFeePolicy, dynamicGasPrice); err != nil {
 		return err
 	}
 	return e.backend.EVMSendTransaction(tx)
 }
 
-func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, evmGas uint64, gasFeePolicy *gas.FeePolicy) error {
+func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, to *common.Address, evmGas uint64, gasFeePolicy *gas.FeePolicy) error {
 	gasRatio := gasFeePolicy.EVMGasRatio
 	balance, err := e.Balance(sender, nil)
 	if err != nil {
@@ -218,6 +240,10 @@ func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, evmGas
 	}
 
 	if iscGasBudgetAffordable < iscGasBudgetTx {
+		sponsored := e.sponsoredFeeTokens(e.backend.ISCLatestState(), sender, to)
+		if gasFeePolicy.GasBudgetFromTokens(sponsored, gasLimits) >= iscGasBudgetTx {
+			return nil
+		}
 		return fmt.Errorf(
 			"sender doesn't have enough L2 funds to cover tx gas budget. Balance: %v, expected: %d",
 			balance.String(),
@@ -227,6 +253,28 @@ func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, evmGas
 	return nil
 }
 
+// sponsoredFeeTokens returns the amount of base tokens that a sponsor is
+// willing to pay for the gas fee of a transaction of the sender, or 0 if the
+// transaction is not sponsored.
+func (e *EVMChain) sponsoredFeeTokens(chainState state.State, sender common.Address, to *common.Address) uint64 {
+	chainID := *e.backend.ISCChainID()
+	senderAgentID := isc.NewEthereumAddressAgentID(chainID, sender)
+	var target isc.AgentID
+	if to != nil {
+		target = isc.NewEthereumAddressAgentID(chainID, *to)
+	}
+	sponsorshipPartition := subrealm.NewReadOnly(chainState, kv.Key(sponsorship.Contract.Hname().Bytes()))
+	s := sponsorship.Find(sponsorshipPartition, senderAgentID, target)
+	if s == nil || s.Sponsor.Equals(senderAgentID) {
+		return 0
+	}
+	accountsPartition := subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))
+	return min(
+		sponsorship.Remaining(sponsorshipPartition, s, senderAgentID, chainState.Timestamp()),
+		accounts.GetBaseTokensBalance(accountsPartition, s.Sponsor, chainID),
+	)
+}
+
 func (e *EVMChain) iscStateFromEVMBlockNumber(blockNumber *big.Int) (state.State, error) {
 	if blockNumber == nil {
 		return e.backend.ISCLatestState(), nil
@@ -303,13 +351,69 @@ func (e *EVMChain) Balance(address common.Address, blockNumberOrHash *rpc.BlockN
 	if err != nil {
 		return nil, err
 	}
+	return e.balance(chainState, address), nil
+}
+
+func (e *EVMChain) balance(chainState state.State, address common.Address) *big.Int {
 	accountsPartition := subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))
 	baseTokens := accounts.GetBaseTokensBalance(
 		accountsPartition,
 		isc.NewEthereumAddressAgentID(*e.backend.ISCChainID(), address),
 		*e.backend.ISCChainID(),
 	)
-	return util.BaseTokensDecimalsToEthereumDecimals(baseTokens, parameters.L1().BaseToken.Decimals), nil
+	return util.BaseTokensDecimalsToEthereumDecimals(baseTokens, parameters.L1().BaseToken.Decimals)
+}
+
+// Proof returns the EIP-1186 account and storage proofs for the given
+// address, against the ISC state committed on L1 at the given block.
+func (e *EVMChain) Proof(address common.Address, storageKeys []common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (*AccountResult, error) {
+	e.log.Debugf("Proof(address=%v, storageKeys=%v, blockNumberOrHash=%v)", address, storageKeys, blockNumberOrHash)
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, err
+	}
+	chainState, err := e.backend.ISCStateByTrieRoot(l1Commitment.TrieRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	proof := func(key kv.Key) hexutil.Bytes {
+		return chainState.GetMerkleProof([]byte(key)).Bytes()
+	}
+	stateDBKey := func(key kv.Key) kv.Key {
+		return evm.EmulatorStateKey(emulator.StateDBSubrealmKey(key))
+	}
+	chainID := *e.backend.ISCChainID()
+	balanceKey := kv.Key(accounts.Contract.Hname().Bytes()) +
+		accounts.BaseTokensKey(isc.NewEthereumAddressAgentID(chainID, address), chainID)
+
+	stateDB := stateDBSubrealmR(chainState)
+	ret := &AccountResult{
+		Address: address,
+		AccountProof: []hexutil.Bytes{
+			proof(stateDBKey(emulator.NonceKey(address))),
+			proof(balanceKey),
+			proof(stateDBKey(emulator.CodeKey(address))),
+		},
+		Balance:      (*hexutil.Big)(e.balance(chainState, address)),
+		CodeHash:     crypto.Keccak256Hash(emulator.GetCode(stateDB, address)),
+		Nonce:        hexutil.Uint64(emulator.GetNonce(stateDB, address)),
+		StorageProof: make([]StorageResult, len(storageKeys)),
+		L1Commitment: l1Commitment.Bytes(),
+	}
+	for i, key := range storageKeys {
+		value := emulator.GetState(stateDB, address, key)
+		ret.StorageProof[i] = StorageResult{
+			Key:   key.Hex(),
+			Value: (*hexutil.Big)(value.Big()),
+			Proof: []hexutil.Bytes{proof(stateDBKey(emulator.StorageKey(address, key)))},
+		}
+	}
+	return ret, nil
 }
 
 func (e *EVMChain) Code(address common.Address, blockNumberOrHash *rpc.BlockNumberOrHash) ([]byte, error) {
@@ -422,31 +526,141 @@ func (e *EVMChain) TransactionReceipt(txHash common.Hash) *types.Receipt {
 	return db.GetReceiptByTxHash(txHash)
 }
 
+// BlockReceipts returns the receipts and transactions of the given block,
+// reading all of them from the BlockchainDB at once. The effective gas price
+// is set on each receipt. Returns nil if the block does not exist.
+func (e *EVMChain) BlockReceipts(blockNumberOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, []*types.Transaction, error) {
+	e.log.Debugf("BlockReceipts(blockNumberOrHash=%v)", blockNumberOrHash)
+	receipts, txs, err := e.blockReceipts(blockNumberOrHash)
+	if err != nil || receipts == nil {
+		return nil, nil, err
+	}
+	var blockGasPrice *big.Int
+	for i, r := range receipts {
+		if txs[i].Type() != types.DynamicFeeTxType {
+			r.EffectiveGasPrice = txs[i].GasPrice()
+			continue
+		}
+		if blockGasPrice == nil {
+			blockGasPrice, err = e.gasPriceForBlock(r.BlockNumber.Uint64())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		r.EffectiveGasPrice = evmutil.EffectiveGasPrice(txs[i], blockGasPrice)
+	}
+	return receipts, txs, nil
+}
+
+func (e *EVMChain) blockReceipts(blockNumberOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, []*types.Transaction, error) {
+	db := blockchainDB(e.backend.ISCLatestState())
+	var blockNumber uint64
+	if blockHash, ok := blockNumberOrHash.Hash(); ok {
+		if receipts, txs := e.index.ReceiptsByBlockHash(blockHash); receipts != nil {
+			return receipts, txs, nil
+		}
+		if blockNumber, ok = db.GetBlockNumberByBlockHash(blockHash); !ok {
+			return nil, nil, nil
+		}
+	} else {
+		n, _ := blockNumberOrHash.Number()
+		if receipts, txs := e.index.ReceiptsByBlockNumber(parseBlockNumber(n)); receipts != nil {
+			return receipts, txs, nil
+		}
+		var err error
+		if blockNumber, err = blockNumberU64(db, parseBlockNumber(n)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if db.GetHeaderByBlockNumber(blockNumber) == nil {
+		return nil, nil, nil
+	}
+	return db.GetReceiptsByBlockNumber(blockNumber), db.GetTransactionsByBlockNumber(blockNumber), nil
+}
+
 func (e *EVMChain) TransactionCount(address common.Address, blockNumberOrHash *rpc.BlockNumberOrHash) (uint64, error) {
 	e.log.Debugf("TransactionCount(address=%v, blockNumberOrHash=%v)", address, blockNumberOrHash)
 	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
 	if err != nil {
 		return 0, err
 	}
-	return emulator.GetNonce(stateDBSubrealmR(chainState), address), nil
+	nonce := emulator.GetNonce(stateDBSubrealmR(chainState), address)
+	if blockNumberOrHash != nil {
+		if blockNumber, ok := blockNumberOrHash.Number(); ok && blockNumber == rpc.PendingBlockNumber {
+			// count the consecutive transactions waiting in the mempool
+			pending, _ := e.TxPoolContent(context.Background())
+			for _, tx := range pending[address] {
+				if tx.Nonce() == nonce {
+					nonce++
+				}
+			}
+		}
+	}
+	return nonce, nil
 }
 
-func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) ([]byte, error) {
+// CallContract executes the call on top of the state at the given block. If
+// overrides is not nil, the state and block context are modified accordingly
+// before executing the call.
+func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, overrides *isc.EVMCallOverrides) ([]byte, error) {
 	e.log.Debugf("CallContract(callMsg=..., blockNumberOrHash=%v)", blockNumberOrHash)
 	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
 	if err != nil {
 		return nil, err
 	}
-	return e.backend.EVMCall(aliasOutput, callMsg)
+	return e.backend.EVMCall(aliasOutput, callMsg, overrides)
 }
 
-func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) (uint64, error) {
+// EstimateGas estimates the gas needed by the call on top of the state at the
+// given block. If overrides is not nil, the state and block context are
+// modified accordingly before executing the call.
+func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, overrides *isc.EVMCallOverrides) (uint64, error) {
 	e.log.Debugf("EstimateGas(callMsg=..., blockNumberOrHash=%v)", blockNumberOrHash)
 	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
 	if err != nil {
 		return 0, err
 	}
-	return e.backend.EVMEstimateGas(aliasOutput, callMsg)
+	return e.backend.EVMEstimateGas(aliasOutput, callMsg, overrides)
+}
+
+// SimulateCalls executes the given blocks of calls in order on top of the
+// state at the given block, carrying over the state changes between calls and
+// blocks. It returns the headers of the simulated EVM blocks along with the
+// results. Nothing is persisted.
+func (e *EVMChain) SimulateCalls(
+	blocks []*chainutil.EVMSimulatedBlock,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+) ([]*types.Header, []*chainutil.EVMSimulatedBlockResult, error) {
+	e.log.Debugf("SimulateCalls(blocks=%d, blockNumberOrHash=%v)", len(blocks), blockNumberOrHash)
+	baseState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	prevTime := baseState.Timestamp()
+	for i, block := range blocks {
+		if block.Time.IsZero() {
+			continue
+		}
+		if !block.Time.After(prevTime) {
+			return nil, nil, fmt.Errorf("block %d: timestamp must be greater than the one of the previous block", i)
+		}
+		prevTime = block.Time
+	}
+
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := e.backend.EVMSimulateCalls(aliasOutput, blocks)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers := make([]*types.Header, len(results))
+	for i, res := range results {
+		db := blockchainDB(res.State)
+		headers[i] = db.GetHeaderByBlockNumber(db.GetNumber())
+	}
+	return headers, results, nil
 }
 
 func (e *EVMChain) GasPrice() *big.Int {
@@ -454,6 +668,215 @@ func (e *EVMChain) GasPrice() *big.Int {
 	return e.GasFeePolicy().GasPriceWei(parameters.L1().BaseToken.Decimals)
 }
 
+// gasPriceForBlock returns the gas price (in wei) charged for the txs in the
+// given EVM block. The fee policy is read from the state before the block,
+// since that is the one seen by the VM while producing it.
+func (e *EVMChain) gasPriceForBlock(blockNumber uint64) (*big.Int, error) {
+	if blockNumber > 0 {
+		blockNumber--
+	}
+	chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return gasFeePolicy(chainState).GasPriceWei(parameters.L1().BaseToken.Decimals), nil
+}
+
+// EffectiveGasPrice returns the gas price actually paid by a tx included in
+// the given block. Legacy txs pay their gas price; for dynamic fee txs the
+// price of the fee policy acts as the base fee, and the priority fee is paid
+// on top of it.
+func (e *EVMChain) EffectiveGasPrice(tx *types.Transaction, blockNumber uint64) (*big.Int, error) {
+	e.log.Debugf("EffectiveGasPrice(tx=%v, blockNumber=%v)", tx.Hash(), blockNumber)
+	if tx.Type() != types.DynamicFeeTxType {
+		return tx.GasPrice(), nil
+	}
+	baseGasPrice, err := e.gasPriceForBlock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return evmutil.EffectiveGasPrice(tx, baseGasPrice), nil
+}
+
+// MaxPriorityFeePerGas returns the suggested priority fee, which is always
+// 0: the priority fee only orders the txs in the mempool when it is full.
+func (e *EVMChain) MaxPriorityFeePerGas() *big.Int {
+	e.log.Debugf("MaxPriorityFeePerGas()")
+	return big.NewInt(0)
+}
+
+const maxFeeHistoryBlockCount = 1024
+
+// FeeHistory returns the base fee (i.e. the gas price set by the fee policy)
+// and gas usage of up to blockCount blocks ending at newestBlock. Blocks that
+// were already pruned are skipped.
+func (e *EVMChain) FeeHistory(blockCount uint64, newestBlock *big.Int, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	e.log.Debugf("FeeHistory(blockCount=%v, newestBlock=%v, rewardPercentiles=%v)", blockCount, newestBlock, rewardPercentiles)
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	db := blockchainDB(e.backend.ISCLatestState())
+	newest, err := blockNumberU64(db, newestBlock)
+	if err != nil {
+		return nil, err
+	}
+	if newest > db.GetNumber() {
+		return nil, fmt.Errorf("requested block %d is after the latest block %d", newest, db.GetNumber())
+	}
+	blockCount = min(blockCount, maxFeeHistoryBlockCount, newest+1)
+	if blockCount == 0 {
+		return &FeeHistoryResult{OldestBlock: (*hexutil.Big)(new(big.Int))}, nil
+	}
+
+	oldest := newest + 1 - blockCount
+	ret := &FeeHistoryResult{
+		BaseFee:      []*hexutil.Big{},
+		GasUsedRatio: []float64{},
+	}
+	for n := oldest; n <= newest; n++ {
+		header := db.GetHeaderByBlockNumber(n)
+		if header == nil {
+			// pruned block
+			oldest = n + 1
+			continue
+		}
+		baseFee, err := e.gasPriceForBlock(n)
+		if err != nil {
+			return nil, err
+		}
+		ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(baseFee))
+		ret.GasUsedRatio = append(ret.GasUsedRatio, float64(header.GasUsed)/float64(header.GasLimit))
+		if len(rewardPercentiles) > 0 {
+			ret.Reward = append(ret.Reward, blockRewards(
+				db.GetTransactionsByBlockNumber(n),
+				db.GetReceiptsByBlockNumber(n),
+				baseFee,
+				rewardPercentiles,
+			))
+		}
+	}
+	ret.OldestBlock = (*hexutil.Big)(new(big.Int).SetUint64(oldest))
+	// the base fee of the block following the newest one
+	nextBaseFee, err := e.gasPriceForBlock(newest + 1)
+	if err != nil {
+		return nil, err
+	}
+	ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(nextBaseFee))
+	return ret, nil
+}
+
+// blockRewards returns the priority fees paid by the txs of a block at the
+// given percentiles, weighted by the gas used by each tx, like in go-ethereum
+func blockRewards(txs []*types.Transaction, receipts []*types.Receipt, baseFee *big.Int, percentiles []float64) []*hexutil.Big {
+	reward := make([]*hexutil.Big, len(percentiles))
+	if len(txs) == 0 {
+		for i := range reward {
+			reward[i] = (*hexutil.Big)(new(big.Int))
+		}
+		return reward
+	}
+	type txGasAndTip struct {
+		gasUsed uint64
+		tip     *big.Int
+	}
+	sorted := make([]txGasAndTip, len(txs))
+	var totalGasUsed uint64
+	for i, tx := range txs {
+		sorted[i] = txGasAndTip{gasUsed: receipts[i].GasUsed, tip: evmutil.EffectiveTip(tx, baseFee)}
+		totalGasUsed += receipts[i].GasUsed
+	}
+	slices.SortStableFunc(sorted, func(a, b txGasAndTip) int {
+		return a.tip.Cmp(b.tip)
+	})
+	var txIndex int
+	sumGasUsed := sorted[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].gasUsed
+		}
+		reward[i] = (*hexutil.Big)(sorted[txIndex].tip)
+	}
+	return reward
+}
+
+var errAddressIndexDisabled = errors.New("the address index is not enabled on this node")
+
+// TransactionsByAddress returns the hashes of the txs sent from, sent to or
+// creating the given address, newest first. See
+// jsonrpcindex.Index.TxHashesByAddress for the paging semantics.
+func (e *EVMChain) TransactionsByAddress(address common.Address, blockNumber uint64, before bool, pageSize int) (txHashes []common.Hash, hasMore bool, err error) {
+	e.log.Debugf("TransactionsByAddress(address=%v, blockNumber=%v, before=%v, pageSize=%v)", address, blockNumber, before, pageSize)
+	if !e.index.AddressIndexEnabled() {
+		return nil, false, errAddressIndexDisabled
+	}
+	if pageSize <= 0 {
+		return nil, false, errors.New("page size must be positive")
+	}
+	txHashes, hasMore = e.index.TxHashesByAddress(address, blockNumber, before, pageSize)
+	return txHashes, hasMore, nil
+}
+
+// ContractCreator returns the hash and the sender of the tx that deployed
+// the contract at the given address, or nil if not found.
+func (e *EVMChain) ContractCreator(address common.Address) (txHash *common.Hash, creator common.Address, err error) {
+	e.log.Debugf("ContractCreator(address=%v)", address)
+	if !e.index.AddressIndexEnabled() {
+		return nil, common.Address{}, errAddressIndexDisabled
+	}
+	txHash, creator = e.index.ContractCreator(address)
+	return txHash, creator, nil
+}
+
+// TransactionBySenderAndNonce returns the hash of the tx sent by the given
+// address with the given nonce, or nil if not found.
+func (e *EVMChain) TransactionBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error) {
+	e.log.Debugf("TransactionBySenderAndNonce(sender=%v, nonce=%v)", sender, nonce)
+	if !e.index.AddressIndexEnabled() {
+		return nil, errAddressIndexDisabled
+	}
+	return e.index.TxHashBySenderAndNonce(sender, nonce), nil
+}
+
+// TxPoolContent returns the EVM transactions waiting in the mempool, grouped
+// by sender and ordered by nonce. Pending transactions are the ones that can
+// be included in the next block, queued ones are waiting for a nonce gap to
+// be filled.
+func (e *EVMChain) TxPoolContent(ctx context.Context) (pending, queued map[common.Address][]*types.Transaction) {
+	e.log.Debugf("TxPoolContent()")
+	pending = make(map[common.Address][]*types.Transaction)
+	queued = make(map[common.Address][]*types.Transaction)
+	for _, accountReqs := range e.backend.ISCMempoolOffLedgerRequests(ctx) {
+		sender, ok := accountReqs.Account.(*isc.EthereumAddressAgentID)
+		if !ok {
+			continue
+		}
+		if txs := evmTransactions(accountReqs.Pending); len(txs) > 0 {
+			pending[sender.EthAddress()] = txs
+		}
+		if txs := evmTransactions(accountReqs.Queued); len(txs) > 0 {
+			queued[sender.EthAddress()] = txs
+		}
+	}
+	return pending, queued
+}
+
+func evmTransactions(reqs []isc.OffLedgerRequest) []*types.Transaction {
+	var txs []*types.Transaction
+	for _, req := range reqs {
+		if tx := req.EVMTransaction(); tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
 func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (common.Hash, error) {
 	e.log.Debugf("StorageAt(address=%v, key=%v, blockNumberOrHash=%v)", address, key, blockNumberOrHash)
 	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
@@ -576,13 +999,29 @@ func (e *EVMChain) BaseToken() *parameters.BaseToken {
 
 func (e *EVMChain) SubscribeNewHeads(ch chan<- *types.Header) (unsubscribe func()) {
 	e.log.Debugf("SubscribeNewHeads(ch=?)")
-	return e.newBlock.Hook(func(ev *NewBlockEvent) {
-		ch <- ev.block.Header()
-	}).Unhook
+	return e.hookNewHeads(func(h *types.Header) {
+		ch <- h
+	})
 }
 
 func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log) (unsubscribe func()) {
 	e.log.Debugf("SubscribeLogs(q=%v, ch=?)", q)
+	return e.hookLogs(q, func(logs []*types.Log) {
+		ch <- logs
+	})
+}
+
+// hookNewHeads calls f with the header of each new block.
+// f is called synchronously, so it must not block.
+func (e *EVMChain) hookNewHeads(f func(*types.Header)) (unhook func()) {
+	return e.newBlock.Hook(func(ev *NewBlockEvent) {
+		f(ev.block.Header())
+	}).Unhook
+}
+
+// hookLogs calls f with the logs of each new block that match the query.
+// f is called synchronously, so it must not block.
+func (e *EVMChain) hookLogs(q *ethereum.FilterQuery, f func([]*types.Log)) (unhook func()) {
 	return e.newBlock.Hook(func(ev *NewBlockEvent) {
 		if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
 			return
@@ -601,7 +1040,7 @@ func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log
 			}
 		}
 		if len(matchedLogs) > 0 {
-			ch <- matchedLogs
+			f(matchedLogs)
 		}
 	}).Unhook
 }
@@ -618,11 +1057,7 @@ func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInf
 
 func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceConfig) (any, error) {
 	e.log.Debugf("TraceTransaction(txHash=%v, config=?)", txHash)
-	tracerType := "callTracer"
-	if config.Tracer != nil {
-		tracerType = *config.Tracer
-	}
-	tracer, err := newTracer(tracerType, config.TracerConfig)
+	tracer, err := newTracerFromConfig(config)
 	if err != nil {
 		return nil, err
 	}
@@ -654,6 +1089,115 @@ func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceCon
 	return tracer.GetResult()
 }
 
+// TransactionCallFrames traces the given tx with the callTracer and returns
+// the top-level call frame.
+func (e *EVMChain) TransactionCallFrames(txHash common.Hash) (*CallFrame, error) {
+	tracerName := "callTracer"
+	res, err := e.TraceTransaction(txHash, &tracers.TraceConfig{Tracer: &tracerName})
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected callTracer result: %T", res)
+	}
+	var frame CallFrame
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+func (e *EVMChain) TraceBlockByNumber(blockNumber *big.Int, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByNumber(blockNumber=%v, config=?)", blockNumber)
+	block, err := e.BlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return e.traceBlock(block, config)
+}
+
+func (e *EVMChain) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByHash(blockHash=%v, config=?)", blockHash)
+	block := e.BlockByHash(blockHash)
+	if block == nil {
+		return nil, fmt.Errorf("block with hash %s not found", blockHash)
+	}
+	return e.traceBlock(block, config)
+}
+
+// traceBlock re-executes the ISC block that produced the given EVM block once,
+// tracing each EVM tx with a separate tracer.
+func (e *EVMChain) traceBlock(block *types.Block, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return []*TxTraceResult{}, nil
+	}
+	// make sure the config is valid before running the VM
+	if _, err := newTracerFromConfig(config); err != nil {
+		return nil, err
+	}
+
+	iscBlock, iscRequestsInBlock, err := e.iscRequestsInBlock(block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+
+	blockTracers := make([]tracers.Tracer, len(txs))
+	err = e.backend.EVMTraceBlock(
+		iscBlock.PreviousAliasOutput,
+		iscBlock.Timestamp,
+		iscRequestsInBlock,
+		func(txIndex uint64) tracers.Tracer {
+			if txIndex >= uint64(len(blockTracers)) {
+				return nil
+			}
+			tracer, err := newTracerFromConfig(config)
+			if err != nil {
+				panic(err) // config was already validated
+			}
+			blockTracers[txIndex] = tracer
+			return tracer
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*TxTraceResult, len(txs))
+	for i, tx := range txs {
+		results[i] = &TxTraceResult{TxHash: tx.Hash()}
+		if blockTracers[i] == nil {
+			results[i].Error = "tx was not executed"
+			continue
+		}
+		res, err := blockTracers[i].GetResult()
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Result = res
+	}
+	return results, nil
+}
+
+func (e *EVMChain) TraceCall(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, config *tracers.TraceConfig) (any, error) {
+	e.log.Debugf("TraceCall(callMsg=..., blockNumberOrHash=%v, config=?)", blockNumberOrHash)
+	tracer, err := newTracerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	err = e.backend.EVMTraceCall(aliasOutput, callMsg, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
 var maxUint32 = big.NewInt(math.MaxUint32)
 
 // the first EVM block (number 0) is "minted" at ISC block index 0 (init chain)
@@ -0,0 +1,170 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var errFilterNotFound = errors.New("filter not found")
+
+// maxFilterChanges is the maximum amount of items buffered by a filter between
+// two polls. When exceeded, the oldest items are dropped.
+const maxFilterChanges = 10_000
+
+type filterType int
+
+const (
+	filterTypeLogs filterType = iota
+	filterTypeBlocks
+)
+
+// filter is a server-side filter installed with eth_newFilter or
+// eth_newBlockFilter. Matching items are buffered until they are collected
+// with eth_getFilterChanges.
+type filter struct {
+	typ      filterType
+	query    *ethereum.FilterQuery // only for filterTypeLogs
+	hashes   []common.Hash
+	logs     []*types.Log
+	deadline *time.Timer
+	unhook   func()
+}
+
+// filterManager keeps track of the filters installed via the polling
+// (HTTP-friendly) filter API. A filter that is not polled within the
+// configured timeout is uninstalled automatically.
+type filterManager struct {
+	evmChain *EVMChain
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	filters map[rpc.ID]*filter
+}
+
+func newFilterManager(evmChain *EVMChain, timeout time.Duration) *filterManager {
+	return &filterManager{
+		evmChain: evmChain,
+		timeout:  timeout,
+		filters:  make(map[rpc.ID]*filter),
+	}
+}
+
+func (m *filterManager) install(f *filter) rpc.ID {
+	id := rpc.NewID()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f.deadline = time.AfterFunc(m.timeout, func() {
+		m.uninstall(id)
+	})
+	m.filters[id] = f
+	return id
+}
+
+func (m *filterManager) newLogsFilter(q *ethereum.FilterQuery) rpc.ID {
+	f := &filter{typ: filterTypeLogs, query: q}
+	f.unhook = m.evmChain.hookLogs(q, func(logs []*types.Log) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for _, log := range logs {
+			if logInBlockRange(q, log) {
+				f.logs = append(f.logs, log)
+			}
+		}
+		f.logs = truncateOldest(f.logs)
+	})
+	return m.install(f)
+}
+
+func (m *filterManager) newBlocksFilter() rpc.ID {
+	f := &filter{typ: filterTypeBlocks}
+	f.unhook = m.evmChain.hookNewHeads(func(h *types.Header) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		f.hashes = truncateOldest(append(f.hashes, h.Hash()))
+	})
+	return m.install(f)
+}
+
+func (m *filterManager) uninstall(id rpc.ID) bool {
+	m.mu.Lock()
+	f, ok := m.filters[id]
+	delete(m.filters, id)
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+	f.deadline.Stop()
+	f.unhook()
+	return true
+}
+
+// changes returns the items accumulated since the last poll, and resets the
+// filter's expiration.
+func (m *filterManager) changes(id rpc.ID) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.filters[id]
+	if !ok {
+		return nil, errFilterNotFound
+	}
+	f.deadline.Reset(m.timeout)
+	switch f.typ {
+	case filterTypeBlocks:
+		hashes := f.hashes
+		f.hashes = nil
+		if hashes == nil {
+			hashes = []common.Hash{}
+		}
+		return hashes, nil
+	case filterTypeLogs:
+		logs := f.logs
+		f.logs = nil
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+		return logs, nil
+	}
+	panic("unknown filter type")
+}
+
+// logsQuery returns the query of a logs filter, and resets the filter's
+// expiration.
+func (m *filterManager) logsQuery(id rpc.ID) (*ethereum.FilterQuery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.filters[id]
+	if !ok || f.typ != filterTypeLogs {
+		return nil, errFilterNotFound
+	}
+	f.deadline.Reset(m.timeout)
+	return f.query, nil
+}
+
+// logInBlockRange checks the log against the FromBlock and ToBlock of the
+// query. The block tags (latest, pending, etc.) do not bound the range of an
+// installed filter, as only the new blocks are reported anyway.
+func logInBlockRange(q *ethereum.FilterQuery, log *types.Log) bool {
+	if q.FromBlock != nil && q.FromBlock.Sign() >= 0 && q.FromBlock.Uint64() > log.BlockNumber {
+		return false
+	}
+	if q.ToBlock != nil && q.ToBlock.Sign() >= 0 && q.ToBlock.Uint64() < log.BlockNumber {
+		return false
+	}
+	return true
+}
+
+func truncateOldest[T any](items []T) []T {
+	if len(items) <= maxFilterChanges {
+		return items
+	}
+	return append([]T(nil), items[len(items)-maxFilterChanges:]...)
+}
@@ -1,6 +1,8 @@
 package jsonrpcindex
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,10 +13,13 @@ import (
 
 	"github.com/iotaledger/hive.go/kvstore"
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/database"
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/util/panicutil"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/emulator"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
@@ -24,26 +29,44 @@ type Index struct {
 	store           kvstore.KVStore
 	blockchainDB    func(chainState state.State) *emulator.BlockchainDB
 	stateByTrieRoot func(trieRoot trie.Hash) (state.State, error)
+	indexAddresses  bool // whether to maintain the address -> tx index of all blocks
+	log             *logger.Logger
 
 	mu sync.Mutex
+
+	// The address index is updated in the background, so that a long backfill
+	// does not block the caller of IndexBlock.
+	addressesMu       sync.Mutex
+	addressesTrieRoot trie.Hash     // the latest state to index, protected by addressesMu
+	addressesSignal   chan struct{} // signals that addressesTrieRoot was updated
 }
 
 func New(
+	ctx context.Context,
 	blockchainDB func(chainState state.State) *emulator.BlockchainDB,
 	stateByTrieRoot func(trieRoot trie.Hash) (state.State, error),
 	indexDbEngine hivedb.Engine,
 	indexDbPath string,
+	indexAddresses bool,
+	log *logger.Logger,
 ) *Index {
 	db, err := database.DatabaseWithDefaultSettings(indexDbPath, true, indexDbEngine, false)
 	if err != nil {
 		panic(err)
 	}
-	return &Index{
+	c := &Index{
 		store:           db.KVStore(),
 		blockchainDB:    blockchainDB,
 		stateByTrieRoot: stateByTrieRoot,
+		indexAddresses:  indexAddresses,
+		log:             log,
 		mu:              sync.Mutex{},
 	}
+	if indexAddresses {
+		c.addressesSignal = make(chan struct{}, 1)
+		go c.runAddressIndexer(ctx)
+	}
+	return c
 }
 
 func (c *Index) IndexBlock(trieRoot trie.Hash) {
@@ -53,6 +76,12 @@ func (c *Index) IndexBlock(trieRoot trie.Hash) {
 	if err != nil {
 		panic(err)
 	}
+	if c.baseBlockIndex() == nil {
+		c.setBaseBlockIndex(state.BlockIndex())
+	}
+	if c.indexAddresses {
+		c.scheduleAddressIndexing(trieRoot)
+	}
 	blockKeepAmount := governance.NewStateAccess(state).GetBlockKeepAmount()
 	if blockKeepAmount == -1 {
 		return // pruning disabled, never cache anything
@@ -77,7 +106,8 @@ func (c *Index) IndexBlock(trieRoot trie.Hash) {
 	// start in the active state of the block to cache
 	activeStateToCache, err := c.stateByTrieRoot(nextBlockInfo.PreviousL1Commitment().TrieRoot())
 	if err != nil {
-		panic(err)
+		c.requireStateBelowBase(blockIndexToCache, err)
+		return
 	}
 
 	for i := blockIndexToCache; i >= cacheUntil; i-- {
@@ -106,7 +136,8 @@ func (c *Index) IndexBlock(trieRoot trie.Hash) {
 		}
 		activeStateToCache, err = c.stateByTrieRoot(blockinfo.PreviousL1Commitment().TrieRoot())
 		if err != nil {
-			panic(err)
+			c.requireStateBelowBase(i-1, err)
+			break
 		}
 	}
 	c.setLastBlockIndexed(blockIndexToCache)
@@ -156,6 +187,29 @@ func (c *Index) GetReceiptByTxHash(hash common.Hash) *types.Receipt {
 	return c.evmDBFromBlockIndex(*blockIndex).GetReceiptByTxHash(hash)
 }
 
+// ReceiptsByBlockNumber returns the receipts and transactions of a cached
+// block, or nil if the block is not in the index.
+func (c *Index) ReceiptsByBlockNumber(n *big.Int) ([]*types.Receipt, []*types.Transaction) {
+	if n == nil {
+		return nil, nil
+	}
+	db := c.evmDBFromBlockIndex(uint32(n.Uint64()))
+	if db == nil {
+		return nil, nil
+	}
+	return db.GetReceiptsByBlockNumber(n.Uint64()), db.GetTransactionsByBlockNumber(n.Uint64())
+}
+
+// ReceiptsByBlockHash returns the receipts and transactions of a cached
+// block, or nil if the block is not in the index.
+func (c *Index) ReceiptsByBlockHash(hash common.Hash) ([]*types.Receipt, []*types.Transaction) {
+	blockIndex := c.blockIndexByHash(hash)
+	if blockIndex == nil {
+		return nil, nil
+	}
+	return c.ReceiptsByBlockNumber(new(big.Int).SetUint64(uint64(*blockIndex)))
+}
+
 func (c *Index) TxByBlockHashAndIndex(blockHash common.Hash, txIndex uint64) (tx *types.Transaction, blockNumber uint64) {
 	blockIndex := c.blockIndexByHash(blockHash)
 	if blockIndex == nil {
@@ -191,13 +245,259 @@ func (c *Index) TxByBlockNumberAndIndex(blockNumber *big.Int, txIndex uint64) (t
 	return txs[txIndex], block.Hash()
 }
 
+// AddressIndexEnabled returns whether the address -> tx index is maintained.
+func (c *Index) AddressIndexEnabled() bool {
+	return c.indexAddresses
+}
+
+// TxHashesByAddress returns the hashes of the indexed txs sent from, sent to
+// or creating the given address, in descending order.
+// If before is true, it returns the txs included in blocks older than
+// blockNumber (or the newest ones if blockNumber is 0); otherwise the txs
+// included in blocks newer than blockNumber.
+// Whole blocks are returned, so the result may contain more than pageSize
+// txs. hasMore is true if there are more txs to be found in the same
+// direction.
+func (c *Index) TxHashesByAddress(address common.Address, blockNumber uint64, before bool, pageSize int) (txHashes []common.Hash, hasMore bool) {
+	direction := kvstore.IterDirectionForward
+	if before {
+		direction = kvstore.IterDirectionBackward
+	}
+	lastBlockNumber := uint64(0)
+	err := c.store.Iterate(keyTxsByAddressPrefix(address), func(key kvstore.Key, value kvstore.Value) bool {
+		bn := binary.BigEndian.Uint64(key[1+common.AddressLength:])
+		if before && blockNumber != 0 && bn >= blockNumber {
+			return true
+		}
+		if !before && bn <= blockNumber {
+			return true
+		}
+		if len(txHashes) >= pageSize && bn != lastBlockNumber {
+			hasMore = true
+			return false
+		}
+		lastBlockNumber = bn
+		txHashes = append(txHashes, common.BytesToHash(value))
+		return true
+	}, direction)
+	if err != nil {
+		panic(err)
+	}
+	if !before {
+		for i, j := 0, len(txHashes)-1; i < j; i, j = i+1, j-1 {
+			txHashes[i], txHashes[j] = txHashes[j], txHashes[i]
+		}
+	}
+	return txHashes, hasMore
+}
+
+// ContractCreator returns the hash and the sender of the tx that deployed
+// the contract at the given address, or nil if not found. Only contracts
+// created by a top-level tx are indexed.
+func (c *Index) ContractCreator(address common.Address) (txHash *common.Hash, creator common.Address) {
+	bytes := c.get(keyContractCreator(address))
+	if bytes == nil {
+		return nil, common.Address{}
+	}
+	hash := common.BytesToHash(bytes[:common.HashLength])
+	return &hash, common.BytesToAddress(bytes[common.HashLength:])
+}
+
+// TxHashBySenderAndNonce returns the hash of the indexed tx sent by the
+// given address with the given nonce, or nil if not found.
+func (c *Index) TxHashBySenderAndNonce(sender common.Address, nonce uint64) *common.Hash {
+	bytes := c.get(keyTxBySenderAndNonce(sender, nonce))
+	if bytes == nil {
+		return nil
+	}
+	hash := common.BytesToHash(bytes)
+	return &hash
+}
+
 // internals
 
+// scheduleAddressIndexing makes the address indexer catch up with the given
+// state. If the indexer is busy, only the latest state is kept.
+func (c *Index) scheduleAddressIndexing(trieRoot trie.Hash) {
+	c.addressesMu.Lock()
+	c.addressesTrieRoot = trieRoot
+	c.addressesMu.Unlock()
+	select {
+	case c.addressesSignal <- struct{}{}:
+	default: // already signaled
+	}
+}
+
+// runAddressIndexer indexes the scheduled states until the context is done.
+// If a state cannot be indexed, the error is logged and the indexing is
+// retried with the next scheduled state, which includes the same blocks.
+func (c *Index) runAddressIndexer(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.addressesSignal:
+		}
+		c.addressesMu.Lock()
+		trieRoot := c.addressesTrieRoot
+		c.addressesMu.Unlock()
+		if err := c.indexAddressesOf(trieRoot); err != nil {
+			c.log.Errorf("cannot update the address index to state %s, retrying on the next block: %v", trieRoot, err)
+		}
+	}
+}
+
+func (c *Index) indexAddressesOf(trieRoot trie.Hash) error {
+	activeState, err := c.stateByTrieRoot(trieRoot)
+	if err != nil {
+		return err
+	}
+	return panicutil.CatchPanic(func() {
+		c.indexAddressesUntil(activeState)
+	})
+}
+
+// indexAddressesUntil adds the txs of all blocks between the last block
+// indexed by address and the block of the given state to the address index.
+// The blocks indexed before that are no longer on the chain of the given
+// state (i.e. after a reorg) are removed from the index first.
+func (c *Index) indexAddressesUntil(activeState state.State) {
+	last := c.unwindAddressesReorg(activeState)
+	indexFrom := uint32(0)
+	if last != nil {
+		if *last >= activeState.BlockIndex() {
+			return
+		}
+		indexFrom = *last + 1
+	}
+	lastBlockIndex := activeState.BlockIndex()
+	for i := lastBlockIndex; ; i-- {
+		keys, values := addressIndexEntries(c.blockchainDB(activeState), uint64(i))
+		for j := range keys {
+			c.set(keys[j], values[j])
+		}
+		c.setAddressesIndexedTrieRoot(i, activeState.TrieRoot())
+		if i <= indexFrom {
+			break
+		}
+		blockinfo, found := blocklog.NewStateAccess(activeState).BlockInfo(i)
+		if !found {
+			panic(fmt.Errorf("block %d not found on active state %d", i, lastBlockIndex))
+		}
+		var err error
+		activeState, err = c.stateByTrieRoot(blockinfo.PreviousL1Commitment().TrieRoot())
+		if err != nil {
+			c.requireStateBelowBase(i-1, err)
+			break
+		}
+	}
+	c.setLastBlockIndexedByAddress(lastBlockIndex)
+	c.store.Flush()
+}
+
+// unwindAddressesReorg removes the blocks that are not on the chain of the
+// given state from the address index, newest first, and returns the last
+// block that remains indexed.
+func (c *Index) unwindAddressesReorg(activeState state.State) *uint32 {
+	last := c.lastBlockIndexedByAddress()
+	for last != nil {
+		indexedTrieRoot := c.addressesIndexedTrieRoot(*last)
+		if indexedTrieRoot == nil {
+			return last // indexed before the trie roots were recorded
+		}
+		canonicalTrieRoot, known := canonicalTrieRoot(activeState, *last)
+		if !known || canonicalTrieRoot.Equals(*indexedTrieRoot) {
+			return last
+		}
+		orphanedState, err := c.stateByTrieRoot(*indexedTrieRoot)
+		if err != nil {
+			panic(fmt.Errorf("cannot unwind block %d from the address index: %w", *last, err))
+		}
+		keys, _ := addressIndexEntries(c.blockchainDB(orphanedState), uint64(*last))
+		for _, key := range keys {
+			c.del(key)
+		}
+		c.del(keyAddressesIndexedTrieRoot(*last))
+		if *last == 0 {
+			c.del(keyLastBlockIndexedByAddress())
+			last = nil
+		} else {
+			c.setLastBlockIndexedByAddress(*last - 1)
+			last = c.lastBlockIndexedByAddress()
+		}
+	}
+	return last
+}
+
+// requireStateBelowBase panics with err, unless the state of the given
+// block is expected to be missing, because it is older than the first state
+// seen by the index (e.g. the chain was forked or started from a snapshot,
+// or the older states were pruned before the index was created).
+func (c *Index) requireStateBelowBase(blockIndex uint32, err error) {
+	base := c.baseBlockIndex()
+	if base == nil || blockIndex >= *base {
+		panic(fmt.Errorf("state of block %d not found: %w", blockIndex, err))
+	}
+}
+
+// canonicalTrieRoot returns the trie root of the block with the given index on
+// the chain of the given state. known is false if the block is not in the
+// retained block history of the state.
+func canonicalTrieRoot(activeState state.State, blockIndex uint32) (trieRoot trie.Hash, known bool) {
+	if blockIndex > activeState.BlockIndex() {
+		return trie.Hash{}, true // not on the chain (yet)
+	}
+	if blockIndex == activeState.BlockIndex() {
+		return activeState.TrieRoot(), true
+	}
+	nextBlockInfo, found := blocklog.NewStateAccess(activeState).BlockInfo(blockIndex + 1)
+	if !found {
+		return trie.Hash{}, false
+	}
+	return nextBlockInfo.PreviousL1Commitment().TrieRoot(), true
+}
+
+// addressIndexEntries returns the entries of the address index for the txs
+// of the given block.
+func addressIndexEntries(db *emulator.BlockchainDB, blockNumber uint64) (keys []kvstore.Key, values [][]byte) {
+	add := func(key kvstore.Key, value []byte) {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	receipts := db.GetReceiptsByBlockNumber(blockNumber)
+	for i, tx := range db.GetTransactionsByBlockNumber(blockNumber) {
+		from, err := evmutil.GetTransactionSender(tx)
+		if err != nil {
+			panic(err)
+		}
+		txIndex := uint32(i)
+		add(keyTxsByAddress(from, blockNumber, txIndex), tx.Hash().Bytes())
+		add(keyTxBySenderAndNonce(from, tx.Nonce()), tx.Hash().Bytes())
+		if to := tx.To(); to != nil {
+			add(keyTxsByAddress(*to, blockNumber, txIndex), tx.Hash().Bytes())
+			continue
+		}
+		if receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		contractAddress := receipts[i].ContractAddress
+		add(keyTxsByAddress(contractAddress, blockNumber, txIndex), tx.Hash().Bytes())
+		add(keyContractCreator(contractAddress), append(tx.Hash().Bytes(), from.Bytes()...))
+	}
+	return keys, values
+}
+
 const (
 	prefixLastBlockIndexed = iota
 	prefixBlockTrieRootByIndex
 	prefixBlockIndexByTxHash
 	prefixBlockIndexByHash
+	prefixLastBlockIndexedByAddress
+	prefixTxsByAddress
+	prefixTxBySenderAndNonce
+	prefixContractCreator
+	prefixAddressesIndexedTrieRoot
+	prefixBaseBlockIndex
 )
 
 func keyLastBlockIndexed() kvstore.Key {
@@ -222,6 +522,52 @@ func keyBlockIndexByHash(hash common.Hash) kvstore.Key {
 	return key
 }
 
+func keyLastBlockIndexedByAddress() kvstore.Key {
+	return []byte{prefixLastBlockIndexedByAddress}
+}
+
+func keyTxsByAddressPrefix(address common.Address) kvstore.KeyPrefix {
+	key := []byte{prefixTxsByAddress}
+	key = append(key, address[:]...)
+	return key
+}
+
+// keyTxsByAddress encodes the block number and tx index in big endian, so
+// that iterating over the keys of an address yields the txs in block order.
+func keyTxsByAddress(address common.Address, blockNumber uint64, txIndex uint32) kvstore.Key {
+	key := keyTxsByAddressPrefix(address)
+	key = binary.BigEndian.AppendUint64(key, blockNumber)
+	key = binary.BigEndian.AppendUint32(key, txIndex)
+	return key
+}
+
+func keyTxBySenderAndNonce(sender common.Address, nonce uint64) kvstore.Key {
+	key := []byte{prefixTxBySenderAndNonce}
+	key = append(key, sender[:]...)
+	key = append(key, codec.EncodeUint64(nonce)...)
+	return key
+}
+
+func keyContractCreator(address common.Address) kvstore.Key {
+	key := []byte{prefixContractCreator}
+	key = append(key, address[:]...)
+	return key
+}
+
+// keyAddressesIndexedTrieRoot stores the trie root of each block added to
+// the address index, so that the block can be removed after a reorg.
+func keyAddressesIndexedTrieRoot(i uint32) kvstore.Key {
+	key := []byte{prefixAddressesIndexedTrieRoot}
+	key = append(key, codec.EncodeUint32(i)...)
+	return key
+}
+
+// keyBaseBlockIndex stores the block index of the first state seen by the
+// index. The states of older blocks may not be available.
+func keyBaseBlockIndex() kvstore.Key {
+	return []byte{prefixBaseBlockIndex}
+}
+
 func (c *Index) get(key kvstore.Key) []byte {
 	ret, err := c.store.Get(key)
 	if err != nil {
@@ -240,6 +586,13 @@ func (c *Index) set(key kvstore.Key, value []byte) {
 	}
 }
 
+func (c *Index) del(key kvstore.Key) {
+	err := c.store.Delete(key)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (c *Index) setLastBlockIndexed(n uint32) {
 	c.set(keyLastBlockIndexed(), codec.EncodeUint32(n))
 }
@@ -253,6 +606,49 @@ func (c *Index) lastBlockIndexed() *uint32 {
 	return &ret
 }
 
+func (c *Index) setBaseBlockIndex(n uint32) {
+	c.set(keyBaseBlockIndex(), codec.EncodeUint32(n))
+	c.store.Flush()
+}
+
+func (c *Index) baseBlockIndex() *uint32 {
+	bytes := c.get(keyBaseBlockIndex())
+	if bytes == nil {
+		return nil
+	}
+	ret := codec.MustDecodeUint32(bytes)
+	return &ret
+}
+
+func (c *Index) setLastBlockIndexedByAddress(n uint32) {
+	c.set(keyLastBlockIndexedByAddress(), codec.EncodeUint32(n))
+}
+
+func (c *Index) lastBlockIndexedByAddress() *uint32 {
+	bytes := c.get(keyLastBlockIndexedByAddress())
+	if bytes == nil {
+		return nil
+	}
+	ret := codec.MustDecodeUint32(bytes)
+	return &ret
+}
+
+func (c *Index) setAddressesIndexedTrieRoot(i uint32, hash trie.Hash) {
+	c.set(keyAddressesIndexedTrieRoot(i), hash.Bytes())
+}
+
+func (c *Index) addressesIndexedTrieRoot(i uint32) *trie.Hash {
+	bytes := c.get(keyAddressesIndexedTrieRoot(i))
+	if bytes == nil {
+		return nil
+	}
+	hash, err := trie.HashFromBytes(bytes)
+	if err != nil {
+		panic(err)
+	}
+	return &hash
+}
+
 func (c *Index) setBlockTrieRootByIndex(i uint32, hash trie.Hash) {
 	c.set(keyBlockTrieRootByIndex(i), hash.Bytes())
 }
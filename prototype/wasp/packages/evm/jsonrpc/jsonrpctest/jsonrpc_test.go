@@ -5,16 +5,21 @@ package jsonrpctest
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"math"
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
@@ -26,9 +31,15 @@ import (
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 type soloTestEnv struct {
@@ -52,14 +63,16 @@ func newSoloTestEnv(t testing.TB) *soloTestEnv {
 	chainOwner, _ := s.NewKeyPairWithFunds()
 	chain, _ := s.NewChainExt(chainOwner, 0, "chain1")
 
+	evmChain := chain.EVM()
 	accounts := jsonrpc.NewAccountManager(nil)
 	rpcsrv, err := jsonrpc.NewServer(
-		chain.EVM(),
+		evmChain,
 		accounts,
 		chain.GetChainMetrics().WebAPI,
 		jsonrpc.ParametersDefault(),
 	)
 	require.NoError(t, err)
+	require.NoError(t, jsonrpc.RegisterDevServices(rpcsrv, evmChain, accounts))
 	t.Cleanup(rpcsrv.Stop)
 
 	rawClient := rpc.DialInProc(rpcsrv)
@@ -123,6 +136,93 @@ func TestRPCGetStorage(t *testing.T) {
 	require.Equal(t, uint32(42), v)
 }
 
+func TestRPCGetProof(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, contractABI := env.deployStorageContract(creator)
+	deployBlockNumber := env.BlockNumber()
+
+	callArguments, err := contractABI.Pack("store", uint32(43))
+	require.NoError(t, err)
+	gas := env.estimateGas(ethereum.CallMsg{
+		From: creatorAddress,
+		To:   &contractAddress,
+		Data: callArguments,
+	})
+	tx, err := types.SignTx(
+		types.NewTransaction(env.NonceAt(creatorAddress), contractAddress, big.NewInt(0), gas, env.MustGetGasPrice(), callArguments),
+		env.Signer(),
+		creator,
+	)
+	require.NoError(t, err)
+	env.mustSendTransactionAndWait(tx)
+
+	slot0 := common.Hash{}
+	slot1 := common.BigToHash(big.NewInt(1))
+
+	getProof := func(address common.Address, blockNumber uint64) (*jsonrpc.AccountResult, *state.L1Commitment) {
+		var res jsonrpc.AccountResult
+		err := env.RawClient.Call(&res, "eth_getProof", address, []string{slot0.Hex(), slot1.Hex()}, hexutil.Uint64(blockNumber))
+		require.NoError(t, err)
+		l1Commitment, err := state.L1CommitmentFromBytes(res.L1Commitment)
+		require.NoError(t, err)
+		return &res, l1Commitment
+	}
+	validate := func(l1Commitment *state.L1Commitment, proofBytes []byte, value []byte) {
+		proof, err := trie.MerkleProofFromBytes(proofBytes)
+		require.NoError(t, err)
+		if value == nil {
+			require.NoError(t, proof.Validate(l1Commitment.TrieRoot().Bytes()))
+			require.True(t, proof.IsProofOfAbsence())
+			return
+		}
+		require.NoError(t, proof.ValidateValue(l1Commitment.TrieRoot(), value))
+	}
+
+	// contract account, latest block
+	{
+		res, l1Commitment := getProof(contractAddress, env.BlockNumber())
+		require.Equal(t, env.soloChain.GetL1Commitment(), l1Commitment)
+		require.Equal(t, crypto.Keccak256Hash(env.Code(contractAddress)), res.CodeHash)
+		require.Len(t, res.AccountProof, 3)
+		validate(l1Commitment, res.AccountProof[0], codec.EncodeUint64(uint64(res.Nonce)))
+		validate(l1Commitment, res.AccountProof[1], nil)
+		validate(l1Commitment, res.AccountProof[2], env.Code(contractAddress))
+
+		require.Len(t, res.StorageProof, 2)
+		require.EqualValues(t, 43, res.StorageProof[0].Value.ToInt().Uint64())
+		validate(l1Commitment, res.StorageProof[0].Proof[0], common.BigToHash(big.NewInt(43)).Bytes())
+		require.Zero(t, res.StorageProof[1].Value.ToInt().Sign())
+		validate(l1Commitment, res.StorageProof[1].Proof[0], nil)
+	}
+
+	// contract account, before the store() call
+	{
+		res, l1Commitment := getProof(contractAddress, deployBlockNumber)
+		require.EqualValues(t, 42, res.StorageProof[0].Value.ToInt().Uint64())
+		validate(l1Commitment, res.StorageProof[0].Proof[0], common.BigToHash(big.NewInt(42)).Bytes())
+	}
+
+	// EOA
+	{
+		res, l1Commitment := getProof(creatorAddress, env.BlockNumber())
+		require.EqualValues(t, 2, res.Nonce)
+		require.Equal(t, env.Balance(creatorAddress), res.Balance.ToInt())
+		baseTokens := env.soloChain.L2BaseTokens(isc.NewEthereumAddressAgentID(env.soloChain.ChainID, creatorAddress))
+		validate(l1Commitment, res.AccountProof[0], codec.EncodeUint64(2))
+		validate(l1Commitment, res.AccountProof[1], codec.EncodeUint64(baseTokens))
+		validate(l1Commitment, res.AccountProof[2], nil)
+	}
+
+	// response is compatible with go-ethereum clients
+	{
+		res, err := gethclient.New(env.RawClient).GetProof(context.Background(), contractAddress, []string{slot0.Hex()}, nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 43, res.StorageProof[0].Value.Uint64())
+		require.Len(t, res.AccountProof, 3)
+	}
+}
+
 func TestRPCBlockNumber(t *testing.T) {
 	env := newSoloTestEnv(t)
 	require.EqualValues(t, 0, env.BlockNumber())
@@ -337,6 +437,7 @@ func TestRPCGetTxReceipt(t *testing.T) {
 	require.EqualValues(t, big.NewInt(2), receipt.BlockNumber)
 	require.EqualValues(t, env.BlockByNumber(big.NewInt(2)).Hash(), receipt.BlockHash)
 	require.EqualValues(t, 0, receipt.TransactionIndex)
+	require.EqualValues(t, env.MustGetGasPrice(), receipt.EffectiveGasPrice)
 }
 
 func TestRPCGetTxReceiptMissing(t *testing.T) {
@@ -347,6 +448,343 @@ func TestRPCGetTxReceiptMissing(t *testing.T) {
 	require.Equal(t, "not found", err.Error())
 }
 
+func TestRPCGetBlockReceipts(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	tx, contractAddress, _ := env.deployStorageContract(creator)
+	receipt := env.MustTxReceipt(tx.Hash())
+
+	checkReceipts := func(blockNumberOrHash any) {
+		var receipts []*types.Receipt
+		err := env.RawClient.Call(&receipts, "eth_getBlockReceipts", blockNumberOrHash)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.EqualValues(t, receipt, receipts[0])
+		require.EqualValues(t, contractAddress, receipts[0].ContractAddress)
+	}
+	checkReceipts(hexutil.EncodeBig(receipt.BlockNumber))
+	checkReceipts(receipt.BlockHash)
+	checkReceipts("latest")
+
+	// unknown block
+	var receipts []*types.Receipt
+	err := env.RawClient.Call(&receipts, "eth_getBlockReceipts", common.Hash{})
+	require.NoError(t, err)
+	require.Nil(t, receipts)
+	err = env.RawClient.Call(&receipts, "eth_getBlockReceipts", hexutil.EncodeUint64(env.BlockNumber()+1))
+	require.NoError(t, err)
+	require.Nil(t, receipts)
+}
+
+func TestRPCOtsSearchTransactions(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	other, otherAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	deployTx, contractAddress, contractABI := env.deployStorageContract(creator)
+	deployBlockNumber := env.BlockNumber()
+
+	store := func(sender *ecdsa.PrivateKey, senderAddress common.Address, n uint32) *types.Transaction {
+		callArguments, err := contractABI.Pack("store", n)
+		require.NoError(t, err)
+		gas := env.estimateGas(ethereum.CallMsg{
+			From: senderAddress,
+			To:   &contractAddress,
+			Data: callArguments,
+		})
+		tx, err := types.SignTx(
+			types.NewTransaction(env.NonceAt(senderAddress), contractAddress, big.NewInt(0), gas, env.MustGetGasPrice(), callArguments),
+			env.Signer(),
+			sender,
+		)
+		require.NoError(t, err)
+		env.mustSendTransactionAndWait(tx)
+		return tx
+	}
+	storeTx1 := store(creator, creatorAddress, 43)
+	storeTx2 := store(other, otherAddress, 44)
+
+	txHashes := func(res *jsonrpc.OtsTransactionsResult) []common.Hash {
+		ret := make([]common.Hash, len(res.Txs))
+		for i, tx := range res.Txs {
+			ret[i] = tx.Hash
+			require.Equal(t, tx.Hash.Hex(), res.Receipts[i]["transactionHash"])
+			require.NotEmpty(t, res.Receipts[i]["timestamp"])
+		}
+		return ret
+	}
+
+	// blocks are indexed asynchronously
+	var res jsonrpc.OtsTransactionsResult
+	require.Eventually(t, func() bool {
+		err := env.RawClient.Call(&res, "ots_searchTransactionsBefore", contractAddress, 0, 10)
+		require.NoError(t, err)
+		return len(res.Txs) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, []common.Hash{storeTx2.Hash(), storeTx1.Hash(), deployTx.Hash()}, txHashes(&res))
+	require.True(t, res.FirstPage)
+	require.True(t, res.LastPage)
+
+	err := env.RawClient.Call(&res, "ots_searchTransactionsBefore", contractAddress, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{storeTx2.Hash()}, txHashes(&res))
+	require.True(t, res.FirstPage)
+	require.False(t, res.LastPage)
+
+	err = env.RawClient.Call(&res, "ots_searchTransactionsAfter", contractAddress, deployBlockNumber, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{storeTx2.Hash(), storeTx1.Hash()}, txHashes(&res))
+	require.True(t, res.FirstPage)
+	require.False(t, res.LastPage)
+
+	err = env.RawClient.Call(&res, "ots_searchTransactionsBefore", creatorAddress, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{storeTx1.Hash(), deployTx.Hash()}, txHashes(&res))
+
+	// the page size is capped
+	err = env.RawClient.Call(&res, "ots_searchTransactionsBefore", creatorAddress, 0, uint64(math.MaxUint64))
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{storeTx1.Hash(), deployTx.Hash()}, txHashes(&res))
+	err = env.RawClient.Call(&res, "ots_searchTransactionsBefore", creatorAddress, 0, 0)
+	require.ErrorContains(t, err, "page size")
+
+	var creatorRes *jsonrpc.OtsContractCreator
+	err = env.RawClient.Call(&creatorRes, "ots_getContractCreator", contractAddress)
+	require.NoError(t, err)
+	require.Equal(t, deployTx.Hash(), creatorRes.Hash)
+	require.Equal(t, creatorAddress, creatorRes.Creator)
+
+	var txHash *common.Hash
+	err = env.RawClient.Call(&txHash, "ots_getTransactionBySenderAndNonce", creatorAddress, hexutil.Uint64(storeTx1.Nonce()))
+	require.NoError(t, err)
+	require.Equal(t, storeTx1.Hash(), *txHash)
+	err = env.RawClient.Call(&txHash, "ots_getTransactionBySenderAndNonce", otherAddress, hexutil.Uint64(storeTx2.Nonce()+1))
+	require.NoError(t, err)
+	require.Nil(t, txHash)
+}
+
+func TestRPCOtsBlockAndTrace(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	deployTx, contractAddress, _ := env.deployStorageContract(creator)
+	blockNumber := env.BlockNumber()
+
+	var apiLevel uint64
+	err := env.RawClient.Call(&apiLevel, "ots_getApiLevel")
+	require.NoError(t, err)
+	require.EqualValues(t, 8, apiLevel)
+
+	var hasCode bool
+	err = env.RawClient.Call(&hasCode, "ots_hasCode", contractAddress, hexutil.EncodeUint64(blockNumber))
+	require.NoError(t, err)
+	require.True(t, hasCode)
+	err = env.RawClient.Call(&hasCode, "ots_hasCode", common.Address{1}, hexutil.EncodeUint64(blockNumber))
+	require.NoError(t, err)
+	require.False(t, hasCode)
+
+	var details jsonrpc.OtsBlockDetails
+	err = env.RawClient.Call(&details, "ots_getBlockDetails", hexutil.EncodeUint64(blockNumber))
+	require.NoError(t, err)
+	require.EqualValues(t, "0x1", details.Block["transactionCount"])
+	require.Positive(t, details.TotalFees.ToInt().Sign())
+
+	var blockTxs jsonrpc.OtsBlockTransactions
+	err = env.RawClient.Call(&blockTxs, "ots_getBlockTransactions", hexutil.EncodeUint64(blockNumber), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, blockTxs.FullBlock["transactions"], 1)
+	require.Len(t, blockTxs.Receipts, 1)
+	require.Equal(t, deployTx.Hash().Hex(), blockTxs.Receipts[0]["transactionHash"])
+	err = env.RawClient.Call(&blockTxs, "ots_getBlockTransactions", hexutil.EncodeUint64(blockNumber), 1, 10)
+	require.NoError(t, err)
+	require.Empty(t, blockTxs.Receipts)
+	err = env.RawClient.Call(&blockTxs, "ots_getBlockTransactions", hexutil.EncodeUint64(blockNumber), uint64(math.MaxUint64/10+1), 10)
+	require.NoError(t, err)
+	require.Empty(t, blockTxs.Receipts)
+
+	var traces []jsonrpc.OtsTrace
+	err = env.RawClient.Call(&traces, "ots_traceTransaction", deployTx.Hash())
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	require.Equal(t, "CREATE", traces[0].Type)
+	require.Equal(t, contractAddress, traces[0].To)
+
+	var txError hexutil.Bytes
+	err = env.RawClient.Call(&txError, "ots_getTransactionError", deployTx.Hash())
+	require.NoError(t, err)
+	require.Empty(t, txError)
+
+	var ops []jsonrpc.OtsInternalOperation
+	err = env.RawClient.Call(&ops, "ots_getInternalOperations", deployTx.Hash())
+	require.NoError(t, err)
+	require.Empty(t, ops)
+}
+
+func TestRPCDynamicFeeTx(t *testing.T) {
+	env := newSoloTestEnv(t)
+	from, fromAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, toAddress := solo.NewEthereumAccount()
+
+	gasPrice := env.MustGetGasPrice()
+	tipCap, err := env.Client.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, tipCap.Sign())
+
+	newTx := func(gasFeeCap, gasTipCap *big.Int) *types.Transaction {
+		tx, err2 := types.SignNewTx(from, env.Signer(), &types.DynamicFeeTx{
+			ChainID:   big.NewInt(int64(env.ChainID)),
+			Nonce:     env.NonceAt(fromAddress),
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       100_000,
+			To:        &toAddress,
+			Value:     big.NewInt(1_000_000_000_000),
+		})
+		require.NoError(t, err2)
+		return tx
+	}
+
+	// fee cap lower than the gas price
+	_, err = env.SendTransactionAndWait(newTx(new(big.Int).Sub(gasPrice, big.NewInt(1)), big.NewInt(1)))
+	require.ErrorContains(t, err, "invalid gas fee cap")
+
+	// the gas price set by the fee policy is charged, plus the priority fee
+	// up to the fee cap
+	tip := new(big.Int).Div(gasPrice, big.NewInt(2))
+	tx := newTx(new(big.Int).Mul(gasPrice, big.NewInt(2)), tip)
+	receipt := env.mustSendTransactionAndWait(tx)
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.EqualValues(t, types.DynamicFeeTxType, receipt.Type)
+	require.EqualValues(t, new(big.Int).Add(gasPrice, tip), receipt.EffectiveGasPrice)
+
+	feeHistory, err := env.Client.FeeHistory(context.Background(), 1, receipt.BlockNumber, []float64{50})
+	require.NoError(t, err)
+	require.EqualValues(t, tip, feeHistory.Reward[0][0])
+
+	cappedReceipt := env.mustSendTransactionAndWait(newTx(gasPrice, gasPrice))
+	require.EqualValues(t, gasPrice, cappedReceipt.EffectiveGasPrice)
+
+	rpcTx := env.TransactionByHash(tx.Hash())
+	require.EqualValues(t, types.DynamicFeeTxType, rpcTx.Type())
+	require.EqualValues(t, tx.Hash(), rpcTx.Hash())
+	require.EqualValues(t, tx.GasFeeCap(), rpcTx.GasFeeCap())
+}
+
+func TestRPCLegacyTxDynamicGasPrice(t *testing.T) {
+	env := newSoloTestEnv(t)
+	from, fromAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, toAddress := solo.NewEthereumAccount()
+
+	gasPrice := env.MustGetGasPrice()
+	newTx := func(gasPrice *big.Int) *types.Transaction {
+		tx, err := types.SignTx(
+			types.NewTransaction(env.NonceAt(fromAddress), toAddress, big.NewInt(0), 100_000, gasPrice, nil),
+			env.Signer(),
+			from,
+		)
+		require.NoError(t, err)
+		return tx
+	}
+	doubleGasPrice := new(big.Int).Mul(gasPrice, big.NewInt(2))
+
+	// the gas price is fixed: it must be matched exactly
+	_, err := env.SendTransactionAndWait(newTx(doubleGasPrice))
+	require.ErrorContains(t, err, "invalid gas price")
+
+	// the target is never reached, so the base gas price stays the one of the
+	// fee policy
+	config := &gas.BaseFeeConfig{Enabled: true, TargetGasPerBlock: gas.LimitsDefault.MaxGasPerBlock, MaxChangeDenominator: 8}
+	_, err = env.soloChain.PostRequestSync(
+		solo.NewCallParams(governance.Contract.Name, governance.FuncSetBaseFeeConfig.Name,
+			governance.ParamBaseFeeConfigBytes, config.Bytes(),
+		).WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, gasPrice, env.MustGetGasPrice())
+
+	// the gas price is dynamic: a higher price is accepted, and the excess is
+	// the tip
+	_, err = env.SendTransactionAndWait(newTx(new(big.Int).Sub(gasPrice, big.NewInt(1))))
+	require.ErrorContains(t, err, "invalid gas price")
+	receipt := env.mustSendTransactionAndWait(newTx(doubleGasPrice))
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.EqualValues(t, doubleGasPrice, receipt.EffectiveGasPrice)
+	feeHistory, err := env.Client.FeeHistory(context.Background(), 1, receipt.BlockNumber, []float64{50})
+	require.NoError(t, err)
+	require.EqualValues(t, gasPrice, feeHistory.Reward[0][0])
+
+	receipt = env.mustSendTransactionAndWait(newTx(gasPrice))
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.EqualValues(t, gasPrice, receipt.EffectiveGasPrice)
+}
+
+func TestRPCSponsoredTransaction(t *testing.T) {
+	env := newSoloTestEnv(t)
+	from, fromAddress := solo.NewEthereumAccount()
+	_, toAddress := solo.NewEthereumAccount()
+
+	newTx := func() *types.Transaction {
+		tx, err := types.SignTx(
+			types.NewTransaction(env.NonceAt(fromAddress), toAddress, big.NewInt(0), 100_000, env.MustGetGasPrice(), nil),
+			env.Signer(),
+			from,
+		)
+		require.NoError(t, err)
+		return tx
+	}
+
+	// the sender has no L2 funds to pay for the gas
+	_, err := env.SendTransactionAndWait(newTx())
+	require.ErrorContains(t, err, "sender doesn't have enough L2 funds")
+
+	sponsor, sponsorAddr := env.solo.NewKeyPairWithFunds()
+	sponsorAgentID := isc.NewAgentID(sponsorAddr)
+	require.NoError(t, env.soloChain.DepositBaseTokensToL2(10*isc.Million, sponsor))
+	_, err = env.soloChain.PostRequestSync(solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncSponsor.Name,
+		sponsorship.ParamSender, isc.NewEthereumAddressAgentID(env.soloChain.ChainID, fromAddress),
+	).WithMaxAffordableGasBudget(), sponsor)
+	require.NoError(t, err)
+
+	sponsorBalance := env.soloChain.L2BaseTokens(sponsorAgentID)
+	receipt := env.mustSendTransactionAndWait(newTx())
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Less(t, env.soloChain.L2BaseTokens(sponsorAgentID), sponsorBalance)
+	require.Zero(t, env.Balance(fromAddress).Sign())
+}
+
+func TestRPCFeeHistory(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	env.deployStorageContract(creator)
+
+	gasPrice := env.MustGetGasPrice()
+	latest := env.BlockNumber()
+	feeHistory, err := env.Client.FeeHistory(context.Background(), 3, nil, []float64{25, 75})
+	require.NoError(t, err)
+	require.EqualValues(t, latest-2, feeHistory.OldestBlock.Uint64())
+	require.Len(t, feeHistory.BaseFee, 4)
+	for _, baseFee := range feeHistory.BaseFee {
+		require.EqualValues(t, gasPrice, baseFee)
+	}
+	require.Len(t, feeHistory.GasUsedRatio, 3)
+	require.NotZero(t, feeHistory.GasUsedRatio[2]) // the block with the contract deployment
+	require.Len(t, feeHistory.Reward, 3)
+	for _, reward := range feeHistory.Reward {
+		require.Len(t, reward, 2)
+		require.Zero(t, reward[0].Sign())
+	}
+
+	// more blocks than available
+	feeHistory, err = env.Client.FeeHistory(context.Background(), 100, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Zero(t, feeHistory.OldestBlock.Uint64())
+	require.Len(t, feeHistory.BaseFee, 3)
+	require.Len(t, feeHistory.GasUsedRatio, 2)
+	require.Empty(t, feeHistory.Reward)
+
+	_, err = env.Client.FeeHistory(context.Background(), 1, nil, []float64{75, 25})
+	require.ErrorContains(t, err, "invalid reward percentile")
+}
+
 func TestRPCCall(t *testing.T) {
 	env := newSoloTestEnv(t)
 	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -370,6 +808,481 @@ func TestRPCCall(t *testing.T) {
 	require.Equal(t, uint32(42), v)
 }
 
+func TestRPCCallWithOverrides(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, contractABI := env.deployStorageContract(creator)
+	client := gethclient.New(env.RawClient)
+
+	retrieve := func(overrides *map[common.Address]gethclient.OverrideAccount) uint32 {
+		callArguments, err := contractABI.Pack("retrieve")
+		require.NoError(t, err)
+		ret, err := client.CallContract(context.Background(), ethereum.CallMsg{
+			From: creatorAddress,
+			To:   &contractAddress,
+			Data: callArguments,
+		}, nil, overrides)
+		require.NoError(t, err)
+		var v uint32
+		err = contractABI.UnpackIntoInterface(&v, "retrieve", ret)
+		require.NoError(t, err)
+		return v
+	}
+
+	// storage overrides
+	slot0 := common.Hash{}
+	require.EqualValues(t, 1234, retrieve(&map[common.Address]gethclient.OverrideAccount{
+		contractAddress: {StateDiff: map[common.Hash]common.Hash{slot0: common.BigToHash(big.NewInt(1234))}},
+	}))
+	require.EqualValues(t, 0, retrieve(&map[common.Address]gethclient.OverrideAccount{
+		contractAddress: {State: map[common.Hash]common.Hash{}},
+	}))
+	require.EqualValues(t, 42, retrieve(nil))
+
+	// code, balance and block overrides on an account that does not exist
+	_, otherAddress := solo.NewEthereumAccount()
+	callUint := func(code []byte, balance *big.Int, blockOverrides gethclient.BlockOverrides) uint64 {
+		ret, err := client.CallContractWithBlockOverrides(context.Background(), ethereum.CallMsg{
+			From: otherAddress,
+			To:   &otherAddress,
+		}, nil, &map[common.Address]gethclient.OverrideAccount{
+			otherAddress: {Code: code, Balance: balance},
+		}, blockOverrides)
+		require.NoError(t, err)
+		return new(big.Int).SetBytes(ret).Uint64()
+	}
+	// <opcode> PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	returnWord := func(opcodes ...byte) []byte {
+		return append(opcodes, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3)
+	}
+	numberCode := returnWord(0x43)            // NUMBER
+	timestampCode := returnWord(0x42)         // TIMESTAMP
+	selfBalanceCode := returnWord(0x30, 0x31) // ADDRESS BALANCE
+	require.EqualValues(t, env.BlockNumber()+1, callUint(numberCode, nil, gethclient.BlockOverrides{}))
+	require.EqualValues(t, 1000, callUint(numberCode, nil, gethclient.BlockOverrides{Number: big.NewInt(1000)}))
+	require.EqualValues(t, 1234567890, callUint(timestampCode, nil, gethclient.BlockOverrides{Time: 1234567890}))
+	oneEther := new(big.Int).SetUint64(1e18)
+	require.EqualValues(t, 1e18, callUint(selfBalanceCode, oneEther, gethclient.BlockOverrides{}))
+
+	// estimateGas accepts the same overrides
+	var gas hexutil.Uint64
+	err := env.RawClient.Call(&gas, "eth_estimateGas", map[string]interface{}{
+		"from": otherAddress,
+		"to":   otherAddress,
+	}, "latest", map[common.Address]interface{}{
+		otherAddress: map[string]interface{}{"code": hexutil.Bytes(numberCode)},
+	})
+	require.NoError(t, err)
+	require.Greater(t, uint64(gas), params.TxGas)
+
+	// state and stateDiff are mutually exclusive
+	err = env.RawClient.Call(&gas, "eth_estimateGas", map[string]interface{}{
+		"from": otherAddress,
+		"to":   otherAddress,
+	}, "latest", map[common.Address]interface{}{
+		otherAddress: map[string]interface{}{"state": map[string]string{}, "stateDiff": map[string]string{}},
+	})
+	require.ErrorContains(t, err, "stateDiff")
+
+	// the committed state is not modified
+	require.EqualValues(t, 42, retrieve(nil))
+	require.Empty(t, env.Code(otherAddress))
+	require.Zero(t, env.Balance(otherAddress).Sign())
+}
+
+func TestRPCSimulateV1(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, storageAddress, storageABI := env.deployStorageContract(creator)
+	iscTestABI, err := abi.JSON(strings.NewReader(evmtest.ISCTestContractABI))
+	require.NoError(t, err)
+	_, _, iscTestAddress := env.DeployEVMContract(creator, iscTestABI, evmtest.ISCTestContractBytecode)
+	_, otherAddress := solo.NewEthereumAccount()
+
+	call := func(to common.Address, contractABI abi.ABI, method string, args ...interface{}) map[string]interface{} {
+		data, err2 := contractABI.Pack(method, args...)
+		require.NoError(t, err2)
+		return map[string]interface{}{"from": creatorAddress, "to": to, "data": hexutil.Bytes(data)}
+	}
+	// TIMESTAMP PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	timestampCode := []byte{0x42, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+
+	blockNumber := env.BlockNumber()
+	t1 := uint64(time.Now().Unix()) + 100
+	t2 := t1 + 10
+
+	var blocks []struct {
+		Number       hexutil.Uint64                   `json:"number"`
+		Timestamp    hexutil.Uint64                   `json:"timestamp"`
+		Calls        []jsonrpc.RPCSimulatedCallResult `json:"calls"`
+		Transactions []common.Hash                    `json:"transactions"`
+	}
+	err = env.RawClient.Call(&blocks, "eth_simulateV1", map[string]interface{}{
+		"blockStateCalls": []interface{}{
+			map[string]interface{}{
+				"blockOverrides": map[string]interface{}{"time": hexutil.Uint64(t1)},
+				"calls": []interface{}{
+					call(storageAddress, storageABI, "store", uint32(1234)),
+					call(iscTestAddress, iscTestABI, "emitDummyEvent"),
+				},
+			},
+			map[string]interface{}{
+				"blockOverrides": map[string]interface{}{
+					"number": hexutil.Uint64(blockNumber + 2),
+					"time":   hexutil.Uint64(t2),
+				},
+				"stateOverrides": map[common.Address]interface{}{
+					otherAddress: map[string]interface{}{"code": hexutil.Bytes(timestampCode)},
+				},
+				"calls": []interface{}{
+					map[string]interface{}{"from": creatorAddress, "to": otherAddress},
+					call(storageAddress, storageABI, "retrieve"),
+					call(iscTestAddress, iscTestABI, "testRevertReason"),
+					call(storageAddress, storageABI, "retrieve"),
+				},
+			},
+		},
+	}, "latest")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	require.EqualValues(t, blockNumber+1, blocks[0].Number)
+	require.EqualValues(t, t1, blocks[0].Timestamp)
+	require.Len(t, blocks[0].Calls, 2)
+	for _, c := range blocks[0].Calls {
+		require.Nil(t, c.Error)
+		require.EqualValues(t, types.ReceiptStatusSuccessful, c.Status)
+		require.NotZero(t, c.GasUsed)
+	}
+	require.Len(t, blocks[0].Calls[0].Logs, 1)
+	require.Equal(t, storageAddress, blocks[0].Calls[0].Logs[0].Address)
+	require.Len(t, blocks[0].Calls[1].Logs, 1)
+	dummyEvent := blocks[0].Calls[1].Logs[0]
+	require.Equal(t, iscTestAddress, dummyEvent.Address)
+	require.EqualValues(t, blockNumber+1, dummyEvent.BlockNumber)
+	require.EqualValues(t, 1, dummyEvent.TxIndex)
+	require.EqualValues(t, 1, dummyEvent.Index)
+
+	// the state changes of the previous block are carried over
+	require.EqualValues(t, blockNumber+2, blocks[1].Number)
+	require.EqualValues(t, t2, blocks[1].Timestamp)
+	require.Len(t, blocks[1].Calls, 4)
+	require.EqualValues(t, t2, new(big.Int).SetBytes(blocks[1].Calls[0].ReturnData).Uint64())
+	var v uint32
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", blocks[1].Calls[1].ReturnData))
+	require.EqualValues(t, 1234, v)
+	require.EqualValues(t, types.ReceiptStatusFailed, blocks[1].Calls[2].Status)
+	require.NotNil(t, blocks[1].Calls[2].Error)
+	require.Contains(t, blocks[1].Calls[2].Error.Message, "foobar")
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", blocks[1].Calls[3].ReturnData))
+	require.EqualValues(t, 1234, v)
+	require.Len(t, blocks[0].Transactions, 2)
+	require.Len(t, blocks[1].Transactions, 4)
+
+	// the full transactions are returned on request, and the blocks without
+	// a time override follow the parent block
+	var fullBlocks []struct {
+		Timestamp    hexutil.Uint64            `json:"timestamp"`
+		Transactions []*jsonrpc.RPCTransaction `json:"transactions"`
+	}
+	err = env.RawClient.Call(&fullBlocks, "eth_simulateV1", map[string]interface{}{
+		"blockStateCalls": []interface{}{
+			map[string]interface{}{
+				"calls": []interface{}{
+					call(iscTestAddress, iscTestABI, "testRevertReason"),
+					call(storageAddress, storageABI, "store", uint32(1)),
+					call(storageAddress, storageABI, "store", uint32(2)),
+				},
+			},
+		},
+		"returnFullTransactions": true,
+	}, "latest")
+	require.NoError(t, err)
+	require.Len(t, fullBlocks, 1)
+	latestBlock := env.BlockByNumber(nil)
+	require.EqualValues(t, latestBlock.Time()+1, fullBlocks[0].Timestamp)
+	require.Len(t, fullBlocks[0].Transactions, 3)
+	nonce := env.NonceAt(creatorAddress)
+	for i, tx := range fullBlocks[0].Transactions {
+		require.Equal(t, creatorAddress, tx.From)
+		require.EqualValues(t, i, *tx.TransactionIndex)
+	}
+	// the failed call does not use the nonce
+	require.EqualValues(t, nonce, fullBlocks[0].Transactions[0].Nonce)
+	require.EqualValues(t, nonce, fullBlocks[0].Transactions[1].Nonce)
+	require.EqualValues(t, nonce+1, fullBlocks[0].Transactions[2].Nonce)
+
+	// the amount of blocks is limited
+	tooManyBlocks := make([]interface{}, 257)
+	for i := range tooManyBlocks {
+		tooManyBlocks[i] = map[string]interface{}{"calls": []interface{}{call(storageAddress, storageABI, "retrieve")}}
+	}
+	err = env.RawClient.Call(&blocks, "eth_simulateV1", map[string]interface{}{
+		"blockStateCalls": tooManyBlocks,
+	}, "latest")
+	require.ErrorContains(t, err, "too many blocks")
+
+	// block numbers must be sequential
+	err = env.RawClient.Call(&blocks, "eth_simulateV1", map[string]interface{}{
+		"blockStateCalls": []interface{}{
+			map[string]interface{}{
+				"blockOverrides": map[string]interface{}{"number": hexutil.Uint64(blockNumber + 5)},
+				"calls":          []interface{}{call(storageAddress, storageABI, "retrieve")},
+			},
+		},
+	}, "latest")
+	require.ErrorContains(t, err, "number")
+
+	// nothing is persisted
+	require.EqualValues(t, blockNumber, env.BlockNumber())
+	retrieveArgs, err := storageABI.Pack("retrieve")
+	require.NoError(t, err)
+	retData, err := env.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From: creatorAddress,
+		To:   &storageAddress,
+		Data: retrieveArgs,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", retData))
+	require.EqualValues(t, 42, v)
+	require.Empty(t, env.Code(otherAddress))
+}
+
+func TestRPCSimulateV1FirstCallReverts(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, storageAddress, storageABI := env.deployStorageContract(creator)
+	iscTestABI, err := abi.JSON(strings.NewReader(evmtest.ISCTestContractABI))
+	require.NoError(t, err)
+	_, _, iscTestAddress := env.DeployEVMContract(creator, iscTestABI, evmtest.ISCTestContractBytecode)
+	_, otherAddress := solo.NewEthereumAccount()
+
+	call := func(to common.Address, contractABI abi.ABI, method string, args ...interface{}) map[string]interface{} {
+		data, err2 := contractABI.Pack(method, args...)
+		require.NoError(t, err2)
+		return map[string]interface{}{"from": creatorAddress, "to": to, "data": hexutil.Bytes(data)}
+	}
+	// TIMESTAMP PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	timestampCode := []byte{0x42, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+	t1 := uint64(time.Now().Unix()) + 100
+
+	var blocks []struct {
+		Calls []jsonrpc.RPCSimulatedCallResult `json:"calls"`
+	}
+	err = env.RawClient.Call(&blocks, "eth_simulateV1", map[string]interface{}{
+		"blockStateCalls": []interface{}{
+			map[string]interface{}{
+				"blockOverrides": map[string]interface{}{"time": hexutil.Uint64(t1)},
+				"stateOverrides": map[common.Address]interface{}{
+					otherAddress: map[string]interface{}{"code": hexutil.Bytes(timestampCode)},
+					storageAddress: map[string]interface{}{
+						"stateDiff": map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(42))},
+					},
+				},
+				"calls": []interface{}{
+					call(iscTestAddress, iscTestABI, "testRevertReason"),
+					map[string]interface{}{"from": creatorAddress, "to": otherAddress},
+					call(storageAddress, storageABI, "retrieve"),
+				},
+			},
+			map[string]interface{}{
+				"calls": []interface{}{
+					call(storageAddress, storageABI, "retrieve"),
+				},
+			},
+		},
+	}, "latest")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Len(t, blocks[0].Calls, 3)
+
+	// the state overrides are kept after the first call is reverted
+	require.EqualValues(t, types.ReceiptStatusFailed, blocks[0].Calls[0].Status)
+	require.EqualValues(t, types.ReceiptStatusSuccessful, blocks[0].Calls[1].Status)
+	require.EqualValues(t, t1, new(big.Int).SetBytes(blocks[0].Calls[1].ReturnData).Uint64())
+	var v uint32
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", blocks[0].Calls[2].ReturnData))
+	require.EqualValues(t, 42, v)
+
+	// and carried over to the following blocks
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", blocks[1].Calls[0].ReturnData))
+	require.EqualValues(t, 42, v)
+}
+
+func TestRPCEVMDevMethods(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	var res string
+	blockNumber := env.BlockNumber()
+	require.NoError(t, env.RawClient.Call(&res, "evm_mine"))
+	require.Equal(t, "0x0", res)
+	require.EqualValues(t, blockNumber+1, env.BlockNumber())
+	block := env.BlockByNumber(nil)
+	require.Empty(t, block.Transactions())
+
+	// advance by a 15-minute period
+	require.NoError(t, env.RawClient.Call(&res, "evm_increaseTime", 15*60))
+	require.Equal(t, "900", res)
+	require.NoError(t, env.RawClient.Call(&res, "evm_increaseTime", hexutil.Uint64(60)))
+	require.Equal(t, "960", res)
+	require.NoError(t, env.RawClient.Call(&res, "evm_mine"))
+	require.GreaterOrEqual(t, env.BlockByNumber(nil).Time(), block.Time()+960)
+
+	next := env.BlockByNumber(nil).Time() + 3600
+	require.NoError(t, env.RawClient.Call(&res, "evm_setNextBlockTimestamp", next))
+	require.NoError(t, env.RawClient.Call(&res, "evm_mine"))
+	require.EqualValues(t, next, env.BlockByNumber(nil).Time())
+	require.Error(t, env.RawClient.Call(&res, "evm_setNextBlockTimestamp", next-1))
+
+	require.NoError(t, env.RawClient.Call(&res, "evm_mine", next+10))
+	require.EqualValues(t, next+10, env.BlockByNumber(nil).Time())
+	require.EqualValues(t, blockNumber+4, env.BlockNumber())
+}
+
+func TestRPCEVMMiningModes(t *testing.T) {
+	env := newSoloTestEnv(t)
+	sender, senderAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, toAddress := solo.NewEthereumAccount()
+
+	sendTx := func() *types.Transaction {
+		nonce, err := env.Client.PendingNonceAt(context.Background(), senderAddress)
+		require.NoError(t, err)
+		tx, err := types.SignTx(
+			types.NewTransaction(nonce, toAddress, big.NewInt(0), 100_000, env.MustGetGasPrice(), nil),
+			env.Signer(),
+			sender,
+		)
+		require.NoError(t, err)
+		require.NoError(t, env.Client.SendTransaction(context.Background(), tx))
+		return tx
+	}
+
+	var ok bool
+	require.NoError(t, env.RawClient.Call(&ok, "evm_setAutomine", false))
+	require.True(t, ok)
+
+	// the transactions are kept pending until the next evm_mine
+	blockNumber := env.BlockNumber()
+	var txs []*types.Transaction
+	for i := 0; i < 3; i++ {
+		txs = append(txs, sendTx())
+	}
+	require.EqualValues(t, []uint64{0, 1, 2}, []uint64{txs[0].Nonce(), txs[1].Nonce(), txs[2].Nonce()})
+	require.Equal(t, blockNumber, env.BlockNumber())
+	require.Zero(t, env.NonceAt(senderAddress))
+	var status map[string]hexutil.Uint
+	require.NoError(t, env.RawClient.Call(&status, "txpool_status"))
+	require.EqualValues(t, 3, status["pending"])
+
+	var res string
+	require.NoError(t, env.RawClient.Call(&res, "evm_mine"))
+	require.EqualValues(t, blockNumber+1, env.BlockNumber())
+	block := env.BlockByNumber(nil)
+	require.Len(t, block.Transactions(), len(txs))
+	for _, tx := range txs {
+		require.Equal(t, types.ReceiptStatusSuccessful, env.MustTxReceipt(tx.Hash()).Status)
+	}
+	require.EqualValues(t, 3, env.NonceAt(senderAddress))
+
+	// with interval mining, the pending transactions are mined periodically
+	require.NoError(t, env.RawClient.Call(&ok, "evm_setIntervalMining", 100))
+	tx := sendTx()
+	require.Eventually(t, func() bool {
+		return env.BlockNumber() == blockNumber+2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, types.ReceiptStatusSuccessful, env.MustTxReceipt(tx.Hash()).Status)
+	require.NoError(t, env.RawClient.Call(&ok, "evm_setIntervalMining", 0))
+
+	// back to automine
+	require.NoError(t, env.RawClient.Call(&ok, "evm_setAutomine", true))
+	sendTx()
+	require.EqualValues(t, blockNumber+3, env.BlockNumber())
+}
+
+func TestRPCHardhatSetState(t *testing.T) {
+	env := newSoloTestEnv(t)
+	_, addr := solo.NewEthereumAccount()
+
+	var ok bool
+	balance := new(big.Int).Mul(big.NewInt(10_000), big.NewInt(params.Ether))
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_setBalance", addr, (*hexutil.Big)(balance)))
+	require.True(t, ok)
+	require.Equal(t, balance, env.Balance(addr))
+
+	balance = big.NewInt(params.Ether)
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_setBalance", addr, (*hexutil.Big)(balance)))
+	require.Equal(t, balance, env.Balance(addr))
+
+	// TIMESTAMP PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	code := []byte{0x42, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_setCode", addr, hexutil.Bytes(code)))
+	require.Equal(t, code, env.Code(addr))
+
+	value := common.HexToHash("0x2a")
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_setStorageAt", addr, hexutil.Uint64(1), value))
+	require.Equal(t, value.Bytes(), env.Storage(addr, common.BigToHash(big.NewInt(1))))
+
+	// the dev blocks contain no EVM transactions
+	require.Empty(t, env.BlockByNumber(nil).Transactions())
+	require.Equal(t, balance, env.Balance(addr))
+}
+
+func TestRPCHardhatImpersonateAccount(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, storageAddress, storageABI := env.deployStorageContract(creator)
+	_, whale := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	data, err := storageABI.Pack("store", uint32(43))
+	require.NoError(t, err)
+	sendTxArgs := &jsonrpc.SendTxArgs{
+		From: whale,
+		To:   &storageAddress,
+		Data: (*hexutil.Bytes)(&data),
+	}
+
+	_, err = env.SendTransaction(sendTxArgs)
+	require.ErrorContains(t, err, "not unlocked")
+
+	var ok bool
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_impersonateAccount", whale))
+	require.True(t, ok)
+
+	nonce := env.NonceAt(whale)
+	txHash := env.MustSendTransaction(sendTxArgs)
+	receipt := env.MustTxReceipt(txHash)
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.NotZero(t, receipt.GasUsed)
+	require.EqualValues(t, env.BlockNumber(), receipt.BlockNumber.Uint64())
+	require.EqualValues(t, nonce+1, env.NonceAt(whale))
+
+	var tx jsonrpc.RPCTransaction
+	require.NoError(t, env.RawClient.Call(&tx, "eth_getTransactionByHash", txHash))
+	require.Equal(t, whale, tx.From)
+	require.Equal(t, &storageAddress, tx.To)
+	require.EqualValues(t, nonce, tx.Nonce)
+
+	var rawReceipt map[string]interface{}
+	require.NoError(t, env.RawClient.Call(&rawReceipt, "eth_getTransactionReceipt", txHash))
+	require.Equal(t, strings.ToLower(whale.Hex()), rawReceipt["from"])
+
+	retrieveArgs, err := storageABI.Pack("retrieve")
+	require.NoError(t, err)
+	ret, err := env.Client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &storageAddress,
+		Data: retrieveArgs,
+	}, nil)
+	require.NoError(t, err)
+	var v uint32
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", ret))
+	require.EqualValues(t, 43, v)
+
+	require.NoError(t, env.RawClient.Call(&ok, "hardhat_stopImpersonatingAccount", whale))
+	require.True(t, ok)
+	_, err = env.SendTransaction(sendTxArgs)
+	require.ErrorContains(t, err, "not unlocked")
+}
+
 func TestRPCCallNonView(t *testing.T) {
 	env := newSoloTestEnv(t)
 	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -435,6 +1348,30 @@ func TestRPCEthChainID(t *testing.T) {
 	require.EqualValues(t, evm.DefaultChainID, chainID)
 }
 
+func TestRPCTxPool(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	env.deployStorageContract(creator)
+
+	// the transaction is already processed, so the pool is empty
+	var status map[string]hexutil.Uint
+	err := env.RawClient.Call(&status, "txpool_status")
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]hexutil.Uint{"pending": 0, "queued": 0}, status)
+
+	var content map[string]map[string]map[string]*jsonrpc.RPCTransaction
+	err = env.RawClient.Call(&content, "txpool_content")
+	require.NoError(t, err)
+	require.Empty(t, content["pending"])
+	require.Empty(t, content["queued"])
+
+	var inspect map[string]map[string]map[string]string
+	err = env.RawClient.Call(&inspect, "txpool_inspect")
+	require.NoError(t, err)
+	require.Contains(t, inspect, "pending")
+	require.Contains(t, inspect, "queued")
+}
+
 func TestRPCTxRejectedIfNotEnoughFunds(t *testing.T) {
 	creator, creatorAddress := solo.NewEthereumAccount()
 
@@ -502,3 +1439,104 @@ func BenchmarkRPCEstimateGas(b *testing.B) {
 		require.NotZero(b, n)
 	}
 }
+
+func TestRPCFilters(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	var blockFilterID string
+	err := env.RawClient.Call(&blockFilterID, "eth_newBlockFilter")
+	require.NoError(t, err)
+
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(t, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+	var logsFilterID string
+	err = env.RawClient.Call(&logsFilterID, "eth_newFilter", map[string]interface{}{
+		"address": contractAddress,
+	})
+	require.NoError(t, err)
+
+	_, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Equal(t, 1, len(receipt.Logs))
+
+	// blocks are published asynchronously
+	var blockHashes []common.Hash
+	require.Eventually(t, func() bool {
+		var hashes []common.Hash
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", blockFilterID)
+		require.NoError(t, err)
+		blockHashes = append(blockHashes, hashes...)
+		return len(blockHashes) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, receipt.BlockHash, blockHashes[1])
+
+	var changes []types.Log
+	require.Eventually(t, func() bool {
+		err = env.RawClient.Call(&changes, "eth_getFilterChanges", logsFilterID)
+		require.NoError(t, err)
+		return len(changes) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, receipt.TxHash, changes[0].TxHash)
+
+	// changes are only returned once
+	err = env.RawClient.Call(&changes, "eth_getFilterChanges", logsFilterID)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	var logs []types.Log
+	err = env.RawClient.Call(&logs, "eth_getFilterLogs", logsFilterID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+
+	// block filters have no logs
+	err = env.RawClient.Call(&logs, "eth_getFilterLogs", blockFilterID)
+	require.ErrorContains(t, err, "filter not found")
+
+	for _, id := range []string{blockFilterID, logsFilterID} {
+		var ok bool
+		err = env.RawClient.Call(&ok, "eth_uninstallFilter", id)
+		require.NoError(t, err)
+		require.True(t, ok)
+		err = env.RawClient.Call(&changes, "eth_getFilterChanges", id)
+		require.ErrorContains(t, err, "filter not found")
+	}
+}
+
+func TestRPCFiltersBlockRange(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(t, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+	currentBlock := hexutil.EncodeUint64(env.BlockNumber())
+
+	var pastFilterID, futureFilterID string
+	err = env.RawClient.Call(&pastFilterID, "eth_newFilter", map[string]interface{}{
+		"address": contractAddress,
+		"toBlock": currentBlock,
+	})
+	require.NoError(t, err)
+	err = env.RawClient.Call(&futureFilterID, "eth_newFilter", map[string]interface{}{
+		"address":   contractAddress,
+		"fromBlock": currentBlock,
+	})
+	require.NoError(t, err)
+
+	_, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Equal(t, 1, len(receipt.Logs))
+
+	var changes []types.Log
+	require.Eventually(t, func() bool {
+		err = env.RawClient.Call(&changes, "eth_getFilterChanges", futureFilterID)
+		require.NoError(t, err)
+		return len(changes) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// the log is out of the range of the other filter
+	err = env.RawClient.Call(&changes, "eth_getFilterChanges", pastFilterID)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
@@ -4,24 +4,32 @@
 package jsonrpc
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/iotaledger/wasp/packages/metrics"
 )
 
 type Parameters struct {
-	Logs LogsLimits
+	Logs           LogsLimits
+	FilterTimeout  time.Duration // filters that are not polled within this time are uninstalled
+	IndexAddresses bool          // maintain the address -> tx index used by the ots_* endpoints (archive nodes only)
 }
 
 func NewParameters(
 	maxBlocksInLogsFilterRange int,
 	maxLogsInResult int,
+	filterTimeout time.Duration,
+	indexAddresses bool,
 ) *Parameters {
 	return &Parameters{
 		Logs: LogsLimits{
 			MaxBlocksInLogsFilterRange: maxBlocksInLogsFilterRange,
 			MaxLogsInResult:            maxLogsInResult,
 		},
+		FilterTimeout:  filterTimeout,
+		IndexAddresses: indexAddresses,
 	}
 }
 
@@ -31,6 +39,8 @@ func ParametersDefault() *Parameters {
 			MaxBlocksInLogsFilterRange: 1000,
 			MaxLogsInResult:            10000,
 		},
+		FilterTimeout:  5 * time.Minute,
+		IndexAddresses: false,
 	}
 }
 
@@ -50,7 +60,8 @@ func NewServer(
 		{"net", NewNetService(int(chainID))},
 		{"eth", NewEthService(evmChain, accountManager, metrics, params)},
 		{"debug", NewDebugService(evmChain, metrics)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain, metrics)},
+		{"ots", NewOtsService(evmChain, metrics)},
 		{"evm", NewEVMService(evmChain)},
 	} {
 		err := rpcsrv.RegisterName(srv.namespace, srv.service)
@@ -60,3 +71,15 @@ func NewServer(
 	}
 	return rpcsrv, nil
 }
+
+// RegisterDevServices registers the `hardhat_*` endpoints, which allow to
+// impersonate accounts and to mutate the chain state at will. They must only be
+// registered on servers backed by Solo (e.g. evmemulator), never on the JSONRPC
+// server of a node.
+func RegisterDevServices(
+	rpcsrv *rpc.Server,
+	evmChain *EVMChain,
+	accountManager *AccountManager,
+) error {
+	return rpcsrv.RegisterName("hardhat", NewHardhatService(evmChain, accountManager))
+}
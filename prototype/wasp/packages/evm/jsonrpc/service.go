@@ -9,16 +9,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/tracers"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/crypto/sha3"
 
@@ -38,6 +40,7 @@ type EthService struct {
 	accounts *AccountManager
 	metrics  *metrics.ChainWebAPIMetrics
 	params   *Parameters
+	filters  *filterManager
 }
 
 func NewEthService(
@@ -51,6 +54,7 @@ func NewEthService(
 		accounts: accounts,
 		metrics:  metrics,
 		params:   params,
+		filters:  newFilterManager(evmChain, params.FilterTimeout),
 	}
 }
 
@@ -260,7 +264,11 @@ func (e *EthService) getTransactionReceipt(txHash common.Hash) (map[string]inter
 	if err != nil {
 		return nil, e.resolveError(err)
 	}
-	return RPCMarshalReceipt(r, tx), nil
+	effectiveGasPrice, err := e.evmChain.EffectiveGasPrice(tx, r.BlockNumber.Uint64())
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	return RPCMarshalReceipt(r, tx, effectiveGasPrice), nil
 }
 
 func (e *EthService) GetTransactionReceipt(txHash common.Hash) (map[string]interface{}, error) {
@@ -272,9 +280,80 @@ func (e *EthService) GetTransactionReceipt(txHash common.Hash) (map[string]inter
 	)
 }
 
+func (e *EthService) getBlockReceipts(blockNumberOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	receipts, txs, err := e.evmChain.BlockReceipts(blockNumberOrHash)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	if receipts == nil {
+		return nil, nil
+	}
+	ret := make([]map[string]interface{}, len(receipts))
+	for i, r := range receipts {
+		ret[i] = RPCMarshalReceipt(r, txs[i], r.EffectiveGasPrice)
+	}
+	return ret, nil
+}
+
+func (e *EthService) GetBlockReceipts(blockNumberOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	return withMetrics(
+		e.metrics, "eth_getBlockReceipts",
+		func() ([]map[string]interface{}, error) {
+			return e.getBlockReceipts(blockNumberOrHash)
+		},
+	)
+}
+
+func (e *EthService) simulateV1(opts *RPCSimulateOpts, blockNumberOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if opts == nil {
+		return nil, errors.New("missing simulation options")
+	}
+	blocks, err := opts.parse()
+	if err != nil {
+		return nil, err
+	}
+	headers, results, err := e.evmChain.SimulateCalls(blocks, blockNumberOrHash)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	ret := make([]map[string]interface{}, len(headers))
+	for i, header := range headers {
+		if o := opts.BlockStateCalls[i].BlockOverrides; o != nil && o.Number != nil && o.Number.ToInt().Cmp(header.Number) != 0 {
+			return nil, fmt.Errorf("block %d: number must be %v, got %v", i, header.Number, o.Number.ToInt())
+		}
+		calls := make([]*RPCSimulatedCallResult, len(results[i].Calls))
+		txs := make([]interface{}, len(results[i].Calls))
+		logIndex := uint(0)
+		for j, res := range results[i].Calls {
+			calls[j] = newRPCSimulatedCallResult(res, e.resolveError(res.Err), header, uint(j), &logIndex)
+			if opts.ReturnFullTransactions {
+				txs[j] = newRPCTransaction(res.Tx, header.Hash(), header.Number.Uint64(), uint64(j))
+			} else {
+				txs[j] = res.Tx.Hash()
+			}
+		}
+		block := RPCMarshalHeader(header)
+		block["calls"] = calls
+		block["transactions"] = txs
+		ret[i] = block
+	}
+	return ret, nil
+}
+
+// SimulateV1 executes a bundle of calls across several simulated blocks, with
+// the state changes carried over between calls and blocks.
+func (e *EthService) SimulateV1(opts *RPCSimulateOpts, blockNumberOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	return withMetrics(
+		e.metrics, "eth_simulateV1",
+		func() ([]map[string]interface{}, error) {
+			return e.simulateV1(opts, blockNumberOrHash)
+		},
+	)
+}
+
 func (e *EthService) sendRawTransaction(txBytes hexutil.Bytes) (common.Hash, error) {
 	tx := new(types.Transaction)
-	if err := rlp.DecodeBytes(txBytes, tx); err != nil {
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
 		return common.Hash{}, err
 	}
 	if err := e.evmChain.SendTransaction(tx); err != nil {
@@ -292,30 +371,58 @@ func (e *EthService) SendRawTransaction(txBytes hexutil.Bytes) (common.Hash, err
 	)
 }
 
-func (e *EthService) call(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
-	ret, err := e.evmChain.CallContract(args.parse(), blockNumberOrHash)
+func (e *EthService) call(
+	args *RPCCallArgs,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+	stateOverride *RPCStateOverride,
+	blockOverrides *RPCBlockOverrides,
+) (hexutil.Bytes, error) {
+	overrides, err := parseCallOverrides(stateOverride, blockOverrides)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := e.evmChain.CallContract(args.parse(), blockNumberOrHash, overrides)
 	return ret, e.resolveError(err)
 }
 
-func (e *EthService) Call(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+func (e *EthService) Call(
+	args *RPCCallArgs,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+	stateOverride *RPCStateOverride,
+	blockOverrides *RPCBlockOverrides,
+) (hexutil.Bytes, error) {
 	return withMetrics(
 		e.metrics, "eth_call",
 		func() (hexutil.Bytes, error) {
-			return e.call(args, blockNumberOrHash)
+			return e.call(args, blockNumberOrHash, stateOverride, blockOverrides)
 		},
 	)
 }
 
-func (e *EthService) estimateGas(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
-	gas, err := e.evmChain.EstimateGas(args.parse(), blockNumberOrHash)
+func (e *EthService) estimateGas(
+	args *RPCCallArgs,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+	stateOverride *RPCStateOverride,
+	blockOverrides *RPCBlockOverrides,
+) (hexutil.Uint64, error) {
+	overrides, err := parseCallOverrides(stateOverride, blockOverrides)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := e.evmChain.EstimateGas(args.parse(), blockNumberOrHash, overrides)
 	return hexutil.Uint64(gas), e.resolveError(err)
 }
 
-func (e *EthService) EstimateGas(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+func (e *EthService) EstimateGas(
+	args *RPCCallArgs,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+	stateOverride *RPCStateOverride,
+	blockOverrides *RPCBlockOverrides,
+) (hexutil.Uint64, error) {
 	return withMetrics(
 		e.metrics, "eth_estimateGas",
 		func() (hexutil.Uint64, error) {
-			return e.estimateGas(args, blockNumberOrHash)
+			return e.estimateGas(args, blockNumberOrHash, stateOverride, blockOverrides)
 		},
 	)
 }
@@ -334,6 +441,30 @@ func (e *EthService) GetStorageAt(address common.Address, key string, blockNumbe
 	)
 }
 
+func (e *EthService) getProof(address common.Address, storageKeys []string, blockNumberOrHash *rpc.BlockNumberOrHash) (*AccountResult, error) {
+	keys := make([]common.Hash, len(storageKeys))
+	for i, key := range storageKeys {
+		keys[i] = common.HexToHash(key)
+	}
+	ret, err := e.evmChain.Proof(address, keys, blockNumberOrHash)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	for i, key := range storageKeys {
+		ret.StorageProof[i].Key = key
+	}
+	return ret, nil
+}
+
+func (e *EthService) GetProof(address common.Address, storageKeys []string, blockNumberOrHash *rpc.BlockNumberOrHash) (*AccountResult, error) {
+	return withMetrics(
+		e.metrics, "eth_getProof",
+		func() (*AccountResult, error) {
+			return e.getProof(address, storageKeys, blockNumberOrHash)
+		},
+	)
+}
+
 func (e *EthService) getBlockTransactionCountByHash(blockHash common.Hash) hexutil.Uint {
 	ret := e.evmChain.BlockTransactionCountByHash(blockHash)
 	return hexutil.Uint(ret)
@@ -399,6 +530,36 @@ func (e *EthService) GasPrice() (*hexutil.Big, error) {
 	)
 }
 
+func (e *EthService) maxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(e.evmChain.MaxPriorityFeePerGas())
+}
+
+func (e *EthService) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	return withMetrics(
+		e.metrics, "eth_maxPriorityFeePerGas",
+		func() (*hexutil.Big, error) {
+			return e.maxPriorityFeePerGas(), nil
+		},
+	)
+}
+
+func (e *EthService) feeHistory(blockCount math.HexOrDecimal64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	ret, err := e.evmChain.FeeHistory(uint64(blockCount), parseBlockNumber(newestBlock), rewardPercentiles)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	return ret, nil
+}
+
+func (e *EthService) FeeHistory(blockCount math.HexOrDecimal64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	return withMetrics(
+		e.metrics, "eth_feeHistory",
+		func() (*FeeHistoryResult, error) {
+			return e.feeHistory(blockCount, newestBlock, rewardPercentiles)
+		},
+	)
+}
+
 func (e *EthService) Mining() bool {
 	return false
 }
@@ -468,6 +629,9 @@ func (e *EthService) SignTransaction(args *SendTxArgs) (hexutil.Bytes, error) {
 }
 
 func (e *EthService) sendTransaction(args *SendTxArgs) (common.Hash, error) {
+	if e.accounts.IsImpersonated(args.From) {
+		return e.sendImpersonatedTransaction(args)
+	}
 	tx, err := e.parseTxArgs(args)
 	if err != nil {
 		return common.Hash{}, err
@@ -478,6 +642,19 @@ func (e *EthService) sendTransaction(args *SendTxArgs) (common.Hash, error) {
 	return tx.Hash(), nil
 }
 
+// sendImpersonatedTransaction executes the transaction on behalf of an account
+// impersonated with hardhat_impersonateAccount (only possible in Solo).
+func (e *EthService) sendImpersonatedTransaction(args *SendTxArgs) (common.Hash, error) {
+	if err := args.setDefaults(e); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := e.evmChain.backend.EVMSendImpersonatedTransaction(args.toCallMsg())
+	if err != nil {
+		return common.Hash{}, e.resolveError(err)
+	}
+	return tx.Hash(), nil
+}
+
 func (e *EthService) SendTransaction(args *SendTxArgs) (common.Hash, error) {
 	return withMetrics(
 		e.metrics, "eth_sendTransaction",
@@ -596,14 +773,68 @@ func (e *EthService) Logs(ctx context.Context, q *RPCFilterQuery) (*rpc.Subscrip
 	return rpcSub, nil
 }
 
+func (e *EthService) NewFilter(q *RPCFilterQuery) (rpc.ID, error) {
+	return withMetrics(
+		e.metrics, "eth_newFilter",
+		func() (rpc.ID, error) {
+			return e.filters.newLogsFilter((*ethereum.FilterQuery)(q)), nil
+		},
+	)
+}
+
+func (e *EthService) NewBlockFilter() (rpc.ID, error) {
+	return withMetrics(
+		e.metrics, "eth_newBlockFilter",
+		func() (rpc.ID, error) {
+			return e.filters.newBlocksFilter(), nil
+		},
+	)
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) (bool, error) {
+	return withMetrics(
+		e.metrics, "eth_uninstallFilter",
+		func() (bool, error) {
+			return e.filters.uninstall(id), nil
+		},
+	)
+}
+
+// GetFilterChanges returns the block hashes (for block filters) or the logs
+// (for log filters) received since the last poll.
+func (e *EthService) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	return withMetrics(
+		e.metrics, "eth_getFilterChanges",
+		func() (interface{}, error) {
+			return e.filters.changes(id)
+		},
+	)
+}
+
+func (e *EthService) getFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	q, err := e.filters.logsQuery(id)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := e.evmChain.Logs(q, &e.params.Logs)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	return logs, nil
+}
+
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return withMetrics(
+		e.metrics, "eth_getFilterLogs",
+		func() ([]*types.Log, error) {
+			return e.getFilterLogs(id)
+		},
+	)
+}
+
 /*
 Not implemented:
-func (e *EthService) NewFilter()
-func (e *EthService) NewBlockFilter()
 func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) UninstallFilter()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
 func (e *EthService) SubmitWork()
 func (e *EthService) GetWork()
 func (e *EthService) SubmitHashrate()
@@ -643,33 +874,464 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
+// TxPoolService contains the implementations for the `txpool_*` JSONRPC
+// endpoints, which report the EVM transactions waiting in the mempool.
+type TxPoolService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
+}
 
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+func NewTxPoolService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *TxPoolService {
+	return &TxPoolService{
+		evmChain: evmChain,
+		metrics:  metrics,
+	}
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+// txPoolMap converts the transactions grouped by sender into the format
+// used by the txpool endpoints: sender => nonce => f(tx).
+func txPoolMap[T any](txsBySender map[common.Address][]*types.Transaction, f func(*types.Transaction) T) map[string]map[string]T {
+	ret := make(map[string]map[string]T, len(txsBySender))
+	for sender, txs := range txsBySender {
+		byNonce := make(map[string]T, len(txs))
+		for _, tx := range txs {
+			byNonce[strconv.FormatUint(tx.Nonce(), 10)] = f(tx)
+		}
+		ret[sender.Hex()] = byNonce
 	}
+	return ret
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
-	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
+func (s *TxPoolService) content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error) {
+	pending, queued := s.evmChain.TxPoolContent(ctx)
+	format := func(tx *types.Transaction) *RPCTransaction {
+		return newRPCTransaction(tx, common.Hash{}, 0, 0)
+	}
+	return map[string]map[string]map[string]*RPCTransaction{
+		"pending": txPoolMap(pending, format),
+		"queued":  txPoolMap(queued, format),
+	}, nil
+}
+
+func (s *TxPoolService) Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(
+		s.metrics, "txpool_content",
+		func() (map[string]map[string]map[string]*RPCTransaction, error) {
+			return s.content(ctx)
+		},
+	)
+}
+
+func (s *TxPoolService) inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	pending, queued := s.evmChain.TxPoolContent(ctx)
+	format := func(tx *types.Transaction) string {
+		if to := tx.To(); to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
 	}
+	return map[string]map[string]map[string]string{
+		"pending": txPoolMap(pending, format),
+		"queued":  txPoolMap(queued, format),
+	}, nil
+}
+
+func (s *TxPoolService) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	return withMetrics(
+		s.metrics, "txpool_inspect",
+		func() (map[string]map[string]map[string]string, error) {
+			return s.inspect(ctx)
+		},
+	)
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
+func (s *TxPoolService) status(ctx context.Context) (map[string]hexutil.Uint, error) {
+	pending, queued := s.evmChain.TxPoolContent(ctx)
+	count := func(txsBySender map[common.Address][]*types.Transaction) hexutil.Uint {
+		n := 0
+		for _, txs := range txsBySender {
+			n += len(txs)
+		}
+		return hexutil.Uint(n)
+	}
 	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
+		"pending": count(pending),
+		"queued":  count(queued),
+	}, nil
+}
+
+func (s *TxPoolService) Status(ctx context.Context) (map[string]hexutil.Uint, error) {
+	return withMetrics(
+		s.metrics, "txpool_status",
+		func() (map[string]hexutil.Uint, error) {
+			return s.status(ctx)
+		},
+	)
+}
+
+// OtsService contains the implementations for the `ots_*` JSONRPC endpoints
+// of the Otterscan block explorer. The search endpoints are backed by the
+// address index.
+type OtsService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
+}
+
+func NewOtsService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *OtsService {
+	return &OtsService{
+		evmChain: evmChain,
+		metrics:  metrics,
 	}
 }
 
+// otsAPILevel is the Otterscan API level reported to the explorer. All the
+// ots_* methods of this level must be implemented.
+const otsAPILevel = 8
+
+// otsMaxPageSize is the maximum page size of ots_getBlockTransactions and
+// ots_searchTransactionsBefore/After.
+const otsMaxPageSize = 1000
+
+//nolint:revive // needs to be GetApiLevel to match the Otterscan API
+func (o *OtsService) GetApiLevel() uint64 {
+	return otsAPILevel
+}
+
+func (o *OtsService) searchTransactions(address common.Address, blockNumber uint64, pageSize uint64, before bool) (*OtsTransactionsResult, error) {
+	if pageSize == 0 {
+		return nil, errors.New("page size must be greater than 0")
+	}
+	pageSize = min(pageSize, otsMaxPageSize)
+	txHashes, hasMore, err := o.evmChain.TransactionsByAddress(address, blockNumber, before, int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	ret := &OtsTransactionsResult{
+		Txs:       make([]*RPCTransaction, len(txHashes)),
+		Receipts:  make([]map[string]interface{}, len(txHashes)),
+		FirstPage: (before && blockNumber == 0) || (!before && !hasMore),
+		LastPage:  (!before && blockNumber == 0) || (before && !hasMore),
+	}
+	blocks := make(map[common.Hash]*types.Block)
+	for i, txHash := range txHashes {
+		tx, blockHash, blockNumber, index, err := o.evmChain.TransactionByHash(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if tx == nil {
+			return nil, fmt.Errorf("tx %s not found", txHash)
+		}
+		r := o.evmChain.TransactionReceipt(txHash)
+		if r == nil {
+			return nil, fmt.Errorf("receipt of tx %s not found", txHash)
+		}
+		effectiveGasPrice, err := o.evmChain.EffectiveGasPrice(tx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		block, ok := blocks[blockHash]
+		if !ok {
+			block = o.evmChain.BlockByHash(blockHash)
+			if block == nil {
+				return nil, fmt.Errorf("block %s not found", blockHash)
+			}
+			blocks[blockHash] = block
+		}
+		ret.Txs[i] = newRPCTransaction(tx, blockHash, blockNumber, index)
+		ret.Receipts[i] = RPCMarshalReceipt(r, tx, effectiveGasPrice)
+		ret.Receipts[i]["timestamp"] = hexutil.Uint64(block.Time())
+	}
+	return ret, nil
+}
+
+func (o *OtsService) SearchTransactionsBefore(address common.Address, blockNumber uint64, pageSize uint64) (*OtsTransactionsResult, error) {
+	return withMetrics(
+		o.metrics, "ots_searchTransactionsBefore",
+		func() (*OtsTransactionsResult, error) {
+			return o.searchTransactions(address, blockNumber, pageSize, true)
+		},
+	)
+}
+
+func (o *OtsService) SearchTransactionsAfter(address common.Address, blockNumber uint64, pageSize uint64) (*OtsTransactionsResult, error) {
+	return withMetrics(
+		o.metrics, "ots_searchTransactionsAfter",
+		func() (*OtsTransactionsResult, error) {
+			return o.searchTransactions(address, blockNumber, pageSize, false)
+		},
+	)
+}
+
+func (o *OtsService) getContractCreator(address common.Address) (*OtsContractCreator, error) {
+	txHash, creator, err := o.evmChain.ContractCreator(address)
+	if err != nil || txHash == nil {
+		return nil, err
+	}
+	return &OtsContractCreator{Hash: *txHash, Creator: creator}, nil
+}
+
+func (o *OtsService) GetContractCreator(address common.Address) (*OtsContractCreator, error) {
+	return withMetrics(
+		o.metrics, "ots_getContractCreator",
+		func() (*OtsContractCreator, error) {
+			return o.getContractCreator(address)
+		},
+	)
+}
+
+func (o *OtsService) GetTransactionBySenderAndNonce(address common.Address, nonce hexutil.Uint64) (*common.Hash, error) {
+	return withMetrics(
+		o.metrics, "ots_getTransactionBySenderAndNonce",
+		func() (*common.Hash, error) {
+			return o.evmChain.TransactionBySenderAndNonce(address, uint64(nonce))
+		},
+	)
+}
+
+func (o *OtsService) HasCode(address common.Address, blockNumberOrHash rpc.BlockNumberOrHash) (bool, error) {
+	return withMetrics(
+		o.metrics, "ots_hasCode",
+		func() (bool, error) {
+			code, err := o.evmChain.Code(address, &blockNumberOrHash)
+			if err != nil {
+				return false, err
+			}
+			return len(code) > 0, nil
+		},
+	)
+}
+
+func (o *OtsService) blockDetails(block *types.Block) (*OtsBlockDetails, error) {
+	fields, err := otsMarshalBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	receipts, _, err := o.evmChain.BlockReceipts(rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.NumberU64())))
+	if err != nil {
+		return nil, err
+	}
+	totalFees := new(big.Int)
+	for _, r := range receipts {
+		totalFees.Add(totalFees, new(big.Int).Mul(r.EffectiveGasPrice, new(big.Int).SetUint64(r.GasUsed)))
+	}
+	return &OtsBlockDetails{
+		Block: fields,
+		Issuance: OtsBlockIssuance{
+			BlockReward: (*hexutil.Big)(big.NewInt(0)),
+			UncleReward: (*hexutil.Big)(big.NewInt(0)),
+			Issuance:    (*hexutil.Big)(big.NewInt(0)),
+		},
+		TotalFees: (*hexutil.Big)(totalFees),
+	}, nil
+}
+
+func (o *OtsService) GetBlockDetails(blockNumber rpc.BlockNumber) (*OtsBlockDetails, error) {
+	return withMetrics(
+		o.metrics, "ots_getBlockDetails",
+		func() (*OtsBlockDetails, error) {
+			block, err := o.evmChain.BlockByNumber(parseBlockNumber(blockNumber))
+			if err != nil {
+				return nil, err
+			}
+			return o.blockDetails(block)
+		},
+	)
+}
+
+func (o *OtsService) GetBlockDetailsByHash(blockHash common.Hash) (*OtsBlockDetails, error) {
+	return withMetrics(
+		o.metrics, "ots_getBlockDetailsByHash",
+		func() (*OtsBlockDetails, error) {
+			block := o.evmChain.BlockByHash(blockHash)
+			if block == nil {
+				return nil, nil
+			}
+			return o.blockDetails(block)
+		},
+	)
+}
+
+// getBlockTransactions returns a page of the txs of a block along with their
+// receipts. Like in Erigon, the pages are counted from the end of the block,
+// while the txs of each page are in block order.
+func (o *OtsService) getBlockTransactions(blockNumber rpc.BlockNumber, pageNumber, pageSize uint64) (*OtsBlockTransactions, error) {
+	if pageSize == 0 || pageSize > otsMaxPageSize {
+		return nil, fmt.Errorf("page size must be between 1 and %d", otsMaxPageSize)
+	}
+	block, err := o.evmChain.BlockByNumber(parseBlockNumber(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	receipts, txs, err := o.evmChain.BlockReceipts(rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.NumberU64())))
+	if err != nil {
+		return nil, err
+	}
+	pageEnd := uint64(len(txs))
+	if skipped, overflow := math.SafeMul(pageNumber, pageSize); overflow || skipped >= pageEnd {
+		pageEnd = 0
+	} else {
+		pageEnd -= skipped
+	}
+	pageStart := uint64(0)
+	if pageEnd > pageSize {
+		pageStart = pageEnd - pageSize
+	}
+
+	fields, err := otsMarshalBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	pageTxs := make([]interface{}, 0, pageEnd-pageStart)
+	pageReceipts := make([]map[string]interface{}, 0, pageEnd-pageStart)
+	for i := pageStart; i < pageEnd; i++ {
+		pageTxs = append(pageTxs, newRPCTransactionFromBlockIndex(block, i))
+		r := RPCMarshalReceipt(receipts[i], txs[i], receipts[i].EffectiveGasPrice)
+		r["logs"] = nil
+		r["logsBloom"] = nil
+		pageReceipts = append(pageReceipts, r)
+	}
+	fields["transactions"] = pageTxs
+	return &OtsBlockTransactions{FullBlock: fields, Receipts: pageReceipts}, nil
+}
+
+func (o *OtsService) GetBlockTransactions(blockNumber rpc.BlockNumber, pageNumber, pageSize uint64) (*OtsBlockTransactions, error) {
+	return withMetrics(
+		o.metrics, "ots_getBlockTransactions",
+		func() (*OtsBlockTransactions, error) {
+			return o.getBlockTransactions(blockNumber, pageNumber, pageSize)
+		},
+	)
+}
+
+// otsMarshalBlock marshals the block without the txs and the logs bloom,
+// which are not needed by the explorer.
+func otsMarshalBlock(block *types.Block) (map[string]interface{}, error) {
+	fields, err := RPCMarshalBlock(block, false, false)
+	if err != nil {
+		return nil, err
+	}
+	fields["transactionCount"] = hexutil.Uint64(len(block.Transactions()))
+	fields["logsBloom"] = nil
+	return fields, nil
+}
+
+func (o *OtsService) getTransactionError(txHash common.Hash) (hexutil.Bytes, error) {
+	frame, err := o.evmChain.TransactionCallFrames(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if frame.Error == "" {
+		return hexutil.Bytes{}, nil
+	}
+	return common.FromHex(frame.Output), nil
+}
+
+// GetTransactionError returns the revert data of a failed tx.
+func (o *OtsService) GetTransactionError(txHash common.Hash) (hexutil.Bytes, error) {
+	return withMetrics(
+		o.metrics, "ots_getTransactionError",
+		func() (hexutil.Bytes, error) {
+			return o.getTransactionError(txHash)
+		},
+	)
+}
+
+func (o *OtsService) traceTransaction(txHash common.Hash) ([]*OtsTrace, error) {
+	frame, err := o.evmChain.TransactionCallFrames(txHash)
+	if err != nil {
+		return nil, err
+	}
+	var traces []*OtsTrace
+	var walk func(frame *CallFrame, depth int)
+	walk = func(frame *CallFrame, depth int) {
+		traces = append(traces, &OtsTrace{
+			Type:   frame.Type,
+			Depth:  depth,
+			From:   common.HexToAddress(frame.From),
+			To:     common.HexToAddress(frame.To),
+			Value:  callFrameValue(frame),
+			Input:  common.FromHex(frame.Input),
+			Output: common.FromHex(frame.Output),
+		})
+		for i := range frame.Calls {
+			walk(&frame.Calls[i], depth+1)
+		}
+	}
+	walk(frame, 0)
+	return traces, nil
+}
+
+// TraceTransaction returns the call tree of a tx, flattened in execution
+// order.
+func (o *OtsService) TraceTransaction(txHash common.Hash) ([]*OtsTrace, error) {
+	return withMetrics(
+		o.metrics, "ots_traceTransaction",
+		func() ([]*OtsTrace, error) {
+			return o.traceTransaction(txHash)
+		},
+	)
+}
+
+func (o *OtsService) getInternalOperations(txHash common.Hash) ([]*OtsInternalOperation, error) {
+	frame, err := o.evmChain.TransactionCallFrames(txHash)
+	if err != nil {
+		return nil, err
+	}
+	ops := []*OtsInternalOperation{}
+	var walk func(frame *CallFrame)
+	walk = func(frame *CallFrame) {
+		for i := range frame.Calls {
+			call := &frame.Calls[i]
+			op := &OtsInternalOperation{
+				From:  common.HexToAddress(call.From),
+				To:    common.HexToAddress(call.To),
+				Value: callFrameValue(call),
+			}
+			switch call.Type {
+			case "CALL":
+				if op.Value != nil && op.Value.ToInt().Sign() > 0 {
+					op.Type = OtsOperationTransfer
+					ops = append(ops, op)
+				}
+			case "SELFDESTRUCT":
+				op.Type = OtsOperationSelfDestruct
+				ops = append(ops, op)
+			case "CREATE":
+				op.Type = OtsOperationCreate
+				ops = append(ops, op)
+			case "CREATE2":
+				op.Type = OtsOperationCreate2
+				ops = append(ops, op)
+			}
+			walk(call)
+		}
+	}
+	walk(frame)
+	return ops, nil
+}
+
+// GetInternalOperations returns the value transfers, contract creations and
+// self-destructs done by the internal calls of a tx.
+func (o *OtsService) GetInternalOperations(txHash common.Hash) ([]*OtsInternalOperation, error) {
+	return withMetrics(
+		o.metrics, "ots_getInternalOperations",
+		func() ([]*OtsInternalOperation, error) {
+			return o.getInternalOperations(txHash)
+		},
+	)
+}
+
+func callFrameValue(frame *CallFrame) *hexutil.Big {
+	if frame.Value == "" {
+		return nil
+	}
+	value, err := hexutil.DecodeBig(frame.Value)
+	if err != nil {
+		return nil
+	}
+	return (*hexutil.Big)(value)
+}
+
 type DebugService struct {
 	evmChain *EVMChain
 	metrics  *metrics.ChainWebAPIMetrics
@@ -695,6 +1357,48 @@ func (d *DebugService) TraceTransaction(txHash common.Hash, config *tracers.Trac
 	)
 }
 
+func (d *DebugService) traceBlockByNumber(blockNumber rpc.BlockNumber, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return d.evmChain.TraceBlockByNumber(parseBlockNumber(blockNumber), config)
+}
+
+func (d *DebugService) TraceBlockByNumber(blockNumber rpc.BlockNumber, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(
+		d.metrics, "debug_traceBlockByNumber",
+		func() ([]*TxTraceResult, error) {
+			return d.traceBlockByNumber(blockNumber, config)
+		},
+	)
+}
+
+func (d *DebugService) traceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return d.evmChain.TraceBlockByHash(blockHash, config)
+}
+
+func (d *DebugService) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(
+		d.metrics, "debug_traceBlockByHash",
+		func() ([]*TxTraceResult, error) {
+			return d.traceBlockByHash(blockHash, config)
+		},
+	)
+}
+
+func (d *DebugService) traceCall(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, config *tracers.TraceConfig) (interface{}, error) {
+	return d.evmChain.TraceCall(args.parse(), blockNumberOrHash, config)
+}
+
+func (d *DebugService) TraceCall(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, config *tracers.TraceConfig) (interface{}, error) {
+	return withMetrics(
+		d.metrics, "debug_traceCall",
+		func() (interface{}, error) {
+			return d.traceCall(args, blockNumberOrHash, config)
+		},
+	)
+}
+
+// EVMService contains the implementations for the `evm_*` JSONRPC endpoints,
+// which control the chain for testing purposes. They are only available in
+// Solo (e.g. with the evmemulator tool).
 type EVMService struct {
 	evmChain *EVMChain
 }
@@ -713,3 +1417,111 @@ func (e *EVMService) Snapshot() (hexutil.Uint, error) {
 func (e *EVMService) Revert(snapshot hexutil.Uint) error {
 	return e.evmChain.backend.RevertToSnapshot(int(snapshot))
 }
+
+// Mine produces a new block, which includes the pending transactions if
+// automine is disabled (see [EVMService.SetAutomine]). If timestamp is set,
+// the block is produced at that time (see [EVMService.SetNextBlockTimestamp]).
+func (e *EVMService) Mine(timestamp *DecimalOrHexUint64) (string, error) {
+	if timestamp != nil {
+		if err := e.evmChain.backend.EVMSetNextBlockTimestamp(time.Unix(int64(*timestamp), 0)); err != nil {
+			return "", err
+		}
+	}
+	if err := e.evmChain.backend.EVMMine(); err != nil {
+		return "", err
+	}
+	return "0x0", nil
+}
+
+// SetAutomine enables or disables automine. When enabled (the default), each
+// transaction is included in its own block as soon as it is received. When
+// disabled, the transactions are kept pending until a block is produced with
+// evm_mine, or at the interval set with evm_setIntervalMining.
+func (e *EVMService) SetAutomine(enabled bool) (bool, error) {
+	if err := e.evmChain.backend.EVMSetAutomine(enabled); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetIntervalMining produces a block with the pending transactions every
+// given amount of milliseconds. 0 disables interval mining.
+func (e *EVMService) SetIntervalMining(milliseconds DecimalOrHexUint64) (bool, error) {
+	if err := e.evmChain.backend.EVMSetIntervalMining(time.Duration(milliseconds) * time.Millisecond); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IncreaseTime advances the clock by the given amount of seconds, and returns
+// the total amount of seconds the clock was advanced so far.
+func (e *EVMService) IncreaseTime(seconds DecimalOrHexUint64) (string, error) {
+	total, err := e.evmChain.backend.EVMIncreaseTime(time.Duration(seconds) * time.Second)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(uint64(total/time.Second), 10), nil
+}
+
+// SetNextBlockTimestamp advances the clock to the given timestamp, which must
+// be in the future.
+func (e *EVMService) SetNextBlockTimestamp(timestamp DecimalOrHexUint64) (string, error) {
+	if err := e.evmChain.backend.EVMSetNextBlockTimestamp(time.Unix(int64(timestamp), 0)); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(uint64(timestamp), 10), nil
+}
+
+// HardhatService contains the implementations for the `hardhat_*` JSONRPC
+// endpoints, which modify the chain state for testing purposes, in the same
+// way as the Hardhat Network. They are only available in Solo (e.g. with the
+// evmemulator tool).
+//
+// Each state modification is performed by producing a new block.
+type HardhatService struct {
+	evmChain *EVMChain
+	accounts *AccountManager
+}
+
+func NewHardhatService(evmChain *EVMChain, accounts *AccountManager) *HardhatService {
+	return &HardhatService{
+		evmChain: evmChain,
+		accounts: accounts,
+	}
+}
+
+// SetBalance sets the balance of the account. Any amount below the precision
+// of the base token is ignored.
+func (h *HardhatService) SetBalance(address common.Address, balance hexutil.Big) (bool, error) {
+	if err := h.evmChain.backend.EVMSetBalance(address, (*big.Int)(&balance)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *HardhatService) SetCode(address common.Address, code hexutil.Bytes) (bool, error) {
+	if err := h.evmChain.backend.EVMSetCode(address, code); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *HardhatService) SetStorageAt(address common.Address, position hexutil.Big, value common.Hash) (bool, error) {
+	if err := h.evmChain.backend.EVMSetStorageAt(address, common.BigToHash((*big.Int)(&position)), value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ImpersonateAccount allows to send transactions from the account with
+// eth_sendTransaction, without its private key. The transactions are not
+// charged any fees.
+func (h *HardhatService) ImpersonateAccount(address common.Address) bool {
+	h.accounts.Impersonate(address)
+	return true
+}
+
+// StopImpersonatingAccount returns false if the account was not impersonated.
+func (h *HardhatService) StopImpersonatingAccount(address common.Address) bool {
+	return h.accounts.StopImpersonating(address)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 )
 
 type tracerFactory func(cfg json.RawMessage) (tracers.Tracer, error)
@@ -22,3 +23,17 @@ func newTracer(tracerType string, cfg json.RawMessage) (tracers.Tracer, error) {
 	}
 	return fn(cfg)
 }
+
+// newTracerFromConfig creates the tracer requested in the trace config. As in
+// go-ethereum, the opcode-level struct logger is used when no tracer is
+// specified; its options are embedded in the main config object.
+func newTracerFromConfig(config *tracers.TraceConfig) (tracers.Tracer, error) {
+	if config == nil || config.Tracer == nil {
+		var loggerConfig *logger.Config
+		if config != nil {
+			loggerConfig = config.Config
+		}
+		return newStructLogger(loggerConfig), nil
+	}
+	return newTracer(*config.Tracer, config.TracerConfig)
+}
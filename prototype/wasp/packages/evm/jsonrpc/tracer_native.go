@@ -0,0 +1,24 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native" // registers the native tracers into tracers.DefaultDirectory
+)
+
+func init() {
+	registerTracer("prestateTracer", newGethNativeTracer("prestateTracer"))
+	registerTracer("4byteTracer", newGethNativeTracer("4byteTracer"))
+}
+
+// newGethNativeTracer returns a factory for one of the native tracers
+// implemented in go-ethereum.
+func newGethNativeTracer(name string) tracerFactory {
+	return func(cfg json.RawMessage) (tracers.Tracer, error) {
+		return tracers.DefaultDirectory.New(name, &tracers.Context{}, cfg)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+)
+
+func init() {
+	registerTracer("structLogger", newStructLoggerFromJSON)
+}
+
+// newStructLogger returns the opcode-level tracer used by default when no
+// tracer is specified in the trace config.
+func newStructLogger(cfg *logger.Config) tracers.Tracer {
+	if cfg == nil {
+		cfg = &logger.Config{}
+	}
+	// debug output is printed to stdout, never enable it on the node
+	c := *cfg
+	c.Debug = false
+	return logger.NewStructLogger(&c)
+}
+
+func newStructLoggerFromJSON(cfg json.RawMessage) (tracers.Tracer, error) {
+	var config logger.Config
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return newStructLogger(&config), nil
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -18,25 +19,32 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
 )
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash      `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             common.Address    `json:"from"`
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Hash             common.Hash       `json:"hash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               *common.Address   `json:"to"`
+	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	Type             hexutil.Uint64    `json:"type"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
 }
 
 // RPCMarshalHeader converts the given header to the RPC output .
@@ -121,11 +129,14 @@ func newRPCTransactionFromBlockIndex(b *types.Block, index uint64) *RPCTransacti
 // newRPCTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64) *RPCTransaction {
-	var signer types.Signer = types.FrontierSigner{}
-	if tx.Protected() {
-		signer = types.NewEIP155Signer(tx.ChainId())
+	from, ok := evmutil.ImpersonatedSender(tx)
+	if !ok {
+		var signer types.Signer = types.FrontierSigner{}
+		if tx.Protected() {
+			signer = evmutil.Signer(tx.ChainId())
+		}
+		from, _ = types.Sender(signer, tx)
 	}
-	from, _ := types.Sender(signer, tx)
 	v, r, s := tx.RawSignatureValues()
 
 	result := &RPCTransaction{
@@ -137,10 +148,20 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		Nonce:    hexutil.Uint64(tx.Nonce()),
 		To:       tx.To(),
 		Value:    (*hexutil.Big)(tx.Value()),
+		Type:     hexutil.Uint64(tx.Type()),
 		V:        (*hexutil.Big)(v),
 		R:        (*hexutil.Big)(r),
 		S:        (*hexutil.Big)(s),
 	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
@@ -158,7 +179,18 @@ func parseBlockNumber(bn rpc.BlockNumber) *big.Int {
 	return big.NewInt(n)
 }
 
-func RPCMarshalReceipt(r *types.Receipt, tx *types.Transaction) map[string]interface{} {
+// FeeHistoryResult is the result of eth_feeHistory.
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// RPCMarshalReceipt converts the given receipt to the RPC output.
+// effectiveGasPrice is the gas price actually charged for the tx, i.e. the
+// one set by the fee policy when the tx was processed.
+func RPCMarshalReceipt(r *types.Receipt, tx *types.Transaction, effectiveGasPrice *big.Int) map[string]interface{} {
 	// fix for an already fixed bug where some old failed receipts contain non-empty logs
 	if r.Status != types.ReceiptStatusSuccessful {
 		r.Logs = []*types.Log{}
@@ -169,7 +201,7 @@ func RPCMarshalReceipt(r *types.Receipt, tx *types.Transaction) map[string]inter
 		"transactionIndex":  hexutil.Uint64(r.TransactionIndex),
 		"blockHash":         r.BlockHash,
 		"blockNumber":       (*hexutil.Big)(r.BlockNumber),
-		"from":              evmutil.MustGetSender(tx),
+		"from":              evmutil.MustGetTransactionSender(tx),
 		"to":                tx.To(),
 		"cumulativeGasUsed": hexutil.Uint64(r.CumulativeGasUsed),
 		"gasUsed":           hexutil.Uint64(r.GasUsed),
@@ -177,6 +209,8 @@ func RPCMarshalReceipt(r *types.Receipt, tx *types.Transaction) map[string]inter
 		"logs":              rpcMarshalLogs(r),
 		"logsBloom":         r.Bloom,
 		"status":            hexutil.Uint64(r.Status),
+		"type":              hexutil.Uint64(tx.Type()),
+		"effectiveGasPrice": (*hexutil.Big)(effectiveGasPrice),
 	}
 }
 
@@ -220,6 +254,193 @@ func (c *RPCCallArgs) parse() (ret ethereum.CallMsg) {
 	return
 }
 
+// RPCStateOverride is the set of accounts to override before executing a call
+// with eth_call or eth_estimateGas (same format as go-ethereum).
+type RPCStateOverride map[common.Address]RPCAccountOverride
+
+// RPCAccountOverride contains the account fields to override. State replaces
+// the whole account storage, while StateDiff replaces only the given slots.
+type RPCAccountOverride struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   *hexutil.Big                 `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// RPCBlockOverrides contains the block context fields to override before
+// executing a call with eth_call or eth_estimateGas.
+type RPCBlockOverrides struct {
+	Number *hexutil.Big    `json:"number"`
+	Time   *hexutil.Uint64 `json:"time"`
+}
+
+func parseCallOverrides(stateOverride *RPCStateOverride, blockOverrides *RPCBlockOverrides) (*isc.EVMCallOverrides, error) {
+	if stateOverride == nil && blockOverrides == nil {
+		return nil, nil
+	}
+	ret := &isc.EVMCallOverrides{}
+	if stateOverride != nil {
+		ret.State = make(map[common.Address]*isc.EVMAccountOverride, len(*stateOverride))
+		for addr, account := range *stateOverride {
+			if account.State != nil && account.StateDiff != nil {
+				return nil, fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+			}
+			o := &isc.EVMAccountOverride{
+				Nonce:   (*uint64)(account.Nonce),
+				Balance: (*big.Int)(account.Balance),
+			}
+			if account.Code != nil {
+				o.Code = append([]byte{}, *account.Code...)
+			}
+			if account.State != nil {
+				o.State = *account.State
+			}
+			if account.StateDiff != nil {
+				o.StateDiff = *account.StateDiff
+			}
+			ret.State[addr] = o
+		}
+	}
+	if blockOverrides != nil {
+		ret.Block = &isc.EVMBlockOverrides{
+			Number: (*big.Int)(blockOverrides.Number),
+			Time:   (*uint64)(blockOverrides.Time),
+		}
+	}
+	return ret, nil
+}
+
+// RPCSimulateOpts are the arguments of eth_simulateV1 (same format as
+// go-ethereum).
+type RPCSimulateOpts struct {
+	BlockStateCalls        []*RPCSimulateBlock `json:"blockStateCalls"`
+	TraceTransfers         bool                `json:"traceTransfers"`
+	Validation             bool                `json:"validation"`
+	ReturnFullTransactions bool                `json:"returnFullTransactions"`
+}
+
+const (
+	// maxSimulateBlocks is the maximum amount of blocks simulated by a single
+	// eth_simulateV1 call (same as go-ethereum).
+	maxSimulateBlocks = 256
+	// maxSimulateCalls is the maximum amount of calls in all the blocks of a
+	// single eth_simulateV1 call.
+	maxSimulateCalls = 1000
+)
+
+// RPCSimulateBlock is a block of calls to be simulated with eth_simulateV1.
+// The state overrides are applied before the first call of the block.
+type RPCSimulateBlock struct {
+	BlockOverrides *RPCBlockOverrides `json:"blockOverrides"`
+	StateOverrides *RPCStateOverride  `json:"stateOverrides"`
+	Calls          []*RPCCallArgs     `json:"calls"`
+}
+
+// RPCSimulatedCallResult is the outcome of a call simulated with
+// eth_simulateV1.
+type RPCSimulatedCallResult struct {
+	ReturnData hexutil.Bytes          `json:"returnData"`
+	Logs       []*types.Log           `json:"logs"`
+	GasUsed    hexutil.Uint64         `json:"gasUsed"`
+	Status     hexutil.Uint64         `json:"status"`
+	Error      *RPCSimulatedCallError `json:"error,omitempty"`
+}
+
+type RPCSimulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+func newRPCSimulatedCallResult(
+	res *chainutil.EVMSimulatedCallResult,
+	err error,
+	header *types.Header,
+	txIndex uint,
+	logIndex *uint,
+) *RPCSimulatedCallResult {
+	ret := &RPCSimulatedCallResult{
+		ReturnData: res.ReturnData,
+		Logs:       make([]*types.Log, 0),
+		GasUsed:    hexutil.Uint64(res.GasUsed),
+		Status:     hexutil.Uint64(types.ReceiptStatusFailed),
+	}
+	if err != nil {
+		ret.Error = &RPCSimulatedCallError{Code: -32015, Message: err.Error()}
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			ret.Error.Code = rpcErr.ErrorCode()
+		}
+		var dataErr rpc.DataError
+		if errors.As(err, &dataErr) {
+			if data, ok := dataErr.ErrorData().(string); ok {
+				ret.Error.Data = data
+				ret.ReturnData, _ = hexutil.Decode(data)
+			}
+		}
+		return ret
+	}
+	ret.Status = hexutil.Uint64(res.Receipt.Status)
+	for _, log := range res.Receipt.Logs {
+		l := *log
+		l.BlockNumber = header.Number.Uint64()
+		l.BlockHash = header.Hash()
+		l.TxIndex = txIndex
+		l.Index = *logIndex
+		*logIndex++
+		ret.Logs = append(ret.Logs, &l)
+	}
+	return ret
+}
+
+func (o *RPCSimulateOpts) parse() ([]*chainutil.EVMSimulatedBlock, error) {
+	if o.Validation {
+		return nil, errors.New("validation is not supported")
+	}
+	if o.TraceTransfers {
+		return nil, errors.New("traceTransfers is not supported")
+	}
+	if len(o.BlockStateCalls) == 0 {
+		return nil, errors.New("empty blockStateCalls")
+	}
+	if len(o.BlockStateCalls) > maxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks: %d > %d", len(o.BlockStateCalls), maxSimulateBlocks)
+	}
+	totalCalls := 0
+	for _, block := range o.BlockStateCalls {
+		totalCalls += len(block.Calls)
+	}
+	if totalCalls > maxSimulateCalls {
+		return nil, fmt.Errorf("too many calls: %d > %d", totalCalls, maxSimulateCalls)
+	}
+	ret := make([]*chainutil.EVMSimulatedBlock, len(o.BlockStateCalls))
+	for i, block := range o.BlockStateCalls {
+		// the block number override is checked after the simulation, and the
+		// time override becomes the timestamp of the simulated ISC block
+		overrides, err := parseCallOverrides(block.StateOverrides, nil)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		if len(block.Calls) == 0 {
+			// ISC blocks cannot be empty
+			return nil, fmt.Errorf("block %d: no calls", i)
+		}
+		calls := make([]ethereum.CallMsg, len(block.Calls))
+		for j, call := range block.Calls {
+			calls[j] = call.parse()
+		}
+		ret[i] = &chainutil.EVMSimulatedBlock{
+			Calls:     calls,
+			Overrides: overrides,
+		}
+		if block.BlockOverrides != nil && block.BlockOverrides.Time != nil {
+			ret[i].Time = time.Unix(int64(*block.BlockOverrides.Time), 0)
+		}
+	}
+	return ret, nil
+}
+
 // SendTxArgs represents the arguments to submit a new transaction into the transaction pool.
 type SendTxArgs struct {
 	From     common.Address  `json:"from"`
@@ -267,19 +488,59 @@ func (args *SendTxArgs) setDefaults(e *EthService) error {
 	return nil
 }
 
-func (args *SendTxArgs) toTransaction() *types.Transaction {
-	var input []byte
+func (args *SendTxArgs) input() []byte {
 	if args.Input != nil {
-		input = *args.Input
-	} else if args.Data != nil {
-		input = *args.Data
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
 	}
+	return nil
+}
+
+func (args *SendTxArgs) toTransaction() *types.Transaction {
+	input := args.input()
 	if args.To == nil {
 		return types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
 	}
 	return types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
 }
 
+// toCallMsg returns the call message used to send the transaction on behalf
+// of an impersonated account.
+func (args *SendTxArgs) toCallMsg() ethereum.CallMsg {
+	var gas uint64
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	return ethereum.CallMsg{
+		From:     args.From,
+		To:       args.To,
+		Gas:      gas,
+		GasPrice: (*big.Int)(args.GasPrice),
+		Value:    (*big.Int)(args.Value),
+		Data:     args.input(),
+	}
+}
+
+// DecimalOrHexUint64 is an uint64 parameter that can be given either as a
+// JSON number or as a hex string, as accepted by the Hardhat Network.
+type DecimalOrHexUint64 uint64
+
+func (n *DecimalOrHexUint64) UnmarshalJSON(data []byte) error {
+	var hex hexutil.Uint64
+	if err := hex.UnmarshalJSON(data); err == nil {
+		*n = DecimalOrHexUint64(hex)
+		return nil
+	}
+	var dec uint64
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*n = DecimalOrHexUint64(dec)
+	return nil
+}
+
 type RPCFilterQuery ethereum.FilterQuery
 
 // UnmarshalJSON sets *args fields with given data.
@@ -404,6 +665,102 @@ func decodeTopic(s string) (common.Hash, error) {
 	return common.BytesToHash(b), err
 }
 
+// TxTraceResult is the result of tracing a single tx of a block.
+type TxTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// AccountResult is the result of eth_getProof, as defined in EIP-1186.
+//
+// The proofs are serialized trie.MerkleProof instances against the trie root
+// of the ISC state, which is committed in L1Commitment. AccountProof contains
+// the proofs for the nonce, base tokens balance and code of the account, in
+// that order. There is no per-account storage trie, so StorageHash is always
+// empty.
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+	L1Commitment hexutil.Bytes   `json:"l1Commitment"`
+}
+
+type StorageResult struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// OtsTransactionsResult is the result of ots_searchTransactionsBefore and
+// ots_searchTransactionsAfter. FirstPage is true if the page contains the
+// newest txs of the address, LastPage if it contains the oldest ones.
+type OtsTransactionsResult struct {
+	Txs       []*RPCTransaction        `json:"txs"`
+	Receipts  []map[string]interface{} `json:"receipts"`
+	FirstPage bool                     `json:"firstPage"`
+	LastPage  bool                     `json:"lastPage"`
+}
+
+// OtsContractCreator is the result of ots_getContractCreator.
+type OtsContractCreator struct {
+	Hash    common.Hash    `json:"hash"`
+	Creator common.Address `json:"creator"`
+}
+
+// OtsBlockDetails is the result of ots_getBlockDetails and
+// ots_getBlockDetailsByHash. There are no block rewards in ISC, so the
+// issuance is always zero.
+type OtsBlockDetails struct {
+	Block     map[string]interface{} `json:"block"`
+	Issuance  OtsBlockIssuance       `json:"issuance"`
+	TotalFees *hexutil.Big           `json:"totalFees"`
+}
+
+type OtsBlockIssuance struct {
+	BlockReward *hexutil.Big `json:"blockReward"`
+	UncleReward *hexutil.Big `json:"uncleReward"`
+	Issuance    *hexutil.Big `json:"issuance"`
+}
+
+// OtsBlockTransactions is the result of ots_getBlockTransactions.
+type OtsBlockTransactions struct {
+	FullBlock map[string]interface{}   `json:"fullblock"`
+	Receipts  []map[string]interface{} `json:"receipts"`
+}
+
+// OtsTrace is an element of the result of ots_traceTransaction.
+type OtsTrace struct {
+	Type   string         `json:"type"`
+	Depth  int            `json:"depth"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value"`
+	Input  hexutil.Bytes  `json:"input"`
+	Output hexutil.Bytes  `json:"output"`
+}
+
+// Internal operation types of ots_getInternalOperations.
+const (
+	OtsOperationTransfer = iota
+	OtsOperationSelfDestruct
+	OtsOperationCreate
+	OtsOperationCreate2
+)
+
+// OtsInternalOperation is an element of the result of
+// ots_getInternalOperations.
+type OtsInternalOperation struct {
+	Type  int            `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+}
+
 type revertError struct {
 	error
 	reason string // revert reason hex encoded
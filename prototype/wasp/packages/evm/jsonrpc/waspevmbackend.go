@@ -4,16 +4,20 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/isc"
@@ -74,12 +78,16 @@ func (b *WaspEVMBackend) EVMSendTransaction(tx *types.Transaction) error {
 	return nil
 }
 
-func (b *WaspEVMBackend) EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) ([]byte, error) {
-	return chainutil.EVMCall(b.chain, aliasOutput, callMsg)
+func (b *WaspEVMBackend) EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) ([]byte, error) {
+	return chainutil.EVMCall(b.chain, aliasOutput, callMsg, overrides)
 }
 
-func (b *WaspEVMBackend) EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) (uint64, error) {
-	return chainutil.EVMEstimateGas(b.chain, aliasOutput, callMsg)
+func (b *WaspEVMBackend) EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) (uint64, error) {
+	return chainutil.EVMEstimateGas(b.chain, aliasOutput, callMsg, overrides)
+}
+
+func (b *WaspEVMBackend) EVMSimulateCalls(aliasOutput *isc.AliasOutputWithID, blocks []*chainutil.EVMSimulatedBlock) ([]*chainutil.EVMSimulatedBlockResult, error) {
+	return chainutil.EVMSimulateCalls(b.chain, aliasOutput, blocks)
 }
 
 func (b *WaspEVMBackend) EVMTraceTransaction(
@@ -99,10 +107,33 @@ func (b *WaspEVMBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *WaspEVMBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	newTracer func(txIndex uint64) tracers.Tracer,
+) error {
+	return chainutil.EVMTraceBlock(
+		b.chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		newTracer,
+	)
+}
+
+func (b *WaspEVMBackend) EVMTraceCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, tracer tracers.Tracer) error {
+	return chainutil.EVMTraceCall(b.chain, aliasOutput, callMsg, tracer)
+}
+
 func (b *WaspEVMBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return chainutil.CallView(chainState, b.chain, isc.Hn(scName), isc.Hn(funName), args)
 }
 
+func (b *WaspEVMBackend) ISCMempoolOffLedgerRequests(ctx context.Context) []*mempool.AccountRequests {
+	return b.chain.MempoolOffLedgerRequests(ctx)
+}
+
 func (b *WaspEVMBackend) BaseToken() *parameters.BaseToken {
 	return b.baseToken
 }
@@ -152,3 +183,39 @@ func (*WaspEVMBackend) RevertToSnapshot(int) error {
 func (*WaspEVMBackend) TakeSnapshot() (int, error) {
 	return 0, errNotImplemented
 }
+
+func (*WaspEVMBackend) EVMMine() error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetAutomine(bool) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetIntervalMining(time.Duration) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMIncreaseTime(time.Duration) (time.Duration, error) {
+	return 0, errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetNextBlockTimestamp(time.Time) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetBalance(common.Address, *big.Int) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetCode(common.Address, []byte) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSetStorageAt(common.Address, common.Hash, common.Hash) error {
+	return errNotImplemented
+}
+
+func (*WaspEVMBackend) EVMSendImpersonatedTransaction(ethereum.CallMsg) (*types.Transaction, error) {
+	return nil, errNotImplemented
+}
@@ -13,6 +13,9 @@ const (
 	CoreContractGovernance      = "governance"
 	CoreContractErrors          = "errors"
 	CoreContractEVM             = "evm"
+	CoreContractScheduler       = "scheduler"
+	CoreContractOracle          = "oracle"
+	CoreContractSponsorship     = "sponsorship"
 	CoreEPRotateStateController = "rotateStateController"
 )
 
@@ -24,16 +27,22 @@ var (
 	CoreContractGovernanceHname      = isc.Hn(CoreContractGovernance)
 	CoreContractErrorsHname          = isc.Hn(CoreContractErrors)
 	CoreContractEVMHname             = isc.Hn(CoreContractEVM)
+	CoreContractSchedulerHname       = isc.Hn(CoreContractScheduler)
+	CoreContractOracleHname          = isc.Hn(CoreContractOracle)
+	CoreContractSponsorshipHname     = isc.Hn(CoreContractSponsorship)
 	CoreEPRotateStateControllerHname = isc.Hn(CoreEPRotateStateController)
 
 	hnames = map[string]isc.Hname{
-		CoreContractRoot:       CoreContractRootHname,
-		CoreContractAccounts:   CoreContractAccountsHname,
-		CoreContractBlob:       CoreContractBlobHname,
-		CoreContractBlocklog:   CoreContractBlocklogHname,
-		CoreContractGovernance: CoreContractGovernanceHname,
-		CoreContractEVM:        CoreContractEVMHname,
-		CoreContractErrors:     CoreContractErrorsHname,
+		CoreContractRoot:        CoreContractRootHname,
+		CoreContractAccounts:    CoreContractAccountsHname,
+		CoreContractBlob:        CoreContractBlobHname,
+		CoreContractBlocklog:    CoreContractBlocklogHname,
+		CoreContractGovernance:  CoreContractGovernanceHname,
+		CoreContractEVM:         CoreContractEVMHname,
+		CoreContractErrors:      CoreContractErrorsHname,
+		CoreContractScheduler:   CoreContractSchedulerHname,
+		CoreContractOracle:      CoreContractOracleHname,
+		CoreContractSponsorship: CoreContractSponsorshipHname,
 	}
 )
 
@@ -0,0 +1,138 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package isc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/iotaledger/hive.go/crypto/bls"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+const (
+	// MaxOracleFeeds is the maximum amount of feeds agreed in a single block
+	MaxOracleFeeds = 32
+	// MaxOracleFeedNameLength is the maximum length of the name of an oracle feed
+	MaxOracleFeedNameLength = 32
+)
+
+// OracleValues are the values of the oracle feeds, by feed name. The values
+// are integers, each feed defines its own scale (e.g. a price with 6 decimals).
+type OracleValues map[string]int64
+
+func OracleValuesFromBytes(data []byte) (ret OracleValues, err error) {
+	ret = OracleValues{}
+	_, err = rwutil.ReadFromBytes(data, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Names returns the names of the feeds, sorted
+func (v OracleValues) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (v OracleValues) Validate() error {
+	if len(v) > MaxOracleFeeds {
+		return fmt.Errorf("too many oracle feeds: %d > %d", len(v), MaxOracleFeeds)
+	}
+	for name := range v {
+		if name == "" || len(name) > MaxOracleFeedNameLength {
+			return fmt.Errorf("invalid oracle feed name: %q", name)
+		}
+	}
+	return nil
+}
+
+func (v OracleValues) Bytes() []byte {
+	return rwutil.WriteToBytes(&v)
+}
+
+func (v *OracleValues) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	size := rr.ReadSize16()
+	for i := 0; i < size && rr.Err == nil; i++ {
+		name := rr.ReadString()
+		(*v)[name] = rr.ReadInt64()
+	}
+	return rr.Err
+}
+
+func (v *OracleValues) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	names := v.Names()
+	ww.WriteSize16(len(names))
+	for _, name := range names {
+		ww.WriteString(name)
+		ww.WriteInt64((*v)[name])
+	}
+	return ww.Err
+}
+
+// OracleSigningMessage returns the message signed by the committee to certify
+// the oracle values agreed for a block: the ID of the anchor output the block
+// is built upon, followed by the encoded values.
+func OracleSigningMessage(anchorOutputID iotago.OutputID, values OracleValues) []byte {
+	return append(anchorOutputID[:], values.Bytes()...)
+}
+
+// OracleValuesFromSigningMessage decodes the values from a message produced
+// by OracleSigningMessage.
+func OracleValuesFromSigningMessage(msg []byte) (OracleValues, error) {
+	if len(msg) < iotago.OutputIDLength {
+		return nil, errors.New("invalid oracle signing message")
+	}
+	return OracleValuesFromBytes(msg[iotago.OutputIDLength:])
+}
+
+// OracleData is a set of oracle values agreed by the committee, along with
+// the proof of their provenance.
+type OracleData struct {
+	Values OracleValues
+	// SignedData is the message signed by the committee, see OracleSigningMessage
+	SignedData []byte
+	// Signature is the BLS threshold signature of SignedData, including the
+	// public key of the committee
+	Signature []byte
+}
+
+func NewOracleData(anchorOutputID iotago.OutputID, values OracleValues, signature []byte) *OracleData {
+	return &OracleData{
+		Values:     values,
+		SignedData: OracleSigningMessage(anchorOutputID, values),
+		Signature:  signature,
+	}
+}
+
+// Verify checks that the values were signed by the owner of the public key
+// included in the signature.
+func (d *OracleData) Verify() error {
+	sig, _, err := bls.SignatureWithPublicKeyFromBytes(d.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid oracle signature: %w", err)
+	}
+	if !sig.IsValid(d.SignedData) {
+		return errors.New("oracle signature does not match the signed data")
+	}
+	values, err := OracleValuesFromSigningMessage(d.SignedData)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(values.Bytes(), d.Values.Bytes()) {
+		return errors.New("oracle values do not match the signed data")
+	}
+	return nil
+}
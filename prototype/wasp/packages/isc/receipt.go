@@ -18,6 +18,7 @@ type Receipt struct {
 	BlockIndex    uint32             `json:"blockIndex"`
 	RequestIndex  uint16             `json:"requestIndex"`
 	ResolvedError string             `json:"resolvedError"`
+	ContractFees  []byte             `json:"contractFees,omitempty"` // serialized governance.ContractFeesRecord, if any
 	GasBurnLog    *gas.BurnLog       `json:"-"`
 }
 
@@ -49,6 +49,7 @@ type UnsignedOffLedgerRequest interface {
 	Bytes() []byte
 	WithNonce(nonce uint64) UnsignedOffLedgerRequest
 	WithGasBudget(gasBudget uint64) UnsignedOffLedgerRequest
+	WithMaxFee(maxFee, tip uint64) UnsignedOffLedgerRequest
 	WithAllowance(allowance *Assets) UnsignedOffLedgerRequest
 	WithSender(sender *cryptolib.PublicKey) UnsignedOffLedgerRequest
 	Sign(key *cryptolib.KeyPair) OffLedgerRequest
@@ -58,10 +59,39 @@ type OffLedgerRequest interface {
 	Request
 	ChainID() ChainID
 	Nonce() uint64
+	MaxFee() uint64
+	Tip() uint64
 	VerifySignature() error
 	EVMTransaction() *types.Transaction
 }
 
+// EncryptedOffLedgerRequest is an off-ledger request with the call encrypted
+// to the committee of the chain, see NewEncryptedOffLedgerRequest.
+type EncryptedOffLedgerRequest interface {
+	OffLedgerRequest
+	Deadline() uint32
+	EncapsulatedKey() []byte
+	EncryptionLabel() []byte
+	IsDecrypted() bool
+	WithDecryptionKey(key []byte) (EncryptedOffLedgerRequest, error)
+}
+
+// KeyEncapsulation provides the symmetric key for an encrypted request, and
+// encapsulates it to the committee, bound to the label of the request. See
+// tcrypto.KeyEncapsulation.
+type KeyEncapsulation interface {
+	Key() []byte
+	EncapsulatedKey(label []byte) ([]byte, error)
+}
+
+type UnsignedEncryptedOffLedgerRequest interface {
+	WithNonce(nonce uint64) UnsignedEncryptedOffLedgerRequest
+	WithGasBudget(gasBudget uint64) UnsignedEncryptedOffLedgerRequest
+	WithMaxFee(maxFee, tip uint64) UnsignedEncryptedOffLedgerRequest
+	WithAllowance(allowance *Assets) UnsignedEncryptedOffLedgerRequest
+	Sign(key *cryptolib.KeyPair) EncryptedOffLedgerRequest
+}
+
 type OnLedgerRequest interface {
 	Request
 	Clone() OnLedgerRequest
@@ -71,6 +101,13 @@ type OnLedgerRequest interface {
 	Features() Features
 }
 
+// ScheduledRequest is a request created by the VM to execute a call
+// registered in the scheduler core contract.
+type ScheduledRequest interface {
+	Request
+	ScheduledCall() *ScheduledCall
+}
+
 type ReturnAmountOptions interface {
 	ReturnTo() iotago.Address
 	Amount() uint64
@@ -147,5 +184,10 @@ func RequestIsUnlockable(req OnLedgerRequest, chainAddress iotago.Address, curre
 }
 
 func RequestHash(req Request) hashing.HashValue {
+	if encryptedReq, ok := req.(*encryptedOffLedgerRequest); ok {
+		// The decryption key is attached after the request is ordered,
+		// so the hash covers the signed part of the request only.
+		return hashing.HashData(encryptedReq.envelopeBytes())
+	}
 	return hashing.HashData(req.Bytes())
 }
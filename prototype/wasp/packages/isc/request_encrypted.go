@@ -0,0 +1,430 @@
+package isc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// encryptedOffLedgerRequest is an off-ledger request with the call (the target,
+// the parameters and the allowance) encrypted to the committee of the chain, so
+// that the call is not known before the request is ordered. The sender, the
+// nonce, the gas budget and the fees are left in plaintext, the mempool needs
+// them. The call is encrypted with a symmetric key, which is in turn encrypted
+// to the BLS key of the committee (see tcrypto.KeyEncapsulation). The
+// encapsulated key is bound to the label of the request (the sender and the
+// encrypted call), so it cannot be reused in another request to get the call
+// decrypted before this one is ordered. The committee recovers the key after
+// the batch of requests is decided, and attaches it to the request, so that
+// anyone can decrypt the call afterwards.
+//
+// The call is revealed, once the batch is decided, even if the block is not
+// produced then (e.g. the consensus outputs SKIP, or the anchor is consumed by
+// another block in the meantime). Therefore the sender sets a deadline, the
+// index of the last block, the request can be processed in. It is in the
+// plaintext and signed, the VM skips the request in the later blocks, and the
+// mempool drops it, so that a revealed call cannot be front-run after the
+// deadline.
+type encryptedOffLedgerRequest struct {
+	chainID         ChainID
+	nonce           uint64
+	deadline        uint32
+	gasBudget       uint64
+	maxFee          uint64
+	tip             uint64
+	encapsulatedKey []byte
+	encryptedCall   []byte
+	signature       offLedgerSignature
+	// nil, until the request is decrypted (or before it is signed)
+	decryptionKey []byte
+	call          *encryptedCall
+}
+
+// encryptedCall is the plaintext of the encrypted part of the request.
+type encryptedCall struct {
+	contract   Hname
+	entryPoint Hname
+	params     dict.Dict
+	allowance  *Assets
+}
+
+// unsignedEncryptedOffLedgerRequest holds the call in plaintext, until it is
+// encrypted and signed.
+type unsignedEncryptedOffLedgerRequest struct {
+	req              *encryptedOffLedgerRequest
+	keyEncapsulation KeyEncapsulation
+}
+
+var (
+	_ Request                           = new(encryptedOffLedgerRequest)
+	_ OffLedgerRequest                  = new(encryptedOffLedgerRequest)
+	_ EncryptedOffLedgerRequest         = new(encryptedOffLedgerRequest)
+	_ Features                          = new(encryptedOffLedgerRequest)
+	_ UnsignedEncryptedOffLedgerRequest = new(unsignedEncryptedOffLedgerRequest)
+)
+
+// NewEncryptedOffLedgerRequest creates an off-ledger request with the call
+// encrypted with the key of the key encapsulation, usually a
+// tcrypto.KeyEncapsulation for the shared BLS public key of the committee.
+// The request is skipped in the blocks after the deadline (a block index).
+func NewEncryptedOffLedgerRequest(
+	chainID ChainID,
+	contract, entryPoint Hname,
+	params dict.Dict,
+	nonce uint64,
+	gasBudget uint64,
+	deadline uint32,
+	keyEncapsulation KeyEncapsulation,
+) UnsignedEncryptedOffLedgerRequest {
+	return &unsignedEncryptedOffLedgerRequest{
+		req: &encryptedOffLedgerRequest{
+			chainID:   chainID,
+			nonce:     nonce,
+			deadline:  deadline,
+			gasBudget: gasBudget,
+			call: &encryptedCall{
+				contract:   contract,
+				entryPoint: entryPoint,
+				params:     params,
+				allowance:  NewEmptyAssets(),
+			},
+		},
+		keyEncapsulation: keyEncapsulation,
+	}
+}
+
+func (u *unsignedEncryptedOffLedgerRequest) WithAllowance(allowance *Assets) UnsignedEncryptedOffLedgerRequest {
+	u.req.call.allowance = allowance.Clone()
+	return u
+}
+
+func (u *unsignedEncryptedOffLedgerRequest) WithGasBudget(gasBudget uint64) UnsignedEncryptedOffLedgerRequest {
+	u.req.gasBudget = gasBudget
+	return u
+}
+
+func (u *unsignedEncryptedOffLedgerRequest) WithMaxFee(maxFee, tip uint64) UnsignedEncryptedOffLedgerRequest {
+	u.req.maxFee = maxFee
+	u.req.tip = tip
+	return u
+}
+
+func (u *unsignedEncryptedOffLedgerRequest) WithNonce(nonce uint64) UnsignedEncryptedOffLedgerRequest {
+	u.req.nonce = nonce
+	return u
+}
+
+// Sign encrypts the call and signs the request. The returned request does
+// not contain the key, so it can be sent to the chain. Panics, if the key is
+// not a valid AES-256 key, or if it cannot be encapsulated.
+func (u *unsignedEncryptedOffLedgerRequest) Sign(key *cryptolib.KeyPair) EncryptedOffLedgerRequest {
+	aead, err := newEncryptedCallAEAD(u.keyEncapsulation.Key())
+	if err != nil {
+		panic(fmt.Errorf("cannot encrypt the call: %w", err))
+	}
+	req := &encryptedOffLedgerRequest{
+		chainID:       u.req.chainID,
+		nonce:         u.req.nonce,
+		deadline:      u.req.deadline,
+		gasBudget:     u.req.gasBudget,
+		maxFee:        u.req.maxFee,
+		tip:           u.req.tip,
+		encryptedCall: aead.Seal(nil, make([]byte, aead.NonceSize()), rwutil.WriteToBytes(u.req.call), nil),
+	}
+	req.encapsulatedKey, err = u.keyEncapsulation.EncapsulatedKey(encryptionLabel(key.GetPublicKey(), req.encryptedCall))
+	if err != nil {
+		panic(fmt.Errorf("cannot encapsulate the key: %w", err))
+	}
+	req.signature = offLedgerSignature{
+		publicKey: key.GetPublicKey(),
+		signature: key.GetPrivateKey().Sign(req.EssenceBytes()),
+	}
+	return req
+}
+
+// The key is used for a single request only, therefore the nonce is fixed.
+func newEncryptedCallAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// The label binds the encapsulated key to the sender and the encrypted call.
+func encryptionLabel(publicKey *cryptolib.PublicKey, encryptedCall []byte) []byte {
+	callHash := hashing.HashData(encryptedCall)
+	return append(append([]byte{}, publicKey.AsBytes()...), callHash[:]...)
+}
+
+func (call *encryptedCall) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.Read(&call.contract)
+	rr.Read(&call.entryPoint)
+	call.params = dict.New()
+	rr.Read(&call.params)
+	call.allowance = NewEmptyAssets()
+	rr.Read(call.allowance)
+	return rr.Err
+}
+
+func (call *encryptedCall) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.Write(&call.contract)
+	ww.Write(&call.entryPoint)
+	ww.Write(&call.params)
+	ww.Write(call.allowance)
+	return ww.Err
+}
+
+func (req *encryptedOffLedgerRequest) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	req.readEssence(rr)
+	req.signature.publicKey = cryptolib.NewEmptyPublicKey()
+	rr.Read(req.signature.publicKey)
+	req.signature.signature = rr.ReadBytes()
+	decryptionKey := rr.ReadBytes()
+	if rr.Err == nil && len(decryptionKey) > 0 {
+		rr.Err = req.decrypt(decryptionKey)
+	}
+	return rr.Err
+}
+
+// The decryption key is written after the signed part of the request, if the
+// request is decrypted already.
+func (req *encryptedOffLedgerRequest) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	req.writeEnvelope(ww)
+	ww.WriteBytes(req.decryptionKey)
+	return ww.Err
+}
+
+func (req *encryptedOffLedgerRequest) readEssence(rr *rwutil.Reader) {
+	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerEncrypted))
+	rr.Read(&req.chainID)
+	req.nonce = rr.ReadAmount64()
+	req.deadline = rr.ReadUint32()
+	req.gasBudget = rr.ReadGas64()
+	req.maxFee = rr.ReadAmount64()
+	req.tip = rr.ReadAmount64()
+	req.encapsulatedKey = rr.ReadBytes()
+	req.encryptedCall = rr.ReadBytes()
+}
+
+func (req *encryptedOffLedgerRequest) writeEssence(ww *rwutil.Writer) {
+	ww.WriteKind(rwutil.Kind(requestKindOffLedgerEncrypted))
+	ww.Write(&req.chainID)
+	ww.WriteAmount64(req.nonce)
+	ww.WriteUint32(req.deadline)
+	ww.WriteGas64(req.gasBudget)
+	ww.WriteAmount64(req.maxFee)
+	ww.WriteAmount64(req.tip)
+	ww.WriteBytes(req.encapsulatedKey)
+	ww.WriteBytes(req.encryptedCall)
+}
+
+func (req *encryptedOffLedgerRequest) writeEnvelope(ww *rwutil.Writer) {
+	req.writeEssence(ww)
+	ww.Write(req.signature.publicKey)
+	ww.WriteBytes(req.signature.signature)
+}
+
+// envelopeBytes are the signed part of the request, without the decryption key.
+func (req *encryptedOffLedgerRequest) envelopeBytes() []byte {
+	ww := rwutil.NewBytesWriter()
+	req.writeEnvelope(ww)
+	return ww.Bytes()
+}
+
+func (req *encryptedOffLedgerRequest) decrypt(key []byte) error {
+	aead, err := newEncryptedCallAEAD(key)
+	if err != nil {
+		return err
+	}
+	if len(req.encryptedCall) < aead.Overhead() {
+		return errors.New("encrypted call too short")
+	}
+	callBytes, err := aead.Open(nil, make([]byte, aead.NonceSize()), req.encryptedCall, nil)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt the call: %w", err)
+	}
+	call, err := rwutil.ReadFromBytes(callBytes, new(encryptedCall))
+	if err != nil {
+		return fmt.Errorf("cannot parse the decrypted call: %w", err)
+	}
+	req.decryptionKey = key
+	req.call = call
+	return nil
+}
+
+func (req *encryptedOffLedgerRequest) Allowance() *Assets {
+	if req.call == nil {
+		return NewEmptyAssets()
+	}
+	return req.call.allowance
+}
+
+func (req *encryptedOffLedgerRequest) Assets() *Assets {
+	return nil
+}
+
+func (req *encryptedOffLedgerRequest) Bytes() []byte {
+	return rwutil.WriteToBytes(req)
+}
+
+// CallTarget is unknown (zero), until the request is decrypted.
+func (req *encryptedOffLedgerRequest) CallTarget() CallTarget {
+	if req.call == nil {
+		return CallTarget{}
+	}
+	return CallTarget{
+		Contract:   req.call.contract,
+		EntryPoint: req.call.entryPoint,
+	}
+}
+
+func (req *encryptedOffLedgerRequest) ChainID() ChainID {
+	return req.chainID
+}
+
+// Deadline is the index of the last block, the request can be processed in.
+func (req *encryptedOffLedgerRequest) Deadline() uint32 {
+	return req.deadline
+}
+
+func (req *encryptedOffLedgerRequest) EncapsulatedKey() []byte {
+	return req.encapsulatedKey
+}
+
+func (req *encryptedOffLedgerRequest) EssenceBytes() []byte {
+	ww := rwutil.NewBytesWriter()
+	req.writeEssence(ww)
+	return ww.Bytes()
+}
+
+// EncryptionLabel is the label, the encapsulated key has to be bound to.
+func (req *encryptedOffLedgerRequest) EncryptionLabel() []byte {
+	return encryptionLabel(req.signature.publicKey, req.encryptedCall)
+}
+
+func (*encryptedOffLedgerRequest) EVMTransaction() *types.Transaction {
+	return nil
+}
+
+func (req *encryptedOffLedgerRequest) Expiry() (time.Time, iotago.Address) {
+	return time.Time{}, nil
+}
+
+func (req *encryptedOffLedgerRequest) GasBudget() (gasBudget uint64, isEVM bool) {
+	return req.gasBudget, false
+}
+
+// ID does not depend on the decryption key, so that the request has the same
+// ID before and after it is decrypted.
+func (req *encryptedOffLedgerRequest) ID() RequestID {
+	return NewRequestID(iotago.TransactionID(hashing.HashData(req.envelopeBytes())), 0)
+}
+
+func (req *encryptedOffLedgerRequest) IsDecrypted() bool {
+	return req.decryptionKey != nil
+}
+
+func (req *encryptedOffLedgerRequest) IsOffLedger() bool {
+	return true
+}
+
+func (req *encryptedOffLedgerRequest) MaxFee() uint64 {
+	return req.maxFee
+}
+
+func (req *encryptedOffLedgerRequest) NFT() *NFT {
+	return nil
+}
+
+func (req *encryptedOffLedgerRequest) Nonce() uint64 {
+	return req.nonce
+}
+
+func (req *encryptedOffLedgerRequest) Params() dict.Dict {
+	if req.call == nil {
+		return dict.New()
+	}
+	return req.call.params
+}
+
+func (req *encryptedOffLedgerRequest) ReturnAmount() (uint64, bool) {
+	return 0, false
+}
+
+func (req *encryptedOffLedgerRequest) SenderAccount() AgentID {
+	return NewAgentID(req.signature.publicKey.AsEd25519Address())
+}
+
+func (req *encryptedOffLedgerRequest) String() string {
+	if req.call == nil {
+		return fmt.Sprintf("encryptedOffLedgerRequest::{ ID: %s, sender: %s, nonce: %d, deadline: %d, decrypted: false }",
+			req.ID().String(),
+			req.SenderAccount().String(),
+			req.nonce,
+			req.deadline,
+		)
+	}
+	return fmt.Sprintf("encryptedOffLedgerRequest::{ ID: %s, sender: %s, target: %s, entrypoint: %s, Params: %s, nonce: %d, deadline: %d, decrypted: true }",
+		req.ID().String(),
+		req.SenderAccount().String(),
+		req.call.contract.String(),
+		req.call.entryPoint.String(),
+		req.call.params.String(),
+		req.nonce,
+		req.deadline,
+	)
+}
+
+func (req *encryptedOffLedgerRequest) TargetAddress() iotago.Address {
+	return req.chainID.AsAddress()
+}
+
+func (req *encryptedOffLedgerRequest) TimeLock() time.Time {
+	return time.Time{}
+}
+
+func (req *encryptedOffLedgerRequest) Timestamp() time.Time {
+	return time.Time{}
+}
+
+func (req *encryptedOffLedgerRequest) Tip() uint64 {
+	return req.tip
+}
+
+// VerifySignature verifies the signature of the encrypted request. The
+// decrypted call is covered by it as well, as it is authenticated by the key.
+func (req *encryptedOffLedgerRequest) VerifySignature() error {
+	if !req.signature.publicKey.Verify(req.EssenceBytes(), req.signature.signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// WithDecryptionKey returns a copy of the request with the call decrypted by
+// the key, recovered by the committee.
+func (req *encryptedOffLedgerRequest) WithDecryptionKey(key []byte) (EncryptedOffLedgerRequest, error) {
+	decrypted := *req
+	if err := decrypted.decrypt(key); err != nil {
+		return nil, err
+	}
+	return &decrypted, nil
+}
@@ -35,7 +35,7 @@ func NewEVMOffLedgerCallRequest(chainID ChainID, callMsg ethereum.CallMsg) OffLe
 
 func (req *evmOffLedgerCallRequest) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
-	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerEVMTx))
+	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerEVMCall))
 	rr.Read(&req.chainID)
 	data := rr.ReadBytes()
 	if rr.Err == nil {
@@ -46,7 +46,7 @@ func (req *evmOffLedgerCallRequest) Read(r io.Reader) error {
 
 func (req *evmOffLedgerCallRequest) Write(w io.Writer) error {
 	ww := rwutil.NewWriter(w)
-	ww.WriteKind(rwutil.Kind(requestKindOffLedgerEVMTx))
+	ww.WriteKind(rwutil.Kind(requestKindOffLedgerEVMCall))
 	ww.Write(&req.chainID)
 	if ww.Err == nil {
 		data := evmtypes.EncodeCallMsg(req.callMsg)
@@ -90,6 +90,10 @@ func (req *evmOffLedgerCallRequest) IsOffLedger() bool {
 	return true
 }
 
+func (req *evmOffLedgerCallRequest) MaxFee() uint64 {
+	return 0
+}
+
 func (req *evmOffLedgerCallRequest) NFT() *NFT {
 	return nil
 }
@@ -116,6 +120,10 @@ func (req *evmOffLedgerCallRequest) String() string {
 	)
 }
 
+func (req *evmOffLedgerCallRequest) Tip() uint64 {
+	return 0
+}
+
 func (req *evmOffLedgerCallRequest) TargetAddress() iotago.Address {
 	return req.chainID.AsAddress()
 }
@@ -105,6 +105,11 @@ func (req *evmOffLedgerTxRequest) IsOffLedger() bool {
 	return true
 }
 
+// MaxFee is always 0: the max fee of the EVM transaction is its gas fee cap
+func (req *evmOffLedgerTxRequest) MaxFee() uint64 {
+	return 0
+}
+
 func (req *evmOffLedgerTxRequest) NFT() *NFT {
 	return nil
 }
@@ -134,6 +139,10 @@ func (req *evmOffLedgerTxRequest) String() string {
 	)
 }
 
+func (req *evmOffLedgerTxRequest) Tip() uint64 {
+	return 0
+}
+
 func (req *evmOffLedgerTxRequest) TargetAddress() iotago.Address {
 	return req.chainID.AsAddress()
 }
@@ -26,9 +26,11 @@ type OffLedgerRequestData struct {
 	contract   Hname
 	entryPoint Hname
 	gasBudget  uint64
+	maxFee     uint64
 	nonce      uint64
 	params     dict.Dict
 	signature  offLedgerSignature
+	tip        uint64
 }
 
 var (
@@ -75,7 +77,10 @@ func (req *OffLedgerRequestData) Write(w io.Writer) error {
 }
 
 func (req *OffLedgerRequestData) readEssence(rr *rwutil.Reader) {
-	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerISC))
+	kind := RequestKind(rr.ReadKind())
+	if kind != requestKindOffLedgerISC && kind != requestKindOffLedgerISCWithFees && rr.Err == nil {
+		rr.Err = errors.New("unexpected object kind")
+	}
 	rr.Read(&req.chainID)
 	rr.Read(&req.contract)
 	rr.Read(&req.entryPoint)
@@ -85,10 +90,21 @@ func (req *OffLedgerRequestData) readEssence(rr *rwutil.Reader) {
 	req.gasBudget = rr.ReadGas64()
 	req.allowance = NewEmptyAssets()
 	rr.Read(req.allowance)
+	if kind == requestKindOffLedgerISCWithFees {
+		req.maxFee = rr.ReadAmount64()
+		req.tip = rr.ReadAmount64()
+	}
 }
 
 func (req *OffLedgerRequestData) writeEssence(ww *rwutil.Writer) {
-	ww.WriteKind(rwutil.Kind(requestKindOffLedgerISC))
+	// the fees are optional, so that the requests without them keep the
+	// original format
+	hasFees := req.maxFee != 0 || req.tip != 0
+	if hasFees {
+		ww.WriteKind(rwutil.Kind(requestKindOffLedgerISCWithFees))
+	} else {
+		ww.WriteKind(rwutil.Kind(requestKindOffLedgerISC))
+	}
 	ww.Write(&req.chainID)
 	ww.Write(&req.contract)
 	ww.Write(&req.entryPoint)
@@ -96,6 +112,10 @@ func (req *OffLedgerRequestData) writeEssence(ww *rwutil.Writer) {
 	ww.WriteAmount64(req.nonce)
 	ww.WriteGas64(req.gasBudget)
 	ww.Write(req.allowance)
+	if hasFees {
+		ww.WriteAmount64(req.maxFee)
+		ww.WriteAmount64(req.tip)
+	}
 }
 
 // Allowance from the sender's account to the target smart contract. Nil mean no Allowance
@@ -149,6 +169,12 @@ func (req *OffLedgerRequestData) IsOffLedger() bool {
 	return true
 }
 
+// MaxFee is the maximum amount of base tokens the sender pays for the gas
+// budget of the request, including the tip. 0 means no limit.
+func (req *OffLedgerRequestData) MaxFee() uint64 {
+	return req.maxFee
+}
+
 func (req *OffLedgerRequestData) NFT() *NFT {
 	return nil
 }
@@ -203,6 +229,11 @@ func (req *OffLedgerRequestData) Timestamp() time.Time {
 	return time.Time{}
 }
 
+// Tip is the amount of base tokens paid to the validators on top of the gas fee
+func (req *OffLedgerRequestData) Tip() uint64 {
+	return req.tip
+}
+
 // VerifySignature verifies essence signature
 func (req *OffLedgerRequestData) VerifySignature() error {
 	if !req.signature.publicKey.Verify(req.EssenceBytes(), req.signature.signature) {
@@ -221,6 +252,15 @@ func (req *OffLedgerRequestData) WithGasBudget(gasBudget uint64) UnsignedOffLedg
 	return req
 }
 
+// WithMaxFee sets the maximum fee and the tip of the request: the request is
+// not processed while the fee for its gas budget exceeds maxFee, and the tip
+// is reduced so that the total does not exceed it
+func (req *OffLedgerRequestData) WithMaxFee(maxFee, tip uint64) UnsignedOffLedgerRequest {
+	req.maxFee = maxFee
+	req.tip = tip
+	return req
+}
+
 func (req *OffLedgerRequestData) WithNonce(nonce uint64) UnsignedOffLedgerRequest {
 	req.nonce = nonce
 	return req
@@ -0,0 +1,98 @@
+package isc
+
+import (
+	"fmt"
+	"io"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// scheduledCallRequest is injected by the VM to execute a due ScheduledCall.
+// It is never sent to the chain: it has no signature nor UTXO, and it is
+// only found in the block log.
+type scheduledCallRequest struct {
+	chainID ChainID
+	call    ScheduledCall
+}
+
+var _ ScheduledRequest = &scheduledCallRequest{}
+
+func NewScheduledCallRequest(chainID ChainID, call *ScheduledCall) ScheduledRequest {
+	return &scheduledCallRequest{
+		chainID: chainID,
+		call:    *call,
+	}
+}
+
+func (req *scheduledCallRequest) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.ReadKindAndVerify(rwutil.Kind(requestKindScheduledCall))
+	rr.Read(&req.chainID)
+	rr.Read(&req.call)
+	return rr.Err
+}
+
+func (req *scheduledCallRequest) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteKind(rwutil.Kind(requestKindScheduledCall))
+	ww.Write(&req.chainID)
+	ww.Write(&req.call)
+	return ww.Err
+}
+
+func (req *scheduledCallRequest) Allowance() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *scheduledCallRequest) Assets() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *scheduledCallRequest) Bytes() []byte {
+	return rwutil.WriteToBytes(req)
+}
+
+func (req *scheduledCallRequest) CallTarget() CallTarget {
+	return req.call.Target
+}
+
+func (req *scheduledCallRequest) GasBudget() (gas uint64, isEVM bool) {
+	return req.call.GasBudget, false
+}
+
+// ID is unique for each execution of the schedule, since the serialized
+// request includes the deadline.
+func (req *scheduledCallRequest) ID() RequestID {
+	return NewRequestID(iotago.TransactionID(hashing.HashData(req.Bytes())), 0)
+}
+
+func (req *scheduledCallRequest) IsOffLedger() bool {
+	return false
+}
+
+func (req *scheduledCallRequest) NFT() *NFT {
+	return nil
+}
+
+func (req *scheduledCallRequest) Params() dict.Dict {
+	return req.call.Params
+}
+
+func (req *scheduledCallRequest) ScheduledCall() *ScheduledCall {
+	return &req.call
+}
+
+func (req *scheduledCallRequest) SenderAccount() AgentID {
+	return req.call.Owner
+}
+
+func (req *scheduledCallRequest) String() string {
+	return fmt.Sprintf("scheduledCallRequest::{ ID: %s, %s }", req.ID(), &req.call)
+}
+
+func (req *scheduledCallRequest) TargetAddress() iotago.Address {
+	return req.chainID.AsAddress()
+}
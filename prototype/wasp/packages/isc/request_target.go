@@ -16,3 +16,18 @@ func NewCallTarget(contract, entryPoint Hname) CallTarget {
 func (t CallTarget) Equals(otherTarget CallTarget) bool {
 	return t.Contract == otherTarget.Contract && t.EntryPoint == otherTarget.EntryPoint
 }
+
+// RequestTargetAgentID returns the agent of the contract called by the request:
+// the ISC contract, or the EVM contract in case of an EVM transaction (nil if
+// it is a contract creation)
+func RequestTargetAgentID(req Request, chainID ChainID) AgentID {
+	if offLedgerReq, ok := req.(OffLedgerRequest); ok {
+		if tx := offLedgerReq.EVMTransaction(); tx != nil {
+			if tx.To() == nil {
+				return nil
+			}
+			return NewEthereumAddressAgentID(chainID, *tx.To())
+		}
+	}
+	return NewContractAgentID(chainID, req.CallTarget().Contract)
+}
@@ -23,6 +23,19 @@ func TestRequestDataSerialization(t *testing.T) {
 		rwutil.BytesTest(t, req, RequestFromBytes)
 	})
 
+	t.Run("off ledger with fees", func(t *testing.T) {
+		unsigned := NewOffLedgerRequest(RandomChainID(), 3, 14, dict.New(), 1337, 100)
+		kindWithoutFees := unsigned.(*OffLedgerRequestData).EssenceBytes()[0]
+		req = unsigned.WithMaxFee(50, 5).Sign(cryptolib.NewKeyPair())
+		rwutil.ReadWriteTest(t, req.(*OffLedgerRequestData), new(OffLedgerRequestData))
+		rwutil.BytesTest(t, req, RequestFromBytes)
+		require.True(t, IsOffledgerKind(req.Bytes()[0]))
+		require.NotEqual(t, kindWithoutFees, req.Bytes()[0])
+		require.EqualValues(t, 50, req.(OffLedgerRequest).MaxFee())
+		require.EqualValues(t, 5, req.(OffLedgerRequest).Tip())
+		require.NoError(t, req.(OffLedgerRequest).VerifySignature())
+	})
+
 	t.Run("on ledger", func(t *testing.T) {
 		sender := tpkg.RandAliasAddress()
 		requestMetadata := &RequestMetadata{
@@ -54,6 +67,57 @@ func TestRequestDataSerialization(t *testing.T) {
 	})
 }
 
+func TestEncryptedOffLedgerRequest(t *testing.T) {
+	key := hashing.PseudoRandomHash(nil)
+	keyEncapsulation := &testKeyEncapsulation{key: key[:]}
+	params := dict.Dict{"a": []byte{1}}
+	sender := cryptolib.NewKeyPair()
+	req := NewEncryptedOffLedgerRequest(RandomChainID(), 3, 14, params, 1337, 100, 42, keyEncapsulation).
+		WithMaxFee(50, 5).
+		WithAllowance(NewAssetsBaseTokens(7)).
+		Sign(sender)
+	require.NoError(t, req.VerifySignature())
+	require.Equal(t, keyEncapsulation.label, req.EncryptionLabel())
+	require.Equal(t, keyEncapsulation.label, req.EncapsulatedKey())
+	require.False(t, req.IsDecrypted())
+	require.EqualValues(t, 42, req.Deadline())
+	require.Equal(t, CallTarget{}, req.CallTarget())
+	require.True(t, IsOffledgerKind(req.Bytes()[0]))
+	rwutil.ReadWriteTest(t, req.(*encryptedOffLedgerRequest), new(encryptedOffLedgerRequest))
+	rwutil.BytesTest(t, Request(req), RequestFromBytes)
+
+	wrongKey := hashing.PseudoRandomHash(nil)
+	_, err := req.WithDecryptionKey(wrongKey[:])
+	require.Error(t, err)
+
+	decrypted, err := req.WithDecryptionKey(key[:])
+	require.NoError(t, err)
+	require.True(t, decrypted.IsDecrypted())
+	require.False(t, req.IsDecrypted())
+	require.Equal(t, CallTarget{Contract: 3, EntryPoint: 14}, decrypted.CallTarget())
+	require.Equal(t, params, decrypted.Params())
+	require.EqualValues(t, 7, decrypted.Allowance().BaseTokens)
+	require.NoError(t, decrypted.VerifySignature())
+	require.Equal(t, req.ID(), decrypted.ID())
+	require.True(t, RequestRefFromRequest(req).IsFor(decrypted))
+	rwutil.BytesTest(t, Request(decrypted), RequestFromBytes)
+}
+
+// Encapsulates the key to the label itself, to check the label is used.
+type testKeyEncapsulation struct {
+	key   []byte
+	label []byte
+}
+
+func (ke *testKeyEncapsulation) Key() []byte {
+	return ke.key
+}
+
+func (ke *testKeyEncapsulation) EncapsulatedKey(label []byte) ([]byte, error) {
+	ke.label = label
+	return label, nil
+}
+
 func TestRequestIDSerialization(t *testing.T) {
 	req := NewOffLedgerRequest(RandomChainID(), 3, 14, dict.New(), 1337, 200).Sign(cryptolib.NewKeyPair())
 	requestID := req.ID()
@@ -20,11 +20,14 @@ const (
 	requestKindOffLedgerISC
 	requestKindOffLedgerEVMTx
 	requestKindOffLedgerEVMCall
+	requestKindScheduledCall
+	requestKindOffLedgerISCWithFees
+	requestKindOffLedgerEncrypted
 )
 
 func IsOffledgerKind(b byte) bool {
 	switch RequestKind(b) {
-	case requestKindOffLedgerISC, requestKindOffLedgerEVMTx:
+	case requestKindOffLedgerISC, requestKindOffLedgerISCWithFees, requestKindOffLedgerEVMTx, requestKindOffLedgerEncrypted:
 		return true
 	}
 	return false
@@ -40,12 +43,16 @@ func RequestFromReader(rr *rwutil.Reader) (ret Request) {
 	switch RequestKind(kind) {
 	case requestKindOnLedger:
 		ret = new(onLedgerRequestData)
-	case requestKindOffLedgerISC:
+	case requestKindOffLedgerISC, requestKindOffLedgerISCWithFees:
 		ret = new(OffLedgerRequestData)
 	case requestKindOffLedgerEVMTx:
 		ret = new(evmOffLedgerTxRequest)
 	case requestKindOffLedgerEVMCall:
 		ret = new(evmOffLedgerCallRequest)
+	case requestKindScheduledCall:
+		ret = new(scheduledCallRequest)
+	case requestKindOffLedgerEncrypted:
+		ret = new(encryptedOffLedgerRequest)
 	default:
 		if rr.Err == nil {
 			rr.Err = errors.New("invalid Request kind")
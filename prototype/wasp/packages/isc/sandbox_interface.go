@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -121,6 +122,21 @@ type Sandbox interface {
 	// (e.g. with the debug_traceTransaction JSONRPC method).
 	EVMTracer() *EVMTracer
 
+	// EVMCallOverrides returns non-nil overrides if an EVM call is being
+	// simulated with a modified state or block context (e.g. with the
+	// eth_call JSONRPC method).
+	EVMCallOverrides() *EVMCallOverrides
+
+	// SimulationMode returns true if the request is executed as part of a
+	// simulated bundle of blocks (e.g. with the eth_simulateV1 JSONRPC method).
+	// In that case EVM calls keep their changes to the state.
+	SimulationMode() bool
+
+	// RecordSimulatedCalls returns true if EVM calls executed in simulation
+	// mode must be recorded as transactions in the EVM block (e.g. when
+	// sending transactions on behalf of an impersonated account in Solo).
+	RecordSimulatedCalls() bool
+
 	// TakeStateSnapshot takes a snapshot of the state. This is useful to implement the try/catch
 	// behavior in Solidity, where the state is reverted after a low level call fails.
 	TakeStateSnapshot() int
@@ -235,4 +251,46 @@ type BLS interface {
 type EVMTracer struct {
 	Tracer  tracers.Tracer
 	TxIndex uint64
+	// If NewTracer is set, it is called for each EVM tx in the block, and
+	// Tracer/TxIndex are ignored. It may return nil to skip tracing the tx.
+	NewTracer func(txIndex uint64) tracers.Tracer
+}
+
+// TracerFor returns the tracer to be used for the EVM tx with the given index
+// in the block, or nil if the tx should not be traced.
+func (t *EVMTracer) TracerFor(txIndex uint64) tracers.Tracer {
+	if t.NewTracer != nil {
+		return t.NewTracer(txIndex)
+	}
+	if txIndex != t.TxIndex {
+		return nil
+	}
+	return t.Tracer
+}
+
+// EVMCallOverrides contains the modifications to apply to the EVM state and
+// block context before executing an EVM call. The modifications are never
+// committed.
+type EVMCallOverrides struct {
+	State map[common.Address]*EVMAccountOverride
+	Block *EVMBlockOverrides
+}
+
+// EVMAccountOverride contains the account fields to override. Nil fields are
+// left unmodified.
+type EVMAccountOverride struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+	// State replaces the whole account storage
+	State map[common.Hash]common.Hash
+	// StateDiff replaces only the given storage slots
+	StateDiff map[common.Hash]common.Hash
+}
+
+// EVMBlockOverrides contains the block context fields to override. Nil fields
+// are left unmodified.
+type EVMBlockOverrides struct {
+	Number *big.Int
+	Time   *uint64
 }
@@ -0,0 +1,84 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package isc
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// ScheduledCall is a call registered in the scheduler core contract. The VM
+// executes it on behalf of its owner in the first block with a timestamp
+// past the deadline, with a gas budget drawn from the owner's L2 account.
+type ScheduledCall struct {
+	ID        uint32
+	Owner     AgentID
+	Target    CallTarget
+	Params    dict.Dict
+	GasBudget uint64
+	// Deadline is the time after which the next call is executed
+	Deadline time.Time
+	// Period is the interval between calls of a recurring schedule, or 0 for
+	// a one-shot call
+	Period time.Duration
+}
+
+func ScheduledCallFromBytes(data []byte) (*ScheduledCall, error) {
+	return rwutil.ReadFromBytes(data, new(ScheduledCall))
+}
+
+func (c *ScheduledCall) IsRecurring() bool {
+	return c.Period > 0
+}
+
+// NextDeadline returns the first deadline of a recurring schedule that is
+// after the given time. Missed periods are skipped, so that a recurring call
+// is executed at most once per block.
+func (c *ScheduledCall) NextDeadline(now time.Time) time.Time {
+	if !c.IsRecurring() || c.Deadline.After(now) {
+		return c.Deadline
+	}
+	missed := now.Sub(c.Deadline)/c.Period + 1
+	return c.Deadline.Add(missed * c.Period)
+}
+
+func (c *ScheduledCall) Bytes() []byte {
+	return rwutil.WriteToBytes(c)
+}
+
+func (c *ScheduledCall) String() string {
+	return fmt.Sprintf("ScheduledCall{ID: %d, Owner: %s, Target: %s::%s, GasBudget: %d, Deadline: %s, Period: %s}",
+		c.ID, c.Owner, c.Target.Contract, c.Target.EntryPoint, c.GasBudget, c.Deadline.UTC(), c.Period)
+}
+
+func (c *ScheduledCall) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	c.ID = rr.ReadUint32()
+	c.Owner = AgentIDFromReader(rr)
+	rr.Read(&c.Target.Contract)
+	rr.Read(&c.Target.EntryPoint)
+	c.Params = dict.New()
+	rr.Read(&c.Params)
+	c.GasBudget = rr.ReadGas64()
+	c.Deadline = time.Unix(0, rr.ReadInt64())
+	c.Period = rr.ReadDuration()
+	return rr.Err
+}
+
+func (c *ScheduledCall) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint32(c.ID)
+	ww.Write(c.Owner)
+	ww.Write(&c.Target.Contract)
+	ww.Write(&c.Target.EntryPoint)
+	ww.Write(&c.Params)
+	ww.WriteGas64(c.GasBudget)
+	ww.WriteInt64(c.Deadline.UnixNano())
+	ww.WriteDuration(c.Period)
+	return ww.Err
+}
@@ -137,28 +137,43 @@ func (b *BufferedKVStore) IterateSorted(prefix kv.Key, f func(key kv.Key, value
 	})
 }
 
+// IterateKeysSorted merges the sorted keys of the backing store with the
+// buffered ones, so that the iteration can stop early without reading all
+// keys with the given prefix.
 func (b *BufferedKVStore) IterateKeysSorted(prefix kv.Key, f func(key kv.Key) bool) {
 	var keys []kv.Key
-
 	for k := range b.muts.Sets {
 		if !k.HasPrefix(prefix) {
 			continue
 		}
 		keys = append(keys, k)
 	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
+	stopped := false
 	b.r.IterateKeysSorted(prefix, func(k kv.Key) bool {
-		if !b.muts.Contains(k) {
-			keys = append(keys, k)
+		for len(keys) > 0 && keys[0] < k {
+			if !f(keys[0]) {
+				stopped = true
+				return false
+			}
+			keys = keys[1:]
+		}
+		if b.muts.Contains(k) {
+			return true
+		}
+		if !f(k) {
+			stopped = true
+			return false
 		}
 		return true
 	})
-
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-
+	if stopped {
+		return
+	}
 	for _, k := range keys {
 		if !f(k) {
-			break
+			return
 		}
 	}
 }
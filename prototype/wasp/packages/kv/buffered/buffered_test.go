@@ -105,3 +105,27 @@ func TestIterateSorted(t *testing.T) {
 	})
 	require.Equal(t, []kv.Key{"234", "245", "247", "248", "250", "259"}, seen)
 }
+
+func TestIterateSortedStop(t *testing.T) {
+	db := mapdb.NewMapDB()
+	_ = db.Set([]byte("12"), []byte("v12"))
+	_ = db.Set([]byte("14"), []byte("v14"))
+	_ = db.Set([]byte("16"), []byte("v16"))
+	b := NewBufferedKVStore(kv.NewHiveKVStoreReader(db))
+
+	b.Set("13", []byte("v13"))
+	b.Set("14", []byte("v14'"))
+	b.Set("17", []byte("v17"))
+	b.Del("16")
+
+	iterate := func(n int) (seen []kv.Key) {
+		b.IterateKeysSorted("1", func(k kv.Key) bool {
+			seen = append(seen, k)
+			return len(seen) < n
+		})
+		return seen
+	}
+	require.Equal(t, []kv.Key{"12", "13", "14", "17"}, iterate(10))
+	require.Equal(t, []kv.Key{"12", "13"}, iterate(2))
+	require.Equal(t, []kv.Key{"12", "13", "14"}, iterate(3))
+}
@@ -19,8 +19,10 @@ type ChainMempoolMetricsProvider struct {
 	timePoolSize      *prometheus.GaugeVec
 	onLedgerPoolSize  *prometheus.GaugeVec
 	onLedgerReqTime   *prometheus.HistogramVec
+	onLedgerRefused   *prometheus.CounterVec
 	offLedgerPoolSize *prometheus.GaugeVec
 	offLedgerReqTime  *prometheus.HistogramVec
+	offLedgerEvicted  *prometheus.CounterVec
 	totalSize         *prometheus.GaugeVec
 	missingReqs       *prometheus.GaugeVec
 }
@@ -82,6 +84,12 @@ func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
 			Help:      "Time (s) an on-ledger request stayed in the mempool before removing it.",
 			Buckets:   execTimeBuckets,
 		}, []string{labelNameChain}),
+		onLedgerRefused: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "on_ledger_refused_total",
+			Help:      "Number of on-ledger requests not added to the mempool because it was full.",
+		}, []string{labelNameChain}),
 		offLedgerPoolSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: "iota_wasp",
 			Subsystem: "mempool",
@@ -95,6 +103,12 @@ func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
 			Help:      "Time (s) an off-ledger request stayed in the mempool before removing it.",
 			Buckets:   execTimeBuckets,
 		}, []string{labelNameChain}),
+		offLedgerEvicted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "off_ledger_evicted_total",
+			Help:      "Number of off-ledger requests evicted from the mempool because it was full.",
+		}, []string{labelNameChain}),
 		totalSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: "iota_wasp",
 			Subsystem: "mempool",
@@ -121,8 +135,10 @@ func (p *ChainMempoolMetricsProvider) register(reg prometheus.Registerer) {
 		p.timePoolSize,
 		p.onLedgerPoolSize,
 		p.onLedgerReqTime,
+		p.onLedgerRefused,
 		p.offLedgerPoolSize,
 		p.offLedgerReqTime,
+		p.offLedgerEvicted,
 		p.totalSize,
 		p.missingReqs,
 	)
@@ -155,8 +171,10 @@ func newChainMempoolMetrics(collectors *ChainMempoolMetricsProvider, chainID isc
 	collectors.timePoolSize.With(labels)
 	collectors.onLedgerPoolSize.With(labels)
 	collectors.onLedgerReqTime.With(labels)
+	collectors.onLedgerRefused.With(labels)
 	collectors.offLedgerPoolSize.With(labels)
 	collectors.offLedgerReqTime.With(labels)
+	collectors.offLedgerEvicted.With(labels)
 	collectors.totalSize.With(labels)
 	collectors.missingReqs.With(labels)
 
@@ -223,3 +241,11 @@ func (m *ChainMempoolMetrics) SetOnLedgerReqTime(d time.Duration) {
 func (m *ChainMempoolMetrics) SetOffLedgerReqTime(d time.Duration) {
 	m.collectors.offLedgerReqTime.With(m.labels).Observe(d.Seconds())
 }
+
+func (m *ChainMempoolMetrics) IncOnLedgerRefused() {
+	m.collectors.onLedgerRefused.With(m.labels).Inc()
+}
+
+func (m *ChainMempoolMetrics) IncOffLedgerEvicted() {
+	m.collectors.offLedgerEvicted.With(m.labels).Inc()
+}
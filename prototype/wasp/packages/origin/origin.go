@@ -25,8 +25,11 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/governance/governanceimpl"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/core/root/rootimpl"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -68,6 +71,9 @@ func InitChain(store state.Store, initParams dict.Dict, originDeposit uint64) st
 	errors.SetInitialState(contractState(errors.Contract))
 	governanceimpl.SetInitialState(contractState(governance.Contract), chainOwner, blockKeepAmount)
 	evmimpl.SetInitialState(contractState(evm.Contract), evmChainID)
+	scheduler.SetInitialState(contractState(scheduler.Contract))
+	oracle.SetInitialState(contractState(oracle.Contract))
+	sponsorship.SetInitialState(contractState(sponsorship.Contract))
 
 	block := store.Commit(d)
 	if err := store.SetLatest(block.TrieRoot()); err != nil {
@@ -15,6 +15,7 @@ import (
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
@@ -691,6 +692,25 @@ func (*Chain) ReceiveOffLedgerRequest(request isc.OffLedgerRequest, sender *cryp
 	panic("unimplemented")
 }
 
+// MempoolOffLedgerRequests implements chain.Chain
+func (ch *Chain) MempoolOffLedgerRequests(context.Context) []*mempool.AccountRequests {
+	return ch.mempool.OffLedgerRequests()
+}
+
+// MempoolRequests implements chain.Chain
+func (ch *Chain) MempoolRequests(_ context.Context, after uint64, limit int) *mempool.RequestsPage {
+	return ch.mempool.Requests(after, limit)
+}
+
+// MempoolRemoveRequest implements chain.Chain
+func (ch *Chain) MempoolRemoveRequest(_ context.Context, requestID isc.RequestID) bool {
+	return ch.mempool.RemoveRequest(requestID)
+}
+
+// GasEstimated implements chain.Chain. The solo mempool does not estimate the
+// gas of the requests, it proposes all of them.
+func (*Chain) GasEstimated(isc.Request, uint64) {}
+
 // AwaitRequestProcessed implements chain.Chain
 func (*Chain) AwaitRequestProcessed(ctx context.Context, requestID isc.RequestID, confirmed bool) <-chan *blocklog.RequestReceipt {
 	panic("unimplemented")
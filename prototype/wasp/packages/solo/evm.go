@@ -1,9 +1,11 @@
 package solo
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -14,6 +16,7 @@ import (
 
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/isc"
@@ -29,6 +32,9 @@ type jsonRPCSoloBackend struct {
 	Chain     *Chain
 	baseToken *parameters.BaseToken
 	snapshots []*Snapshot
+	// timeOffset is the total amount of time the clock was advanced with
+	// EVMIncreaseTime and EVMSetNextBlockTimestamp
+	timeOffset time.Duration
 }
 
 func newJSONRPCSoloBackend(chain *Chain, baseToken *parameters.BaseToken) jsonrpc.ChainBackend {
@@ -36,16 +42,29 @@ func newJSONRPCSoloBackend(chain *Chain, baseToken *parameters.BaseToken) jsonrp
 }
 
 func (b *jsonRPCSoloBackend) EVMSendTransaction(tx *types.Transaction) error {
+	if !b.Chain.Automine() {
+		// the transaction will be included in the next mined block
+		req, err := isc.NewEVMOffLedgerTxRequest(b.Chain.ChainID, tx)
+		if err != nil {
+			return err
+		}
+		b.Chain.mempool.ReceiveRequests(req)
+		return nil
+	}
 	_, err := b.Chain.PostEthereumTransaction(tx)
 	return err
 }
 
-func (b *jsonRPCSoloBackend) EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) ([]byte, error) {
-	return chainutil.EVMCall(b.Chain, aliasOutput, callMsg)
+func (b *jsonRPCSoloBackend) EVMCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) ([]byte, error) {
+	return chainutil.EVMCall(b.Chain, aliasOutput, callMsg, overrides)
 }
 
-func (b *jsonRPCSoloBackend) EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg) (uint64, error) {
-	return chainutil.EVMEstimateGas(b.Chain, aliasOutput, callMsg)
+func (b *jsonRPCSoloBackend) EVMEstimateGas(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, overrides *isc.EVMCallOverrides) (uint64, error) {
+	return chainutil.EVMEstimateGas(b.Chain, aliasOutput, callMsg, overrides)
+}
+
+func (b *jsonRPCSoloBackend) EVMSimulateCalls(aliasOutput *isc.AliasOutputWithID, blocks []*chainutil.EVMSimulatedBlock) ([]*chainutil.EVMSimulatedBlockResult, error) {
+	return chainutil.EVMSimulateCalls(b.Chain, aliasOutput, blocks)
 }
 
 func (b *jsonRPCSoloBackend) EVMTraceTransaction(
@@ -65,6 +84,25 @@ func (b *jsonRPCSoloBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *jsonRPCSoloBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	newTracer func(txIndex uint64) tracers.Tracer,
+) error {
+	return chainutil.EVMTraceBlock(
+		b.Chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		newTracer,
+	)
+}
+
+func (b *jsonRPCSoloBackend) EVMTraceCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, tracer tracers.Tracer) error {
+	return chainutil.EVMTraceCall(b.Chain, aliasOutput, callMsg, tracer)
+}
+
 func (b *jsonRPCSoloBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return b.Chain.CallViewAtState(chainState, scName, funName, args)
 }
@@ -93,6 +131,10 @@ func (b *jsonRPCSoloBackend) ISCStateByTrieRoot(trieRoot trie.Hash) (state.State
 	return b.Chain.store.StateByTrieRoot(trieRoot)
 }
 
+func (b *jsonRPCSoloBackend) ISCMempoolOffLedgerRequests(ctx context.Context) []*mempool.AccountRequests {
+	return b.Chain.MempoolOffLedgerRequests(ctx)
+}
+
 func (b *jsonRPCSoloBackend) BaseToken() *parameters.BaseToken {
 	return b.baseToken
 }
@@ -115,11 +157,66 @@ func (b *jsonRPCSoloBackend) TakeSnapshot() (int, error) {
 	return len(b.snapshots) - 1, nil
 }
 
+func (b *jsonRPCSoloBackend) EVMMine() error {
+	return b.Chain.EVMMine()
+}
+
+func (b *jsonRPCSoloBackend) EVMSetAutomine(enabled bool) error {
+	b.Chain.SetAutomine(enabled)
+	return nil
+}
+
+func (b *jsonRPCSoloBackend) EVMSetIntervalMining(interval time.Duration) error {
+	if interval < 0 {
+		return errors.New("invalid interval")
+	}
+	b.Chain.SetIntervalMining(interval)
+	return nil
+}
+
+func (b *jsonRPCSoloBackend) EVMIncreaseTime(d time.Duration) (time.Duration, error) {
+	if d < 0 {
+		return 0, errors.New("cannot decrease the time")
+	}
+	if d > 0 {
+		b.Chain.Env.AdvanceClockBy(d)
+		b.timeOffset += d
+	}
+	return b.timeOffset, nil
+}
+
+func (b *jsonRPCSoloBackend) EVMSetNextBlockTimestamp(t time.Time) error {
+	now := b.Chain.Env.GlobalTime()
+	if !t.After(now) {
+		return fmt.Errorf("timestamp %d is lower than or equal to the current time (%d)", t.Unix(), now.Unix())
+	}
+	_, err := b.EVMIncreaseTime(t.Sub(now))
+	return err
+}
+
+func (b *jsonRPCSoloBackend) EVMSetBalance(addr common.Address, balance *big.Int) error {
+	return b.Chain.EVMSetBalance(addr, balance)
+}
+
+func (b *jsonRPCSoloBackend) EVMSetCode(addr common.Address, code []byte) error {
+	return b.Chain.EVMSetCode(addr, code)
+}
+
+func (b *jsonRPCSoloBackend) EVMSetStorageAt(addr common.Address, key, value common.Hash) error {
+	return b.Chain.EVMSetStorageAt(addr, key, value)
+}
+
+func (b *jsonRPCSoloBackend) EVMSendImpersonatedTransaction(callMsg ethereum.CallMsg) (*types.Transaction, error) {
+	return b.Chain.EVMSendImpersonatedTransaction(callMsg)
+}
+
 func (ch *Chain) EVM() *jsonrpc.EVMChain {
 	return jsonrpc.NewEVMChain(
+		ch.Env.ctx,
 		newJSONRPCSoloBackend(ch, parameters.L1().BaseToken),
 		ch.Env.publisher,
 		true,
+		true,
 		hivedb.EngineMapDB,
 		"",
 		ch.log,
@@ -138,13 +235,19 @@ var EthereumAccounts [10]*ecdsa.PrivateKey
 
 func init() {
 	for i := 0; i < len(EthereumAccounts); i++ {
-		seed := crypto.Keccak256([]byte(fmt.Sprintf("seed %d", i)))
-		key, err := crypto.ToECDSA(seed)
-		if err != nil {
-			panic(err)
-		}
-		EthereumAccounts[i] = key
+		EthereumAccounts[i] = EthereumAccountKey(i)
+	}
+}
+
+// EthereumAccountKey returns the deterministic private key of the i-th test
+// account. The first ones are also available in EthereumAccounts.
+func EthereumAccountKey(i int) *ecdsa.PrivateKey {
+	seed := crypto.Keccak256([]byte(fmt.Sprintf("seed %d", i)))
+	key, err := crypto.ToECDSA(seed)
+	if err != nil {
+		panic(err)
 	}
+	return key
 }
 
 func (ch *Chain) EthereumAccountByIndexWithL2Funds(i int, baseTokens ...uint64) (*ecdsa.PrivateKey, common.Address) {
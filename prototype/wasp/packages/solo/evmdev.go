@@ -0,0 +1,133 @@
+package solo
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+)
+
+// EVMDevAddress is the sender of the EVM calls used to produce the blocks
+// of the EVM dev methods (EVMMine, EVMSetCode, etc). Its nonce is
+// incremented on each of these blocks. It also receives the base tokens
+// removed by EVMSetBalance. It is derived from a fixed label, so that it
+// does not collide with the zero address nor with any account with a known
+// private key.
+var EVMDevAddress = common.BytesToAddress(crypto.Keccak256([]byte("wasp-solo-evm-dev")))
+
+// runEVMDevBlock produces a block with a single EVM call, executed in
+// simulation mode: the call is not charged any fees, its sender is not
+// required to sign it, and its changes to the state are kept. If record is
+// true, the call is recorded in the EVM block as an impersonated transaction.
+func (ch *Chain) runEVMDevBlock(call ethereum.CallMsg, overrides *isc.EVMCallOverrides, record bool) (dict.Dict, error) {
+	if call.GasPrice == nil {
+		call.GasPrice = ch.GetGasFeePolicy().GasPriceWei(parameters.L1().BaseToken.Decimals)
+	}
+	results := func() []*vm.RequestResult {
+		ch.runVMMutex.Lock()
+		defer ch.runVMMutex.Unlock()
+
+		task := ch.newVMTask([]isc.Request{isc.NewEVMOffLedgerCallRequest(ch.ChainID, call)}, true)
+		task.EVMCallOverrides = overrides
+		task.SimulationMode = true
+		task.RecordSimulatedCalls = record
+		return ch.settleTaskResultNolock(ch.runVMTaskNoLock(task))
+	}()
+	if len(results) == 0 {
+		return nil, errors.New("request was skipped")
+	}
+	res := results[0]
+	return res.Return, ch.ResolveVMError(res.Receipt.Error).AsGoError()
+}
+
+func (ch *Chain) runEVMDevOverrides(overrides map[common.Address]*isc.EVMAccountOverride) error {
+	_, err := ch.runEVMDevBlock(
+		ethereum.CallMsg{From: EVMDevAddress, To: &EVMDevAddress},
+		&isc.EVMCallOverrides{State: overrides},
+		false,
+	)
+	return err
+}
+
+// EVMMine produces a new block. If automine is disabled, the block includes
+// the requests pending in the mempool (see MinePendingRequests); otherwise, or
+// if there are none, it has no EVM transactions.
+func (ch *Chain) EVMMine() error {
+	if !ch.Automine() {
+		if _, ok := ch.MinePendingRequests(); ok {
+			return nil
+		}
+	}
+	return ch.runEVMDevOverrides(nil)
+}
+
+// EVMSetCode replaces the EVM code of the given account, in a new block.
+func (ch *Chain) EVMSetCode(addr common.Address, code []byte) error {
+	if code == nil {
+		code = []byte{}
+	}
+	return ch.runEVMDevOverrides(map[common.Address]*isc.EVMAccountOverride{
+		addr: {Code: code},
+	})
+}
+
+// EVMSetStorageAt sets the value of a storage slot of the given EVM account,
+// in a new block.
+func (ch *Chain) EVMSetStorageAt(addr common.Address, key, value common.Hash) error {
+	return ch.runEVMDevOverrides(map[common.Address]*isc.EVMAccountOverride{
+		addr: {StateDiff: map[common.Hash]common.Hash{key: value}},
+	})
+}
+
+// EVMSetBalance sets the balance (in wei) of the given EVM account, in a new
+// block. Since the L2 balances are backed by the L1 ledger, the difference is
+// either deposited from the faucet or transferred to EVMDevAddress. Any
+// amount below the precision of the base token is ignored.
+func (ch *Chain) EVMSetBalance(addr common.Address, balance *big.Int) error {
+	decimals := parameters.L1().BaseToken.Decimals
+	agentID := isc.NewEthereumAddressAgentID(ch.ChainID, addr)
+	target := util.EthereumDecimalsToBaseTokenDecimals(balance, decimals)
+	current := ch.L2BaseTokens(agentID)
+	switch {
+	case target > current:
+		wallet, walletAddr := ch.Env.NewKeyPair()
+		_, err := ch.Env.GetFundsFromFaucet(walletAddr, target-current+TransferAllowanceToGasBudgetBaseTokens)
+		if err != nil {
+			return err
+		}
+		return ch.TransferAllowanceTo(isc.NewAssetsBaseTokens(target-current), agentID, wallet)
+	case target < current:
+		if addr == EVMDevAddress {
+			return errors.New("cannot decrease the balance of the dev address")
+		}
+		devBalance := ch.L2BaseTokens(isc.NewEthereumAddressAgentID(ch.ChainID, EVMDevAddress))
+		return ch.runEVMDevOverrides(map[common.Address]*isc.EVMAccountOverride{
+			addr:          {Balance: util.BaseTokensDecimalsToEthereumDecimals(target, decimals)},
+			EVMDevAddress: {Balance: util.BaseTokensDecimalsToEthereumDecimals(devBalance+current-target, decimals)},
+		})
+	}
+	return nil
+}
+
+// EVMSendImpersonatedTransaction executes the given EVM call on behalf of
+// call.From in a new block, without requiring its signature nor charging any
+// fees. The call is recorded in the EVM block as an impersonated transaction,
+// which is returned.
+func (ch *Chain) EVMSendImpersonatedTransaction(call ethereum.CallMsg) (*types.Transaction, error) {
+	ret, err := ch.runEVMDevBlock(call, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return evmtypes.DecodeTransaction(ret[evm.FieldTransaction])
+}
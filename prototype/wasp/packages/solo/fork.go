@@ -0,0 +1,110 @@
+package solo
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+)
+
+// ForkChain creates a chain from a snapshot of an existing chain, in the format
+// of the snapshot files produced by the Wasp nodes (see sm_snapshots). The new
+// chain keeps the ID and the state of the original chain, and its blocks
+// continue from the block of the snapshot.
+//
+// Since the anchor output of the original chain is not in the L1 ledger of
+// Solo, a new anchor output is imported into it, controlled by a Solo state
+// controller. Other outputs owned by the original chain (native token
+// treasuries, foundries and NFTs) are not imported, so any request that needs
+// to consume them will fail. The chain owner is not changed.
+func (env *Solo) ForkChain(name string, chainID isc.ChainID, snapshot io.Reader) *Chain {
+	env.logger.Debugf("forking chain '%s' from a snapshot of %s", name, chainID)
+
+	db, writeMutex, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
+	require.NoError(env.T, err)
+	store := indexedstore.New(state.NewStore(db, writeMutex))
+	snapshotInfo, err := sm_snapshots.ReadSnapshot(store, snapshot)
+	require.NoError(env.T, err)
+	err = store.SetLatest(snapshotInfo.TrieRoot())
+	require.NoError(env.T, err)
+	chainState, err := store.LatestState()
+	require.NoError(env.T, err)
+
+	blockInfo, ok := blocklog.NewStateAccess(chainState).BlockInfo(snapshotInfo.StateIndex())
+	require.True(env.T, ok, "block info of block #%d not found", snapshotInfo.StateIndex())
+	require.NotNil(env.T, blockInfo.PreviousAliasOutput, "cannot fork from the origin block")
+	prevAliasOutput := blockInfo.PreviousAliasOutput
+	require.Equal(env.T, chainID.AsAliasID(), prevAliasOutput.GetAliasID(), "the snapshot belongs to another chain")
+
+	// the blocks of the forked chain must not go back in time
+	if blockInfo.Timestamp.After(env.GlobalTime()) {
+		env.AdvanceClockBy(blockInfo.Timestamp.Sub(env.GlobalTime()))
+	}
+
+	stateControllerKey := env.NewKeyPairFromIndex(-1) // leaving positive indices to user
+	stateControllerAddr := stateControllerKey.GetPublicKey().AsEd25519Address()
+	anchor := &iotago.AliasOutput{
+		AliasID:        chainID.AsAliasID(),
+		StateIndex:     snapshotInfo.StateIndex(),
+		StateMetadata:  forkedStateMetadata(chainState, snapshotInfo.Commitment()),
+		FoundryCounter: prevAliasOutput.GetAliasOutput().FoundryCounter,
+		Conditions: iotago.UnlockConditions{
+			&iotago.StateControllerAddressUnlockCondition{Address: stateControllerAddr},
+			&iotago.GovernorAddressUnlockCondition{Address: stateControllerAddr},
+		},
+		Features: iotago.Features{
+			&iotago.SenderFeature{Address: chainID.AsAddress()},
+		},
+	}
+	anchor.Amount = accounts.GetTotalL2FungibleTokens(subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))).BaseTokens +
+		parameters.L1().Protocol.RentStructure.MinRent(anchor)
+	anchorID := env.utxoDB.ImportOutput(anchor)
+
+	chainOriginator := env.NewKeyPairFromIndex(-1000 + len(env.chains)) // making new originator for each new chain
+	_, err = env.utxoDB.GetFundsFromFaucet(chainOriginator.Address())
+	require.NoError(env.T, err)
+
+	env.chainsMutex.Lock()
+	defer env.chainsMutex.Unlock()
+	ch := env.addChain(chainData{
+		Name:                   name,
+		ChainID:                chainID,
+		StateControllerKeyPair: stateControllerKey,
+		OriginatorPrivateKey:   chainOriginator,
+		ValidatorFeeTarget:     isc.NewAgentID(chainOriginator.Address()),
+		db:                     db,
+		writeMutex:             writeMutex,
+	})
+
+	ch.log.Infof("chain '%s' forked. Chain ID: %s, latest block: #%d, anchor output: %s",
+		ch.Name, ch.ChainID, snapshotInfo.StateIndex(), anchorID.ToHex())
+	return ch
+}
+
+// forkedStateMetadata returns the state metadata of the anchor output of a
+// forked chain, in the same way as the VM does it at the end of each block
+func forkedStateMetadata(chainState kv.KVStoreReader, l1Commitment *state.L1Commitment) []byte {
+	rootState := subrealm.NewReadOnly(chainState, kv.Key(root.Contract.Hname().Bytes()))
+	governanceState := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	// On error, the publicURL is len(0)
+	publicURL, _ := governance.GetPublicURL(governanceState)
+	return transaction.NewStateMetadata(
+		l1Commitment,
+		governance.MustGetGasFeePolicy(governanceState),
+		root.GetSchemaVersion(rootState),
+		publicURL,
+	).Bytes()
+}
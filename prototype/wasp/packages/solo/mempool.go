@@ -7,18 +7,23 @@
 package solo
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/isc"
 )
 
 type Mempool interface {
 	ReceiveRequests(reqs ...isc.Request)
 	RequestBatchProposal() []isc.Request
-	RemoveRequest(reqs isc.RequestID)
+	RemoveRequest(reqs isc.RequestID) bool
 	Info() MempoolInfo
+	OffLedgerRequests() []*mempool.AccountRequests
+	Requests(after uint64, limit int) *mempool.RequestsPage
 }
 
 type MempoolInfo struct {
@@ -29,6 +34,7 @@ type MempoolInfo struct {
 
 type mempoolImpl struct {
 	requests    map[isc.RequestID]isc.Request
+	index       *mempool.RequestIndex
 	info        MempoolInfo
 	currentTime func() time.Time
 	chainID     isc.ChainID
@@ -38,6 +44,7 @@ type mempoolImpl struct {
 func newMempool(currentTime func() time.Time, chainID isc.ChainID) Mempool {
 	return &mempoolImpl{
 		requests:    map[isc.RequestID]isc.Request{},
+		index:       mempool.NewRequestIndex(),
 		info:        MempoolInfo{},
 		currentTime: currentTime,
 		chainID:     chainID,
@@ -55,6 +62,7 @@ func (mi *mempoolImpl) ReceiveRequests(reqs ...isc.Request) {
 		if _, ok := mi.requests[req.ID()]; !ok {
 			mi.info.TotalPool++
 			mi.info.InPoolCounter++
+			mi.poolIndex(req).Added(req, mi.currentTime())
 		}
 		mi.requests[req.ID()] = req
 	}
@@ -74,6 +82,7 @@ func (mi *mempoolImpl) RequestBatchProposal() []isc.Request {
 			if expiration != nil && timeLock.UnixTime >= expiration.UnixTime {
 				// can never be processed, just reject
 				delete(mi.requests, rid)
+				mi.poolIndex(request).Removed(request)
 				continue
 			}
 			if timeLock == nil || timeLock.UnixTime <= uint32(now.Unix()) {
@@ -89,16 +98,72 @@ func (mi *mempoolImpl) RequestBatchProposal() []isc.Request {
 	return batch
 }
 
-func (mi *mempoolImpl) RemoveRequest(rID isc.RequestID) {
+func (mi *mempoolImpl) RemoveRequest(rID isc.RequestID) bool {
 	mi.mu.Lock()
 	defer mi.mu.Unlock()
-	if _, ok := mi.requests[rID]; ok {
+	request, ok := mi.requests[rID]
+	if ok {
 		mi.info.OutPoolCounter++
 		mi.info.TotalPool--
+		mi.poolIndex(request).Removed(request)
 	}
 	delete(mi.requests, rID)
+	return ok
 }
 
 func (mi *mempoolImpl) Info() MempoolInfo {
 	return mi.info
 }
+
+// OffLedgerRequests returns the off-ledger requests grouped by the sender.
+// All of them are reported as pending, because solo proposes all the
+// off-ledger requests in the next batch regardless of their nonces.
+func (mi *mempoolImpl) OffLedgerRequests() []*mempool.AccountRequests {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	byAccount := map[string]*mempool.AccountRequests{}
+	ret := []*mempool.AccountRequests{}
+	for _, request := range mi.requests {
+		offLedgerReq, ok := request.(isc.OffLedgerRequest)
+		if !ok {
+			continue
+		}
+		key := offLedgerReq.SenderAccount().String()
+		accountReqs, ok := byAccount[key]
+		if !ok {
+			accountReqs = &mempool.AccountRequests{
+				Account: offLedgerReq.SenderAccount(),
+				Pending: []isc.OffLedgerRequest{},
+				Queued:  []isc.OffLedgerRequest{},
+			}
+			byAccount[key] = accountReqs
+			ret = append(ret, accountReqs)
+		}
+		accountReqs.Pending = append(accountReqs.Pending, offLedgerReq)
+	}
+	for _, accountReqs := range ret {
+		slices.SortFunc(accountReqs.Pending, func(a, b isc.OffLedgerRequest) int {
+			return cmp.Compare(a.Nonce(), b.Nonce())
+		})
+	}
+	return ret
+}
+
+// Requests returns a page of the requests in the mempool. Solo has no
+// separate pool for the time-locked requests, they are reported as on-ledger
+// ones.
+func (mi *mempoolImpl) Requests(after uint64, limit int) *mempool.RequestsPage {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	return &mempool.RequestsPage{
+		Requests:  mi.index.Page(after, limit),
+		PoolSizes: mi.index.PoolSizes(),
+	}
+}
+
+func (mi *mempoolImpl) poolIndex(request isc.Request) mempool.PoolIndex {
+	if request.IsOffLedger() {
+		return mi.index.ForPool(mempool.PoolOffLedger)
+	}
+	return mi.index.ForPool(mempool.PoolOnLedger)
+}
@@ -0,0 +1,109 @@
+package solo
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm"
+)
+
+// miningConfig determines when the requests in the mempool are processed.
+//
+// By default (automine), the requests added to the mempool are processed by
+// the batch loop as soon as possible, and the EVM transactions sent through
+// the JSON-RPC backend are run synchronously, each one in its own block. With
+// automine disabled, the requests are kept in the mempool until a block is
+// produced with MinePendingRequests, either explicitly or at a fixed interval
+// (see SetIntervalMining), in order to reproduce the batching of a committee.
+type miningConfig struct {
+	mutex      sync.Mutex
+	noAutomine bool
+	// stopInterval stops the interval mining goroutine, if running
+	stopInterval context.CancelFunc
+}
+
+// Automine returns whether automine is enabled (see SetAutomine).
+func (ch *Chain) Automine() bool {
+	ch.mining.mutex.Lock()
+	defer ch.mining.mutex.Unlock()
+	return !ch.mining.noAutomine
+}
+
+// SetAutomine enables or disables automine, which is enabled by default. When
+// disabled, the requests in the mempool are only processed by
+// MinePendingRequests, which can be called periodically with
+// SetIntervalMining.
+func (ch *Chain) SetAutomine(enabled bool) {
+	ch.mining.mutex.Lock()
+	defer ch.mining.mutex.Unlock()
+	ch.mining.noAutomine = !enabled
+}
+
+// SetIntervalMining calls MinePendingRequests at the given interval, so that
+// all requests received during the interval are processed in the same block.
+// An interval of 0 stops it. It is meant to be used with automine disabled.
+func (ch *Chain) SetIntervalMining(interval time.Duration) {
+	ch.mining.mutex.Lock()
+	defer ch.mining.mutex.Unlock()
+
+	if ch.mining.stopInterval != nil {
+		ch.mining.stopInterval()
+		ch.mining.stopInterval = nil
+	}
+	if interval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(ch.Env.ctx)
+	ch.mining.stopInterval = cancel
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ch.MinePendingRequests()
+			}
+		}
+	}()
+}
+
+// MinePendingRequests produces a block with the requests pending in the
+// mempool. The off-ledger requests are ordered by nonce, so that several
+// requests of the same sender can be included in the same block. Requests
+// skipped by the VM (e.g. when the gas limit of the block is reached) are kept
+// in the mempool. The returned boolean is false if no block was produced
+// because the mempool was empty.
+func (ch *Chain) MinePendingRequests() ([]*vm.RequestResult, bool) {
+	ch.runVMMutex.Lock()
+	defer ch.runVMMutex.Unlock()
+	if ch.Env.ctx.Err() != nil {
+		return nil, false
+	}
+	requests := ch.mempool.RequestBatchProposal()
+	if len(requests) == 0 {
+		return nil, false
+	}
+	slices.SortFunc(requests, func(a, b isc.Request) int {
+		return cmp.Compare(requestNonce(a), requestNonce(b))
+	})
+	results := ch.runRequestsNolock(requests, "mine")
+	for _, res := range results {
+		if res.Receipt.Error != nil {
+			ch.log.Errorf("MinePendingRequests: %v", res.Receipt.Error)
+		}
+	}
+	return results, true
+}
+
+func requestNonce(req isc.Request) uint64 {
+	if offLedgerReq, ok := req.(isc.OffLedgerRequest); ok {
+		return offLedgerReq.Nonce()
+	}
+	return 0
+}
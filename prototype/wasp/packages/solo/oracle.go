@@ -0,0 +1,69 @@
+package solo
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/crypto/bls"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+)
+
+// oracleConfig mimics the oracle feeds of a committee. The values are signed
+// with a BLS key of the chain, in place of the threshold key of the committee.
+type oracleConfig struct {
+	mutex  sync.Mutex
+	key    *bls.PrivateKey
+	values isc.OracleValues
+}
+
+// SetOracleValues sets the values of the oracle feeds agreed by the
+// "committee", which are saved in the oracle core contract in each block
+// produced from now on, until the values are changed again. Passing nil stops
+// the oracle.
+func (ch *Chain) SetOracleValues(values isc.OracleValues) {
+	require.NoError(ch.Env.T, values.Validate())
+	ch.oracle.mutex.Lock()
+	defer ch.oracle.mutex.Unlock()
+	ch.oracle.values = values
+}
+
+// OraclePublicKey returns the BLS public key used to sign the oracle values.
+func (ch *Chain) OraclePublicKey() bls.PublicKey {
+	ch.oracle.mutex.Lock()
+	defer ch.oracle.mutex.Unlock()
+	return ch.oracleKeyNoLock().PublicKey()
+}
+
+// GetOracleValue returns the latest value of an oracle feed, as stored in
+// the oracle core contract.
+func (ch *Chain) GetOracleValue(feed string) (*oracle.Value, error) {
+	ret, err := ch.CallView(oracle.Contract.Name, oracle.ViewGetValue.Name, oracle.ParamFeed, feed)
+	if err != nil {
+		return nil, err
+	}
+	return oracle.ValueFromBytes(ret.Get(oracle.ParamValue))
+}
+
+func (ch *Chain) oracleKeyNoLock() *bls.PrivateKey {
+	if ch.oracle.key == nil {
+		key := bls.PrivateKeyFromRandomness()
+		ch.oracle.key = &key
+	}
+	return ch.oracle.key
+}
+
+// oracleData returns the signed oracle values for a block built on top of
+// the given anchor output, or nil if no values are set.
+func (ch *Chain) oracleData(anchorOutputID iotago.OutputID) *isc.OracleData {
+	ch.oracle.mutex.Lock()
+	defer ch.oracle.mutex.Unlock()
+	if len(ch.oracle.values) == 0 {
+		return nil
+	}
+	sig, err := ch.oracleKeyNoLock().Sign(isc.OracleSigningMessage(anchorOutputID, ch.oracle.values))
+	require.NoError(ch.Env.T, err)
+	return isc.NewOracleData(anchorOutputID, ch.oracle.values, sig.Bytes())
+}
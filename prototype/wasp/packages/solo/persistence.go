@@ -0,0 +1,189 @@
+package solo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/database"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// In a persistent Solo environment (see InitOptions.DBPath), the state of each
+// chain is stored in its own chain state DB, while the L1 ledger and the chain
+// records are stored in the Solo DB with the following keys:
+const (
+	// dbPrefixChain + chainID -> ChainSnapshot (without the DB contents)
+	dbPrefixChain = 'c'
+	// dbPrefixTransaction + txID -> L1 transaction
+	dbPrefixTransaction = 't'
+	// dbPrefixUnspent + outputID -> empty, for each unspent output of the L1 ledger
+	dbPrefixUnspent = 'u'
+	// dbKeyGlobalTime -> logical time of the L1 ledger
+	dbKeyGlobalTime = 'g'
+)
+
+// openDB opens (or creates) the persistent environment stored in the given
+// directory, and restores the L1 ledger and the chains from it.
+func (env *Solo) openDB(dir string) {
+	db, err := database.DatabaseWithDefaultSettings(filepath.Join(dir, "solo"), true, hivedb.EngineRocksDB, false, database.AllowedEnginesStorage...)
+	require.NoError(env.T, err)
+	env.db = db.KVStore()
+
+	env.restoreLedger()
+	env.utxoDB.OnTransactionAdded(env.saveL1Transaction)
+	env.restoreChains()
+
+	env.T.Cleanup(env.closeDB)
+}
+
+func (env *Solo) closeDB() {
+	// the context is already cancelled; wait for the batch loop to finish
+	// running any pending requests
+	env.chainsMutex.RLock()
+	chains := lo.Values(env.chains)
+	env.chainsMutex.RUnlock()
+	for _, ch := range chains {
+		ch.runVMMutex.Lock()
+		//nolint:staticcheck // empty critical section
+		ch.runVMMutex.Unlock()
+	}
+
+	err := env.db.Set([]byte{dbKeyGlobalTime}, codec.EncodeTime(env.GlobalTime()))
+	require.NoError(env.T, err)
+	err = env.chainStateDatabaseManager.FlushAndCloseStores()
+	require.NoError(env.T, err)
+	err = env.db.Flush()
+	require.NoError(env.T, err)
+	err = env.db.Close()
+	require.NoError(env.T, err)
+}
+
+func (env *Solo) restoreLedger() {
+	globalTimeBytes, err := env.db.Get([]byte{dbKeyGlobalTime})
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		// new DB
+		env.saveLedgerState(env.utxoDB.State())
+		return
+	}
+	require.NoError(env.T, err)
+
+	state := env.utxoDB.State()
+	state.GlobalLogicalTime, err = codec.DecodeTime(globalTimeBytes)
+	require.NoError(env.T, err)
+	state.Transactions = make(map[string]*iotago.Transaction)
+	err = env.db.Iterate(kvstore.KeyPrefix{dbPrefixTransaction}, func(k kvstore.Key, v kvstore.Value) bool {
+		tx := new(iotago.Transaction)
+		rwutil.NewBytesReader(v).ReadSerialized(tx)
+		state.Transactions[hex.EncodeToString(k[1:])] = tx
+		return true
+	})
+	require.NoError(env.T, err)
+	state.UTXO = nil
+	err = env.db.IterateKeys(kvstore.KeyPrefix{dbPrefixUnspent}, func(k kvstore.Key) bool {
+		state.UTXO = append(state.UTXO, hex.EncodeToString(k[1:]))
+		return true
+	})
+	require.NoError(env.T, err)
+	env.utxoDB.SetState(state)
+}
+
+// saveLedgerState replaces the L1 ledger stored in the DB
+func (env *Solo) saveLedgerState(state *utxodb.UtxoDBState) {
+	err := env.db.DeletePrefix(kvstore.KeyPrefix{dbPrefixTransaction})
+	require.NoError(env.T, err)
+	err = env.db.DeletePrefix(kvstore.KeyPrefix{dbPrefixUnspent})
+	require.NoError(env.T, err)
+
+	batch, err := env.db.Batched()
+	require.NoError(env.T, err)
+	for txID, tx := range state.Transactions {
+		b, err2 := hex.DecodeString(txID)
+		require.NoError(env.T, err2)
+		err = batch.Set(append([]byte{dbPrefixTransaction}, b...), rwutil.NewBytesWriter().WriteSerialized(tx).Bytes())
+		require.NoError(env.T, err)
+	}
+	for _, outputID := range state.UTXO {
+		b, err2 := hex.DecodeString(outputID)
+		require.NoError(env.T, err2)
+		err = batch.Set(append([]byte{dbPrefixUnspent}, b...), []byte{})
+		require.NoError(env.T, err)
+	}
+	err = batch.Set([]byte{dbKeyGlobalTime}, codec.EncodeTime(state.GlobalLogicalTime))
+	require.NoError(env.T, err)
+	err = batch.Commit()
+	require.NoError(env.T, err)
+}
+
+// saveL1Transaction is called by the UtxoDB each time a transaction is added to the L1 ledger
+func (env *Solo) saveL1Transaction(tx *iotago.Transaction, globalTime time.Time) {
+	txID, err := tx.ID()
+	require.NoError(env.T, err)
+
+	batch, err := env.db.Batched()
+	require.NoError(env.T, err)
+	err = batch.Set(append([]byte{dbPrefixTransaction}, txID[:]...), rwutil.NewBytesWriter().WriteSerialized(tx).Bytes())
+	require.NoError(env.T, err)
+	for _, input := range tx.Essence.Inputs {
+		if utxoInput, ok := input.(*iotago.UTXOInput); ok {
+			outputID := utxoInput.ID()
+			err = batch.Delete(append([]byte{dbPrefixUnspent}, outputID[:]...))
+			require.NoError(env.T, err)
+		}
+	}
+	for i := range tx.Essence.Outputs {
+		outputID := iotago.OutputIDFromTransactionIDAndIndex(txID, uint16(i))
+		err = batch.Set(append([]byte{dbPrefixUnspent}, outputID[:]...), []byte{})
+		require.NoError(env.T, err)
+	}
+	err = batch.Set([]byte{dbKeyGlobalTime}, codec.EncodeTime(globalTime))
+	require.NoError(env.T, err)
+	err = batch.Commit()
+	require.NoError(env.T, err)
+}
+
+func (env *Solo) saveChainData(chData *chainData) {
+	b, err := json.Marshal(chData.snapshot())
+	require.NoError(env.T, err)
+	err = env.db.Set(append([]byte{dbPrefixChain}, chData.ChainID.Bytes()...), b)
+	require.NoError(env.T, err)
+}
+
+func (env *Solo) restoreChains() {
+	var chainSnapshots []*ChainSnapshot
+	err := env.db.Iterate(kvstore.KeyPrefix{dbPrefixChain}, func(_ kvstore.Key, v kvstore.Value) bool {
+		chainSnapshot := &ChainSnapshot{}
+		err := json.Unmarshal(v, chainSnapshot)
+		require.NoError(env.T, err)
+		chainSnapshots = append(chainSnapshots, chainSnapshot)
+		return true
+	})
+	require.NoError(env.T, err)
+
+	for _, chainSnapshot := range chainSnapshots {
+		ch := func() *Chain {
+			env.chainsMutex.Lock()
+			defer env.chainsMutex.Unlock()
+			return env.addChain(env.chainDataFromSnapshot(chainSnapshot))
+		}()
+		// if the environment was not closed properly, the latest block may not
+		// have been added to the L1 ledger; in that case it is discarded
+		l1Commitment := ch.GetL1Commitment()
+		if ch.GetRootCommitment() != l1Commitment.TrieRoot() {
+			ch.log.Warnf("discarding the latest block, which is not in the L1 ledger")
+			err = ch.store.SetLatest(l1Commitment.TrieRoot())
+			require.NoError(env.T, err)
+		}
+		ch.log.Infof("chain '%s' restored. Chain ID: %s, latest block: #%d", ch.Name, ch.ChainID, ch.LatestBlockIndex())
+	}
+}
@@ -36,6 +36,8 @@ type CallParams struct {
 	nft        *isc.NFT
 	allowance  *isc.Assets
 	gasBudget  uint64
+	maxFee     uint64 // ignored for on-ledger
+	tip        uint64 // ignored for on-ledger
 	nonce      uint64 // ignored for on-ledger
 	params     dict.Dict
 	sender     iotago.Address
@@ -171,6 +173,13 @@ func (r *CallParams) WithMaxAffordableGasBudget() *CallParams {
 	return r
 }
 
+// WithMaxFee sets the max fee and the tip of the off-ledger request
+func (r *CallParams) WithMaxFee(maxFee, tip uint64) *CallParams {
+	r.maxFee = maxFee
+	r.tip = tip
+	return r
+}
+
 func (r *CallParams) WithNonce(nonce uint64) *CallParams {
 	r.nonce = nonce
 	return r
@@ -187,7 +196,8 @@ func (r *CallParams) NewRequestOffLedger(ch *Chain, keyPair *cryptolib.KeyPair)
 		r.nonce = ch.Nonce(isc.NewAgentID(keyPair.Address()))
 	}
 	ret := isc.NewOffLedgerRequest(ch.ID(), r.target, r.entryPoint, r.params, r.nonce, r.gasBudget).
-		WithAllowance(r.allowance)
+		WithAllowance(r.allowance).
+		WithMaxFee(r.maxFee, r.tip)
 	return ret.Sign(keyPair)
 }
 
@@ -55,8 +55,16 @@ func (ch *Chain) estimateGas(req isc.Request) (result *vm.RequestResult) {
 }
 
 func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskResult {
+	return ch.runVMTaskNoLock(ch.newVMTask(reqs, estimateGas))
+}
+
+func (ch *Chain) newVMTask(reqs []isc.Request, estimateGas bool) *vm.VMTask {
 	anchorOutput := ch.GetAnchorOutputFromL1()
-	task := &vm.VMTask{
+	var oracleData *isc.OracleData
+	if !estimateGas {
+		oracleData = ch.oracleData(anchorOutput.OutputID())
+	}
+	return &vm.VMTask{
 		Processors:         ch.proc,
 		AnchorOutput:       anchorOutput.GetAliasOutput(),
 		AnchorOutputID:     anchorOutput.OutputID(),
@@ -65,13 +73,16 @@ func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskR
 		Store:              ch.store,
 		Entropy:            hashing.PseudoRandomHash(nil),
 		ValidatorFeeTarget: ch.ValidatorFeeTarget,
+		OracleData:         oracleData,
 		Log:                ch.Log().Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar(),
 		// state baseline is always valid in Solo
 		EnableGasBurnLogging: ch.Env.enableGasBurnLogging,
 		EstimateGasMode:      estimateGas,
 		MigrationsOverride:   ch.migrationScheme,
 	}
+}
 
+func (ch *Chain) runVMTaskNoLock(task *vm.VMTask) *vm.VMTaskResult {
 	res, err := vmimpl.Run(task)
 	require.NoError(ch.Env.T, err)
 	accounts.CheckLedger(res.StateDraft, "solo")
@@ -81,8 +92,12 @@ func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskR
 func (ch *Chain) runRequestsNolock(reqs []isc.Request, trace string) (results []*vm.RequestResult) {
 	ch.Log().Debugf("runRequestsNolock ('%s')", trace)
 
-	res := ch.runTaskNoLock(reqs, false)
+	return ch.settleTaskResultNolock(ch.runTaskNoLock(reqs, false))
+}
 
+// settleTaskResultNolock signs the anchor transaction produced by the VM,
+// commits the block and adds the transaction to the L1 ledger.
+func (ch *Chain) settleTaskResultNolock(res *vm.VMTaskResult) []*vm.RequestResult {
 	var essence *iotago.TransactionEssence
 	if res.RotationAddress == nil {
 		essence = res.TransactionEssence
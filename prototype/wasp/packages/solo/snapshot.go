@@ -3,7 +3,6 @@ package solo
 import (
 	"encoding/json"
 	"os"
-	"sync"
 
 	"github.com/stretchr/testify/require"
 
@@ -38,14 +37,7 @@ func (env *Solo) TakeSnapshot() *Snapshot {
 	}
 
 	for _, ch := range env.chains {
-		chainSnapshot := &ChainSnapshot{
-			Name:                   ch.Name,
-			StateControllerKeyPair: rwutil.WriteToBytes(ch.StateControllerKeyPair),
-			ChainID:                ch.ChainID.Bytes(),
-			OriginatorPrivateKey:   rwutil.WriteToBytes(ch.OriginatorPrivateKey),
-			ValidatorFeeTarget:     ch.ValidatorFeeTarget.Bytes(),
-		}
-
+		chainSnapshot := ch.chainData.snapshot()
 		err := ch.db.Iterate(kvstore.EmptyPrefix, func(k, v []byte) bool {
 			chainSnapshot.DB = append(chainSnapshot.DB, k, v)
 			return true
@@ -64,36 +56,56 @@ func (env *Solo) RestoreSnapshot(snapshot *Snapshot) {
 	defer env.chainsMutex.Unlock()
 
 	env.utxoDB.SetState(snapshot.UtxoDB)
+	if env.db != nil {
+		env.saveLedgerState(snapshot.UtxoDB)
+	}
 	for _, chainSnapshot := range snapshot.Chains {
-		sckp, err := rwutil.ReadFromBytes(chainSnapshot.StateControllerKeyPair, new(cryptolib.KeyPair))
-		require.NoError(env.T, err)
+		chainData := env.chainDataFromSnapshot(chainSnapshot)
+		for i := 0; i < len(chainSnapshot.DB); i += 2 {
+			err := chainData.db.Set(chainSnapshot.DB[i], chainSnapshot.DB[i+1])
+			require.NoError(env.T, err)
+		}
+		env.addChain(chainData)
+	}
+}
 
-		chainID, err := isc.ChainIDFromBytes(chainSnapshot.ChainID)
-		require.NoError(env.T, err)
+// snapshot returns the ChainSnapshot of the chain, without the contents of the DB
+func (ch *chainData) snapshot() *ChainSnapshot {
+	return &ChainSnapshot{
+		Name:                   ch.Name,
+		StateControllerKeyPair: rwutil.WriteToBytes(ch.StateControllerKeyPair),
+		ChainID:                ch.ChainID.Bytes(),
+		OriginatorPrivateKey:   rwutil.WriteToBytes(ch.OriginatorPrivateKey),
+		ValidatorFeeTarget:     ch.ValidatorFeeTarget.Bytes(),
+	}
+}
 
-		okp, err := rwutil.ReadFromBytes(chainSnapshot.OriginatorPrivateKey, new(cryptolib.KeyPair))
-		require.NoError(env.T, err)
+// chainDataFromSnapshot decodes the chain data from the snapshot and opens the chain DB
+// (the contents of the DB in the snapshot are ignored)
+func (env *Solo) chainDataFromSnapshot(chainSnapshot *ChainSnapshot) chainData {
+	sckp, err := rwutil.ReadFromBytes(chainSnapshot.StateControllerKeyPair, new(cryptolib.KeyPair))
+	require.NoError(env.T, err)
 
-		val, err := isc.AgentIDFromBytes(chainSnapshot.ValidatorFeeTarget)
-		require.NoError(env.T, err)
+	chainID, err := isc.ChainIDFromBytes(chainSnapshot.ChainID)
+	require.NoError(env.T, err)
 
-		db, _, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
-		require.NoError(env.T, err)
-		for i := 0; i < len(chainSnapshot.DB); i += 2 {
-			err = db.Set(chainSnapshot.DB[i], chainSnapshot.DB[i+1])
-			require.NoError(env.T, err)
-		}
+	okp, err := rwutil.ReadFromBytes(chainSnapshot.OriginatorPrivateKey, new(cryptolib.KeyPair))
+	require.NoError(env.T, err)
 
-		chainData := chainData{
-			Name:                   chainSnapshot.Name,
-			StateControllerKeyPair: sckp,
-			ChainID:                chainID,
-			OriginatorPrivateKey:   okp,
-			ValidatorFeeTarget:     val,
-			db:                     db,
-			writeMutex:             &sync.Mutex{},
-		}
-		env.addChain(chainData)
+	val, err := isc.AgentIDFromBytes(chainSnapshot.ValidatorFeeTarget)
+	require.NoError(env.T, err)
+
+	db, writeMutex, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
+	require.NoError(env.T, err)
+
+	return chainData{
+		Name:                   chainSnapshot.Name,
+		StateControllerKeyPair: sckp,
+		ChainID:                chainID,
+		OriginatorPrivateKey:   okp,
+		ValidatorFeeTarget:     val,
+		db:                     db,
+		writeMutex:             writeMutex,
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -18,11 +19,13 @@ import (
 	"github.com/iotaledger/hive.go/kvstore"
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
 	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/options"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/database"
 	"github.com/iotaledger/wasp/packages/evm/evmlogger"
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
@@ -70,6 +73,8 @@ type Solo struct {
 	seed                            cryptolib.Seed
 	publisher                       *publisher.Publisher
 	ctx                             context.Context
+	// db stores the L1 ledger and the chain records, if the environment is persistent
+	db kvstore.KVStore
 }
 
 // data to be persisted in the snapshot
@@ -117,6 +122,10 @@ type Chain struct {
 	runVMMutex sync.Mutex
 	// mempool of the chain is used in Solo to mimic a real node
 	mempool Mempool
+	// mining determines when the requests in the mempool are processed
+	mining miningConfig
+	// oracle holds the values of the oracle feeds to be saved in each block
+	oracle oracleConfig
 
 	RequestsBlock uint32
 
@@ -134,6 +143,9 @@ type InitOptions struct {
 	GasBurnLogEnabled        bool
 	Seed                     cryptolib.Seed
 	Log                      *logger.Logger
+	// If DBPath is set, the environment is stored on RocksDB in the given directory,
+	// and restored from it if it already exists.
+	DBPath string
 }
 
 func DefaultInitOptions() *InitOptions {
@@ -161,18 +173,26 @@ func New(t Context, initOptions ...*InitOptions) *Solo {
 		}
 	}
 	evmlogger.Init(opt.Log)
+	// the EVM dev methods record impersonated transactions (see evmdev.go)
+	evmutil.EnableImpersonatedSenders()
 
 	chainRecordRegistryProvider, err := registry.NewChainRecordRegistryImpl("")
 	require.NoError(t, err)
 
-	chainStateDatabaseManager, err := database.NewChainStateDatabaseManager(chainRecordRegistryProvider, database.WithEngine(hivedb.EngineMapDB))
+	dbOptions := []options.Option[database.ChainStateDatabaseManager]{database.WithEngine(hivedb.EngineMapDB)}
+	if opt.DBPath != "" {
+		dbOptions = []options.Option[database.ChainStateDatabaseManager]{
+			database.WithEngine(hivedb.EngineRocksDB),
+			database.WithPath(filepath.Join(opt.DBPath, "chains")),
+		}
+	}
+	chainStateDatabaseManager, err := database.NewChainStateDatabaseManager(chainRecordRegistryProvider, dbOptions...)
 	if err != nil {
 		panic(err)
 	}
 
 	utxoDBinitParams := utxodb.DefaultInitParams()
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	t.Cleanup(cancelCtx)
 	ret := &Solo{
 		T:                               t,
 		logger:                          opt.Log,
@@ -186,6 +206,11 @@ func New(t Context, initOptions ...*InitOptions) *Solo {
 		publisher:                       publisher.New(opt.Log.Named("publisher")),
 		ctx:                             ctx,
 	}
+	if opt.DBPath != "" {
+		ret.openDB(opt.DBPath)
+	}
+	// the context is cancelled before the DB is closed
+	t.Cleanup(cancelCtx)
 	globalTime := ret.utxoDB.GlobalTime()
 	ret.logger.Infof("Solo environment has been created: logical time: %v, time step: %v",
 		globalTime.Format(timeLayout), ret.utxoDB.TimeStep())
@@ -215,7 +240,9 @@ func (env *Solo) batchLoop() {
 			return lo.Values(env.chains)
 		}()
 		for _, ch := range chains {
-			ch.collateAndRunBatch()
+			if ch.Automine() {
+				ch.collateAndRunBatch()
+			}
 		}
 	}
 }
@@ -233,14 +260,23 @@ func (env *Solo) Publisher() *publisher.Publisher {
 }
 
 func (env *Solo) GetChainByName(name string) *Chain {
+	ch, ok := env.ChainByName(name)
+	if !ok {
+		panic("chain not found")
+	}
+	return ch
+}
+
+// ChainByName returns the chain with the given name, if it exists
+func (env *Solo) ChainByName(name string) (*Chain, bool) {
 	env.chainsMutex.Lock()
 	defer env.chainsMutex.Unlock()
 	for _, ch := range env.chains {
 		if ch.Name == name {
-			return ch
+			return ch, true
 		}
 	}
-	panic("chain not found")
+	return nil, false
 }
 
 // WithNativeContract registers a native contract so that it may be deployed
@@ -387,6 +423,9 @@ func (env *Solo) addChain(chData chainData) *Chain {
 		migrationScheme:        allmigrations.DefaultScheme,
 	}
 	env.chains[chData.ChainID] = ch
+	if env.db != nil {
+		env.saveChainData(&chData)
+	}
 	return ch
 }
 
@@ -0,0 +1,46 @@
+package solo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/solo"
+)
+
+func TestForkChain(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+	_, ethAddr := ch.EthereumAccountByIndexWithL2Funds(0)
+	ethAgentID := isc.NewEthereumAddressAgentID(ch.ChainID, ethAddr)
+	ethBalance := ch.L2BaseTokens(ethAgentID)
+	require.NotZero(t, ethBalance)
+
+	blockIndex := ch.LatestBlockIndex()
+	var snapshot bytes.Buffer
+	err := sm_snapshots.WriteSnapshot(
+		sm_snapshots.NewSnapshotInfo(blockIndex, ch.GetL1Commitment()),
+		ch.Store(),
+		&snapshot,
+	)
+	require.NoError(t, err)
+
+	forkEnv := solo.New(t)
+	fork := forkEnv.ForkChain("fork", ch.ChainID, &snapshot)
+	require.Equal(t, ch.ChainID, fork.ChainID)
+	require.Equal(t, blockIndex, fork.LatestBlockIndex())
+	require.Equal(t, ethBalance, fork.L2BaseTokens(ethAgentID))
+	require.Equal(t, ch.EVM().BlockNumber(), fork.EVM().BlockNumber())
+	require.False(t, forkEnv.GlobalTime().Before(ch.GetLatestBlockInfo().Timestamp))
+
+	// the forked chain keeps producing blocks
+	fork.MustDepositBaseTokensToL2(isc.Million, fork.OriginatorPrivateKey)
+	require.Equal(t, blockIndex+1, fork.LatestBlockIndex())
+	require.Equal(t, ethBalance, fork.L2BaseTokens(ethAgentID))
+
+	// the original chain is not affected
+	require.Equal(t, blockIndex, ch.LatestBlockIndex())
+}
@@ -0,0 +1,59 @@
+//go:build rocksdb
+
+package solo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
+)
+
+func TestPersistentDB(t *testing.T) {
+	dbPath := t.TempDir()
+
+	var (
+		chainID     isc.ChainID
+		blockIndex  uint32
+		globalTime  time.Time
+		ethAgentID  isc.AgentID
+		ethBalance  uint64
+		userAddress iotago.Address
+	)
+	t.Run("create", func(t *testing.T) {
+		env := solo.New(t, &solo.InitOptions{DBPath: dbPath})
+		ch := env.NewChain()
+		_, ethAddr := ch.EthereumAccountByIndexWithL2Funds(0)
+		ethAgentID = isc.NewEthereumAddressAgentID(ch.ChainID, ethAddr)
+		_, userAddress = env.NewKeyPairWithFunds()
+
+		chainID = ch.ChainID
+		blockIndex = ch.LatestBlockIndex()
+		globalTime = env.GlobalTime()
+		ethBalance = ch.L2BaseTokens(ethAgentID)
+		require.NotZero(t, ethBalance)
+	})
+	t.Run("restore", func(t *testing.T) {
+		env := solo.New(t, &solo.InitOptions{DBPath: dbPath})
+		ch := env.GetChainByName("chain1")
+		require.Equal(t, chainID, ch.ChainID)
+		require.Equal(t, blockIndex, ch.LatestBlockIndex())
+		require.Equal(t, globalTime, env.GlobalTime())
+		require.Equal(t, ethBalance, ch.L2BaseTokens(ethAgentID))
+		env.AssertL1BaseTokens(userAddress, utxodb.FundsFromFaucetAmount)
+
+		// the chain keeps producing blocks
+		ch.MustDepositBaseTokensToL2(isc.Million, ch.OriginatorPrivateKey)
+		require.Equal(t, blockIndex+1, ch.LatestBlockIndex())
+	})
+	t.Run("restore again", func(t *testing.T) {
+		env := solo.New(t, &solo.InitOptions{DBPath: dbPath})
+		ch := env.GetChainByName("chain1")
+		require.Equal(t, blockIndex+1, ch.LatestBlockIndex())
+	})
+}
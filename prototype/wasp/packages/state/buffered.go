@@ -4,6 +4,9 @@
 package state
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/buffered"
@@ -25,8 +28,19 @@ func newBufferedKVStore(db kvstore.KVStore) *bufferedKVStore {
 	}
 }
 
-func (*bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
-	panic("should no be called")
+// NewBufferedStore returns a Store that reads from the given one, but keeps all
+// writes in memory, so that the backing store is never modified. It allows to
+// commit (e.g. simulated) blocks on top of the chain state and discard them later.
+func NewBufferedStore(s Store) Store {
+	base, ok := s.(*store)
+	if !ok {
+		panic(fmt.Sprintf("cannot create buffered store from %T", s))
+	}
+	return NewStore(newBufferedKVStore(base.db), new(sync.Mutex))
+}
+
+func (b *bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
+	return &bufferedBatch{b: b, muts: buffered.NewMutations()}, nil
 }
 
 func (*bufferedKVStore) Clear() error {
@@ -90,3 +104,36 @@ func (*bufferedKVStore) WithRealm(realm []byte) (kvstore.KVStore, error) {
 func (*bufferedKVStore) WithExtendedRealm(realm []byte) (kvstore.KVStore, error) {
 	panic("should no be called")
 }
+
+// bufferedBatch collects mutations that are applied to the bufferedKVStore's
+// in-memory cache on Commit.
+type bufferedBatch struct {
+	b    *bufferedKVStore
+	muts *buffered.Mutations
+}
+
+var _ kvstore.BatchedMutations = &bufferedBatch{}
+
+func (m *bufferedBatch) Set(key kvstore.Key, value kvstore.Value) error {
+	m.muts.Set(kv.Key(key), value)
+	return nil
+}
+
+func (m *bufferedBatch) Delete(key kvstore.Key) error {
+	m.muts.Del(kv.Key(key))
+	return nil
+}
+
+func (m *bufferedBatch) Cancel() {
+	m.muts = buffered.NewMutations()
+}
+
+func (m *bufferedBatch) Commit() error {
+	for k, v := range m.muts.Sets {
+		m.b.muts.Set(k, v)
+	}
+	for k := range m.muts.Dels {
+		m.b.muts.Del(k)
+	}
+	return nil
+}
@@ -33,6 +33,15 @@ func New(s state.Store) IndexedStore {
 	}
 }
 
+// NewBuffered returns an IndexedStore backed by the given one, where all writes
+// are kept in memory (see state.NewBufferedStore).
+func NewBuffered(s IndexedStore) IndexedStore {
+	if is, ok := s.(*istore); ok {
+		return New(state.NewBufferedStore(is.Store))
+	}
+	return New(state.NewBufferedStore(s))
+}
+
 func (s *istore) BlockByIndex(index uint32) (state.Block, error) {
 	root, err := s.findTrieRootByIndex(index)
 	if err != nil {
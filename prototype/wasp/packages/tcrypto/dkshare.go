@@ -566,6 +566,60 @@ func (s *dkShareImpl) BLSPriShare() *share.PriShare {
 	return &share.PriShare{I: int(*s.index), V: s.blsPrivateShare}
 }
 
+///////////////////////// BLS based threshold encryption.
+
+// BLSDecryptionShare produces the decryption share of the encapsulated key
+// with the own key share. The share is only produced, if the encapsulated key
+// is bound to the label. See KeyEncapsulation.
+func (s *dkShareImpl) BLSDecryptionShare(encapsulatedKey, label []byte) ([]byte, error) {
+	_, u2, err := verifiedEncapsulatedKey(s.blsSuite, encapsulatedKey, label)
+	if err != nil {
+		return nil, err
+	}
+	return decryptionShareToBytes(&share.PubShare{
+		I: int(*s.index),
+		V: s.blsSuite.G2().Point().Mul(s.blsPrivateShare, u2),
+	})
+}
+
+// BLSVerifyDecryptionShare verifies the decryption share of a particular node.
+// The encapsulated key has to be verified with VerifyEncapsulatedKey before.
+func (s *dkShareImpl) BLSVerifyDecryptionShare(encapsulatedKey, decShare []byte) error {
+	u1, _, _, _, err := encapsulatedKeyFromBytes(s.blsSuite, encapsulatedKey)
+	if err != nil {
+		return err
+	}
+	pubShare, err := decryptionShareFromBytes(s.blsSuite, decShare)
+	if err != nil {
+		return err
+	}
+	if pubShare.I < 0 || pubShare.I >= len(s.blsPublicShares) {
+		return fmt.Errorf("invalid decryption share index: %v", pubShare.I)
+	}
+	if !s.blsSuite.Pair(u1, s.blsPublicShares[pubShare.I]).Equal(s.blsSuite.Pair(s.blsSuite.G1().Point().Base(), pubShare.V)) {
+		return errors.New("invalid decryption share")
+	}
+	return nil
+}
+
+// BLSRecoverDecryptionKey recovers the symmetric key from the decryption shares.
+// The shares have to be verified with BLSVerifyDecryptionShare before.
+func (s *dkShareImpl) BLSRecoverDecryptionKey(decShares [][]byte) ([]byte, error) {
+	pubShares := make([]*share.PubShare, len(decShares))
+	for i := range decShares {
+		pubShare, err := decryptionShareFromBytes(s.blsSuite, decShares[i])
+		if err != nil {
+			return nil, err
+		}
+		pubShares[i] = pubShare
+	}
+	point, err := share.RecoverCommit(s.blsSuite.G2(), pubShares, int(s.blsThreshold), int(s.n))
+	if err != nil {
+		return nil, err
+	}
+	return decryptionKeyFromPoint(point)
+}
+
 ///////////////////////// Test support functions.
 
 func (s *dkShareImpl) AssignNodePubKeys(nodePubKeys []*cryptolib.PublicKey) {
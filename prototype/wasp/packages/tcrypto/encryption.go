@@ -0,0 +1,168 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package tcrypto
+
+import (
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// Labelled threshold encryption to the BLS key of a committee (TDH2-like,
+// hashed ElGamal on the BN256 pairing). The client picks a random r, and sends
+// the encapsulated key (U1, U2, e, f) = (r·G1, r·G2, e, s+r·e) along with the
+// data, encrypted with a symmetric key derived from r·P, where P is the shared
+// BLS public key. The pair (e, f) is a NIZK proof, that U1 and U2 are for the
+// same r, and that the sender knows it, with e = H(label, U1, U2, s·G1, s·G2).
+// The label binds the encapsulated key to the data it protects, so it cannot
+// be copied to another request (e.g. by another sender) to get the data
+// decrypted there. The nodes only produce the decryption shares for the keys
+// with a valid proof for the expected label (the scheme is CCA-secure).
+// The node i produces the decryption share x_i·r·G2, which can be verified by
+// anyone, as e(r·G1, P_i) = e(G1, x_i·r·G2). Any BLSThreshold of the verified
+// shares recover r·P, and therefore the symmetric key.
+
+// KeyEncapsulation is a fresh symmetric key, encapsulated to the shared BLS
+// public key of a committee. The encapsulated key is only produced for a
+// label, as the label usually depends on the data encrypted with the key.
+type KeyEncapsulation struct {
+	suite Suite
+	r     kyber.Scalar
+	key   []byte
+}
+
+// NewKeyEncapsulation generates a fresh symmetric key, and encapsulates it to
+// the shared BLS public key of a committee.
+func NewKeyEncapsulation(suite Suite, sharedPublic kyber.Point) (*KeyEncapsulation, error) {
+	r := suite.G2().Scalar().Pick(suite.RandomStream())
+	key, err := decryptionKeyFromPoint(suite.G2().Point().Mul(r, sharedPublic))
+	if err != nil {
+		return nil, err
+	}
+	return &KeyEncapsulation{suite: suite, r: r, key: key}, nil
+}
+
+// Key is the symmetric key, it must never be sent along with the encapsulated key.
+func (ke *KeyEncapsulation) Key() []byte {
+	return ke.key
+}
+
+// EncapsulatedKey produces the encapsulated key bound to the label.
+func (ke *KeyEncapsulation) EncapsulatedKey(label []byte) ([]byte, error) {
+	suite := ke.suite
+	u1 := suite.G1().Point().Mul(ke.r, nil)
+	u2 := suite.G2().Point().Mul(ke.r, nil)
+	s := suite.G2().Scalar().Pick(suite.RandomStream())
+	e, err := encapsulatedKeyChallenge(suite, label, u1, u2, suite.G1().Point().Mul(s, nil), suite.G2().Point().Mul(s, nil))
+	if err != nil {
+		return nil, err
+	}
+	f := suite.G2().Scalar().Add(s, suite.G2().Scalar().Mul(ke.r, e))
+	ww := rwutil.NewBytesWriter()
+	cryptolib.PointToWriter(ww, u1)
+	cryptolib.PointToWriter(ww, u2)
+	cryptolib.ScalarToWriter(ww, e)
+	cryptolib.ScalarToWriter(ww, f)
+	return ww.Bytes(), ww.Err
+}
+
+// VerifyEncapsulatedKey checks, if the encapsulated key is well-formed and
+// bound to the label, i.e. if the decryption shares for it can be produced.
+func VerifyEncapsulatedKey(suite Suite, encapsulatedKey, label []byte) error {
+	_, _, err := verifiedEncapsulatedKey(suite, encapsulatedKey, label)
+	return err
+}
+
+// EncapsulatedKeyID identifies the symmetric key behind the encapsulated key.
+// The proof is randomized, so the same key can be encapsulated in many ways,
+// but all of them share the ID. Use it to detect the reused keys.
+func EncapsulatedKeyID(suite Suite, encapsulatedKey []byte) (hashing.HashValue, error) {
+	u1, _, _, _, err := encapsulatedKeyFromBytes(suite, encapsulatedKey)
+	if err != nil {
+		return hashing.NilHash, err
+	}
+	u1Bytes, err := u1.MarshalBinary()
+	if err != nil {
+		return hashing.NilHash, err
+	}
+	return hashing.HashData(u1Bytes), nil
+}
+
+func verifiedEncapsulatedKey(suite Suite, encapsulatedKey, label []byte) (u1, u2 kyber.Point, err error) {
+	u1, u2, e, f, err := encapsulatedKeyFromBytes(suite, encapsulatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	// w1 = f·G1 - e·U1 = s·G1, w2 = f·G2 - e·U2 = s·G2, if U1 and U2 are for the same r.
+	w1 := suite.G1().Point().Sub(suite.G1().Point().Mul(f, nil), suite.G1().Point().Mul(e, u1))
+	w2 := suite.G2().Point().Sub(suite.G2().Point().Mul(f, nil), suite.G2().Point().Mul(e, u2))
+	expected, err := encapsulatedKeyChallenge(suite, label, u1, u2, w1, w2)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !expected.Equal(e) {
+		return nil, nil, errors.New("invalid proof of the encapsulated key")
+	}
+	return u1, u2, nil
+}
+
+func encapsulatedKeyChallenge(suite Suite, label []byte, u1, u2, w1, w2 kyber.Point) (kyber.Scalar, error) {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteBytes(label)
+	cryptolib.PointToWriter(ww, u1)
+	cryptolib.PointToWriter(ww, u2)
+	cryptolib.PointToWriter(ww, w1)
+	cryptolib.PointToWriter(ww, w2)
+	if ww.Err != nil {
+		return nil, ww.Err
+	}
+	hash := hashing.HashDataBlake2b(ww.Bytes())
+	return suite.G2().Scalar().SetBytes(hash[:]), nil
+}
+
+func encapsulatedKeyFromBytes(suite Suite, data []byte) (u1, u2 kyber.Point, e, f kyber.Scalar, err error) {
+	rr := rwutil.NewBytesReader(data)
+	u1 = cryptolib.PointFromReader(rr, suite.G1())
+	u2 = cryptolib.PointFromReader(rr, suite.G2())
+	e = cryptolib.ScalarFromReader(rr, suite.G2())
+	f = cryptolib.ScalarFromReader(rr, suite.G2())
+	rr.Close()
+	if rr.Err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("cannot parse the encapsulated key: %w", rr.Err)
+	}
+	return u1, u2, e, f, nil
+}
+
+func decryptionShareFromBytes(suite Suite, data []byte) (*share.PubShare, error) {
+	rr := rwutil.NewBytesReader(data)
+	index := rr.ReadUint16()
+	point := cryptolib.PointFromReader(rr, suite.G2())
+	rr.Close()
+	if rr.Err != nil {
+		return nil, fmt.Errorf("cannot parse the decryption share: %w", rr.Err)
+	}
+	return &share.PubShare{I: int(index), V: point}, nil
+}
+
+func decryptionShareToBytes(decShare *share.PubShare) ([]byte, error) {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint16(uint16(decShare.I))
+	cryptolib.PointToWriter(ww, decShare.V)
+	return ww.Bytes(), ww.Err
+}
+
+func decryptionKeyFromPoint(point kyber.Point) ([]byte, error) {
+	pointBytes, err := point.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	key := hashing.HashDataBlake2b(pointBytes)
+	return key[:], nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package tcrypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+)
+
+func blsDKShares(t *testing.T, n, threshold int) ([]DKShare, kyber.Point) {
+	edSuite := DefaultEd25519Suite()
+	blsSuite := DefaultBLSSuite()
+	edPriPoly := share.NewPriPoly(edSuite, n, nil, edSuite.RandomStream())
+	_, edCommits := edPriPoly.Commit(nil).Info()
+	edPriShares := edPriPoly.Shares(n)
+	blsPriPoly := share.NewPriPoly(blsSuite, threshold, nil, blsSuite.RandomStream())
+	_, blsCommits := blsPriPoly.Commit(nil).Info()
+	blsPriShares := blsPriPoly.Shares(n)
+
+	nodeKeys := make([]*cryptolib.KeyPair, n)
+	nodePubKeys := make([]*cryptolib.PublicKey, n)
+	for i := range nodeKeys {
+		nodeKeys[i] = cryptolib.NewKeyPair()
+		nodePubKeys[i] = nodeKeys[i].GetPublicKey()
+	}
+	edPublicShares := make([]kyber.Point, n)
+	blsPublicShares := make([]kyber.Point, n)
+	for i := 0; i < n; i++ {
+		edPublicShares[i] = edSuite.Point().Mul(edPriShares[i].V, nil)
+		blsPublicShares[i] = blsSuite.Point().Mul(blsPriShares[i].V, nil)
+	}
+	dkShares := make([]DKShare, n)
+	for i := range dkShares {
+		dks, err := NewDKShare(
+			uint16(i), uint16(n), uint16(n), nodeKeys[i].GetPrivateKey(), nodePubKeys,
+			edSuite, edCommits[0], edCommits, edPublicShares, edPriShares[i].V,
+			blsSuite, uint16(threshold), blsCommits[0], blsCommits, blsPublicShares, blsPriShares[i].V,
+		)
+		require.NoError(t, err)
+		dkShares[i] = dks
+	}
+	return dkShares, blsCommits[0]
+}
+
+func TestThresholdEncryption(t *testing.T) {
+	dkShares, sharedPublic := blsDKShares(t, 4, 2)
+	suite := DefaultBLSSuite()
+	label := []byte("label")
+
+	keyEncapsulation, err := NewKeyEncapsulation(suite, sharedPublic)
+	require.NoError(t, err)
+	encapsulatedKey, err := keyEncapsulation.EncapsulatedKey(label)
+	require.NoError(t, err)
+	require.NoError(t, VerifyEncapsulatedKey(suite, encapsulatedKey, label))
+
+	decShares := make([][]byte, len(dkShares))
+	for i, dks := range dkShares {
+		decShares[i], err = dks.BLSDecryptionShare(encapsulatedKey, label)
+		require.NoError(t, err)
+		for _, verifier := range dkShares {
+			require.NoError(t, verifier.BLSVerifyDecryptionShare(encapsulatedKey, decShares[i]))
+		}
+	}
+
+	// Any threshold of the shares recover the same key.
+	recovered, err := dkShares[0].BLSRecoverDecryptionKey(decShares[1:3])
+	require.NoError(t, err)
+	require.Equal(t, keyEncapsulation.Key(), recovered)
+	recovered, err = dkShares[3].BLSRecoverDecryptionKey([][]byte{decShares[3], decShares[0]})
+	require.NoError(t, err)
+	require.Equal(t, keyEncapsulation.Key(), recovered)
+
+	// The shares for another key are rejected.
+	otherKeyEncapsulation, err := NewKeyEncapsulation(suite, sharedPublic)
+	require.NoError(t, err)
+	otherEncapsulatedKey, err := otherKeyEncapsulation.EncapsulatedKey(label)
+	require.NoError(t, err)
+	require.Error(t, dkShares[0].BLSVerifyDecryptionShare(otherEncapsulatedKey, decShares[1]))
+
+	// The encapsulated key is not decrypted for another label.
+	require.Error(t, VerifyEncapsulatedKey(suite, encapsulatedKey, []byte("other")))
+	_, err = dkShares[0].BLSDecryptionShare(encapsulatedKey, []byte("other"))
+	require.Error(t, err)
+
+	// The inconsistent encapsulated keys are rejected.
+	inconsistent := append(append([]byte{}, encapsulatedKey[:suite.G1().PointLen()]...), otherEncapsulatedKey[suite.G1().PointLen():]...)
+	require.Error(t, VerifyEncapsulatedKey(suite, inconsistent, label))
+	_, err = dkShares[0].BLSDecryptionShare(inconsistent, label)
+	require.Error(t, err)
+}
+
+func TestEncapsulatedKeyID(t *testing.T) {
+	_, sharedPublic := blsDKShares(t, 4, 2)
+	suite := DefaultBLSSuite()
+
+	keyEncapsulation, err := NewKeyEncapsulation(suite, sharedPublic)
+	require.NoError(t, err)
+	encapsulatedKey1, err := keyEncapsulation.EncapsulatedKey([]byte("label1"))
+	require.NoError(t, err)
+	encapsulatedKey2, err := keyEncapsulation.EncapsulatedKey([]byte("label2"))
+	require.NoError(t, err)
+	require.NotEqual(t, encapsulatedKey1, encapsulatedKey2)
+
+	// The same key has the same ID, whatever the label and the proof.
+	id1, err := EncapsulatedKeyID(suite, encapsulatedKey1)
+	require.NoError(t, err)
+	id2, err := EncapsulatedKeyID(suite, encapsulatedKey2)
+	require.NoError(t, err)
+	require.Equal(t, id1, id2)
+
+	otherKeyEncapsulation, err := NewKeyEncapsulation(suite, sharedPublic)
+	require.NoError(t, err)
+	otherEncapsulatedKey, err := otherKeyEncapsulation.EncapsulatedKey([]byte("label1"))
+	require.NoError(t, err)
+	otherID, err := EncapsulatedKeyID(suite, otherEncapsulatedKey)
+	require.NoError(t, err)
+	require.NotEqual(t, id1, otherID)
+}
@@ -66,6 +66,11 @@ type DKShare interface {
 	BLSCommits() *share.PubPoly                                 // TODO: Abstract the BLS signing part to some interface and keep the keys inside.
 	BLSPriShare() *share.PriShare                               // TODO: Abstract the BLS signing part to some interface and keep the keys inside.
 	//
+	// BLS based threshold encryption (for the encrypted requests).
+	BLSDecryptionShare(encapsulatedKey, label []byte) ([]byte, error)
+	BLSVerifyDecryptionShare(encapsulatedKey, decShare []byte) error
+	BLSRecoverDecryptionKey(decShares [][]byte) ([]byte, error)
+	//
 	// For tests only.
 	AssignNodePubKeys(nodePubKeys []*cryptolib.PublicKey)
 	AssignCommonData(dks DKShare)
@@ -43,6 +43,8 @@ type UtxoDB struct {
 	// globalLogicalTime can be ahead of real time due to AdvanceClockBy
 	globalLogicalTime time.Time
 	timeStep          time.Duration
+	// onTransactionAdded is called with the mutex locked
+	onTransactionAdded func(tx *iotago.Transaction, globalLogicalTime time.Time)
 }
 
 type InitParams struct {
@@ -141,6 +143,19 @@ func (u *UtxoDB) addTransaction(tx *iotago.Transaction, isGenesis bool) {
 	// advance clock
 	u.advanceClockBy(u.timeStep)
 	u.checkLedgerBalance()
+	if !isGenesis && u.onTransactionAdded != nil {
+		u.onTransactionAdded(tx, u.globalLogicalTime)
+	}
+}
+
+// OnTransactionAdded registers a function that is called each time a transaction is added
+// to the ledger, together with the logical time after the transaction was added.
+// The function is called while the UtxoDB is locked, so it must not call back into it.
+func (u *UtxoDB) OnTransactionAdded(f func(tx *iotago.Transaction, globalLogicalTime time.Time)) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.onTransactionAdded = f
 }
 
 func (u *UtxoDB) advanceClockBy(step time.Duration) {
@@ -174,6 +189,21 @@ func (u *UtxoDB) GenesisAddress() iotago.Address {
 }
 
 func (u *UtxoDB) mustGetFundsFromFaucetTx(target iotago.Address, amount ...uint64) *iotago.Transaction {
+	fundsAmount := FundsFromFaucetAmount
+	if len(amount) > 0 {
+		fundsAmount = amount[0]
+	}
+	return u.mustGenesisFundedTx(&iotago.BasicOutput{
+		Amount: fundsAmount,
+		Conditions: iotago.UnlockConditions{
+			&iotago.AddressUnlockCondition{Address: target},
+		},
+	})
+}
+
+// mustGenesisFundedTx builds a transaction that creates the given output with
+// funds from the genesis address
+func (u *UtxoDB) mustGenesisFundedTx(out iotago.Output) *iotago.Transaction {
 	unspentOutputs := u.getUnspentOutputs(genesisAddress)
 	if len(unspentOutputs) != 1 {
 		panic("number of genesis outputs must be 1")
@@ -185,25 +215,15 @@ func (u *UtxoDB) mustGetFundsFromFaucetTx(target iotago.Address, amount ...uint6
 		inputOutputID = oid
 	}
 
-	fundsAmount := FundsFromFaucetAmount
-	if len(amount) > 0 {
-		fundsAmount = amount[0]
-	}
-
 	tx, err := builder.NewTransactionBuilder(parameters.L1().Protocol.NetworkID()).
 		AddInput(&builder.TxInput{
 			UnlockTarget: genesisAddress,
 			InputID:      inputOutputID,
 			Input:        inputOutput,
 		}).
+		AddOutput(out).
 		AddOutput(&iotago.BasicOutput{
-			Amount: fundsAmount,
-			Conditions: iotago.UnlockConditions{
-				&iotago.AddressUnlockCondition{Address: target},
-			},
-		}).
-		AddOutput(&iotago.BasicOutput{
-			Amount: inputOutput.Amount - fundsAmount,
+			Amount: inputOutput.Amount - out.Deposit(),
 			Conditions: iotago.UnlockConditions{
 				&iotago.AddressUnlockCondition{Address: genesisAddress},
 			},
@@ -221,6 +241,23 @@ func (u *UtxoDB) GetFundsFromFaucet(target iotago.Address, amount ...uint64) (*i
 	return tx, u.AddToLedger(tx)
 }
 
+// ImportOutput adds the given output to the ledger, with funds from the genesis address, and
+// returns its ID. Unlike AddToLedger, the transaction is not validated, so that the output
+// can continue an alias or NFT chain created outside of the ledger (e.g. when forking an
+// existing ISC chain).
+func (u *UtxoDB) ImportOutput(out iotago.Output) iotago.OutputID {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	tx := u.mustGenesisFundedTx(out)
+	u.addTransaction(tx, false)
+	txID, err := tx.ID()
+	if err != nil {
+		panic(err)
+	}
+	return iotago.OutputIDFromTransactionIDAndIndex(txID, 0)
+}
+
 // Supply returns supply of the instance.
 func (u *UtxoDB) Supply() uint64 {
 	return u.supply
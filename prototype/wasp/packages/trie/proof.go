@@ -1,5 +1,11 @@
 package trie
 
+import (
+	"io"
+
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
 // MerkleProof is a proof of inclusion or absence
 type MerkleProof struct {
 	Key  []byte
@@ -54,3 +60,81 @@ func (tr *TrieReader) MerkleProof(key []byte) *MerkleProof {
 	}
 	return ret
 }
+
+func MerkleProofFromBytes(data []byte) (*MerkleProof, error) {
+	return rwutil.ReadFromBytes(data, new(MerkleProof))
+}
+
+func (p *MerkleProof) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *MerkleProof) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	encodedKey := rr.ReadBytes()
+	if rr.Err == nil {
+		p.Key, rr.Err = decodeToUnpackedBytes(encodedKey)
+	}
+	size := rr.ReadSize16()
+	p.Path = make([]*MerkleProofElement, size)
+	for i := range p.Path {
+		p.Path[i] = new(MerkleProofElement)
+		rr.Read(p.Path[i])
+	}
+	return rr.Err
+}
+
+func (p *MerkleProof) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var encodedKey []byte
+	encodedKey, ww.Err = encodeUnpackedBytes(p.Key)
+	ww.WriteBytes(encodedKey)
+	ww.WriteSize16(len(p.Path))
+	for _, e := range p.Path {
+		ww.Write(e)
+	}
+	return ww.Err
+}
+
+func (e *MerkleProofElement) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	encodedPathExtension := rr.ReadBytes()
+	if rr.Err == nil {
+		e.PathExtension, rr.Err = decodeToUnpackedBytes(encodedPathExtension)
+	}
+	flags := rr.ReadUint16()
+	for i := 0; i < NumChildren; i++ {
+		if (flags & (1 << i)) != 0 {
+			e.Children[i] = &Hash{}
+			rr.Read(e.Children[i])
+		}
+	}
+	e.Terminal = rr.ReadBytes()
+	if len(e.Terminal) == 0 {
+		e.Terminal = nil
+	}
+	e.ChildIndex = int(rr.ReadUint8())
+	return rr.Err
+}
+
+func (e *MerkleProofElement) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var encodedPathExtension []byte
+	encodedPathExtension, ww.Err = encodeUnpackedBytes(e.PathExtension)
+	ww.WriteBytes(encodedPathExtension)
+	flags := uint16(0)
+	for i, c := range e.Children {
+		if c != nil {
+			flags |= 1 << i
+		}
+	}
+	ww.WriteUint16(flags)
+	for _, c := range e.Children {
+		if c != nil {
+			ww.Write(c)
+		}
+	}
+	ww.WriteBytes(e.Terminal)
+	ww.WriteUint8(uint8(e.ChildIndex))
+	return ww.Err
+}
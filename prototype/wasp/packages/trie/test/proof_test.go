@@ -28,6 +28,8 @@ func TestProofScenariosBlake2b(t *testing.T) {
 					require.EqualValues(t, []byte(v), vBin)
 				}
 				p := trr.MerkleProof([]byte(k))
+				p, err = trie.MerkleProofFromBytes(p.Bytes())
+				require.NoError(t, err)
 				err = p.Validate(root.Bytes())
 				require.NoError(t, err)
 				if len(v) > 0 {
@@ -27,6 +27,12 @@ func AdjustAccountBaseTokens(state kv.KVStore, account isc.AgentID, adjustment i
 	}
 }
 
+// BaseTokensKey returns the key where the base tokens balance of the account
+// is stored, relative to the accounts partition
+func BaseTokensKey(agentID isc.AgentID, chainID isc.ChainID) kv.Key {
+	return baseTokensKey(accountKey(agentID, chainID))
+}
+
 func GetBaseTokensBalance(state kv.KVStoreReader, agentID isc.AgentID, chainID isc.ChainID) uint64 {
 	return getBaseTokens(state, accountKey(agentID, chainID))
 }
@@ -29,6 +29,10 @@ func (sa *StateAccess) AccountExists(agentID isc.AgentID, chainID isc.ChainID) b
 	return accountExists(sa.state, agentID, chainID)
 }
 
+func (sa *StateAccess) BaseTokensBalance(agentID isc.AgentID, chainID isc.ChainID) uint64 {
+	return GetBaseTokensBalance(sa.state, agentID, chainID)
+}
+
 // converts an account key from the accounts contract (shortform without chainID) to an AgentID
 func AgentIDFromKey(key kv.Key, chainID isc.ChainID) (isc.AgentID, error) {
 	if len(key) < isc.ChainIDLength {
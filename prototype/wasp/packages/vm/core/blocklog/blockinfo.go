@@ -9,11 +9,12 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/util/rwutil"
 )
 
 const (
-	BlockInfoLatestSchemaVersion = 0
+	BlockInfoLatestSchemaVersion = 1
 )
 
 type BlockInfo struct {
@@ -25,6 +26,10 @@ type BlockInfo struct {
 	PreviousAliasOutput   *isc.AliasOutputWithID // nil for block #0
 	GasBurned             uint64
 	GasFeeCharged         uint64
+	// GasPerToken is the gas price of the block, which is the base gas price if
+	// the dynamic base fee is enabled. It is 0:0 if the gas is free, and in the
+	// blocks with SchemaVersion 0.
+	GasPerToken util.Ratio32
 }
 
 // RequestTimestamp returns timestamp which corresponds to the request with the given index
@@ -56,6 +61,7 @@ func (bi *BlockInfo) String() string {
 	ret += fmt.Sprintf("\tPrev AliasOutput: %s\n", bi.PreviousAliasOutput.String())
 	ret += fmt.Sprintf("\tGas burned: %d\n", bi.GasBurned)
 	ret += fmt.Sprintf("\tGas fee charged: %d\n", bi.GasFeeCharged)
+	ret += fmt.Sprintf("\tGas per token: %s\n", bi.GasPerToken)
 	ret += "}\n"
 	return ret
 }
@@ -94,6 +100,9 @@ func (bi *BlockInfo) Read(r io.Reader) error {
 	}
 	bi.GasBurned = rr.ReadGas64()
 	bi.GasFeeCharged = rr.ReadGas64()
+	if bi.SchemaVersion >= 1 {
+		rr.Read(&bi.GasPerToken)
+	}
 	return rr.Err
 }
 
@@ -110,5 +119,8 @@ func (bi *BlockInfo) Write(w io.Writer) error {
 	}
 	ww.WriteGas64(bi.GasBurned)
 	ww.WriteGas64(bi.GasFeeCharged)
+	if bi.SchemaVersion >= 1 {
+		ww.Write(&bi.GasPerToken)
+	}
 	return ww.Err
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -27,6 +29,38 @@ func TestSerdeRequestReceipt(t *testing.T) {
 	back, err := RequestReceiptFromBytes(forward, rec.BlockIndex, rec.RequestIndex)
 	require.NoError(t, err)
 	require.EqualValues(t, forward, back.Bytes())
+
+	rec.ContractFees = &governance.ContractFeesRecord{OwnerFee: 10, GasPerTokenMultiplier: util.Ratio32{A: 2, B: 1}}
+	rec.GasBurnLog = gas.NewGasBurnLog()
+	forward = rec.Bytes()
+	back, err = RequestReceiptFromBytes(forward, rec.BlockIndex, rec.RequestIndex)
+	require.NoError(t, err)
+	require.EqualValues(t, rec.ContractFees, back.ContractFees)
+	require.NotNil(t, back.GasBurnLog)
+	require.EqualValues(t, forward, back.Bytes())
+}
+
+func TestSerdeBlockInfo(t *testing.T) {
+	bi := &BlockInfo{
+		SchemaVersion:         BlockInfoLatestSchemaVersion,
+		Timestamp:             time.Unix(0, 123),
+		TotalRequests:         3,
+		NumSuccessfulRequests: 2,
+		NumOffLedgerRequests:  1,
+		GasBurned:             1000,
+		GasFeeCharged:         10,
+		GasPerToken:           util.Ratio32{A: 800, B: 9},
+	}
+	back, err := BlockInfoFromBytes(bi.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, bi, back)
+
+	// blocks saved before the gas price was recorded
+	bi.SchemaVersion = 0
+	back, err = BlockInfoFromBytes(bi.Bytes())
+	require.NoError(t, err)
+	require.Zero(t, back.GasPerToken)
+	require.Equal(t, bi.GasBurned, back.GasBurned)
 }
 
 func createRequestLookupKeys(blocks uint32, requests uint16) []byte {
@@ -0,0 +1,57 @@
+package blocklog
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
+
+// The encapsulated keys of the processed encrypted requests are kept (by
+// their IDs), so that the key of a revealed request cannot be used again.
+// They are pruned along with the receipts of the requests.
+
+func encapsulatedKeyID(req isc.Request) (hashing.HashValue, bool) {
+	encryptedReq, ok := req.(isc.EncryptedOffLedgerRequest)
+	if !ok {
+		return hashing.NilHash, false
+	}
+	keyID, err := tcrypto.EncapsulatedKeyID(tcrypto.DefaultBLSSuite(), encryptedReq.EncapsulatedKey())
+	if err != nil {
+		// A malformed key, it cannot be decrypted anyway.
+		return hashing.NilHash, false
+	}
+	return keyID, true
+}
+
+func saveEncapsulatedKey(partition kv.KVStore, req isc.Request, key RequestLookupKey) {
+	if keyID, ok := encapsulatedKeyID(req); ok {
+		collections.NewMap(partition, prefixEncapsulatedKeys).SetAt(keyID[:], key.Bytes())
+	}
+}
+
+func pruneEncapsulatedKey(partition kv.KVStore, req isc.Request, key RequestLookupKey) {
+	keyID, ok := encapsulatedKeyID(req)
+	if !ok {
+		return
+	}
+	keys := collections.NewMap(partition, prefixEncapsulatedKeys)
+	if bytes.Equal(keys.GetAt(keyID[:]), key.Bytes()) {
+		keys.DelAt(keyID[:])
+	}
+}
+
+// IsEncapsulatedKeyUsed checks, if the encapsulated key was used by an
+// encrypted request processed already.
+func IsEncapsulatedKeyUsed(stateReader kv.KVStoreReader, encapsulatedKey []byte) (bool, error) {
+	keyID, err := tcrypto.EncapsulatedKeyID(tcrypto.DefaultBLSSuite(), encapsulatedKey)
+	if err != nil {
+		return false, err
+	}
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	return collections.NewMapReadOnly(partition, prefixEncapsulatedKeys).HasAt(keyID[:]), nil
+}
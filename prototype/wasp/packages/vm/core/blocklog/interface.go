@@ -63,4 +63,8 @@ const (
 	// Array of requestID.
 	// Temporary list of unprocessable requests that need updating the outputID field
 	prefixNewUnprocessableRequests = "U"
+
+	// Map of tcrypto.EncapsulatedKeyID => RequestLookupKey (pruned)
+	//   The keys of the processed encrypted requests, they cannot be reused.
+	prefixEncapsulatedKeys = "k"
 )
@@ -54,6 +54,7 @@ func SaveRequestReceipt(partition kv.KVStore, rec *RequestReceipt, key RequestLo
 	// save the record. Key is a LookupKey
 	data := rec.Bytes()
 	collections.NewMap(partition, prefixRequestReceipts).SetAt(key.Bytes(), data)
+	saveEncapsulatedKey(partition, rec.Request, key)
 	return nil
 }
 
@@ -229,6 +230,7 @@ func pruneRequestLogRecordsByBlockIndex(partition kv.KVStore, blockIndex uint32,
 		if err != nil {
 			panic(err)
 		}
+		pruneEncapsulatedKey(partition, receipt.Request, lookupKey)
 
 		receiptMap.DelAt(lookupKey[:])
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/util/rwutil"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -24,12 +25,20 @@ type RequestReceipt struct {
 	GasBurned     uint64                 `json:"gasBurned"`
 	GasFeeCharged uint64                 `json:"gasFeeCharged"`
 	SDCharged     uint64                 `json:"storageDepositCharged"`
+	// fee overrides of the target contract applied to the request, if any
+	ContractFees *governance.ContractFeesRecord `json:"contractFees,omitempty"`
 	// not persistent
 	BlockIndex   uint32       `json:"blockIndex"`
 	RequestIndex uint16       `json:"requestIndex"`
 	GasBurnLog   *gas.BurnLog `json:"-"`
 }
 
+// flags of the optional fields of the receipt
+const (
+	receiptFlagError        = 0x01
+	receiptFlagContractFees = 0x02
+)
+
 func RequestReceiptFromBytes(data []byte, blockIndex uint32, reqIndex uint16) (*RequestReceipt, error) {
 	rec, err := rwutil.ReadFromBytes(data, new(RequestReceipt))
 	if err != nil {
@@ -73,11 +82,15 @@ func (rec *RequestReceipt) Read(r io.Reader) error {
 	rec.GasFeeCharged = rr.ReadGas64()
 	rec.SDCharged = rr.ReadAmount64()
 	rec.Request = isc.RequestFromReader(rr)
-	hasError := rr.ReadBool()
-	if hasError {
+	flags := rr.ReadByte()
+	if flags&receiptFlagError != 0 {
 		rec.Error = new(isc.UnresolvedVMError)
 		rr.Read(rec.Error)
 	}
+	if flags&receiptFlagContractFees != 0 {
+		rec.ContractFees = new(governance.ContractFeesRecord)
+		rr.Read(rec.ContractFees)
+	}
 	if len(rr.Bytes()) != 0 {
 		rec.GasBurnLog = new(gas.BurnLog)
 		rr.Read(rec.GasBurnLog)
@@ -93,10 +106,20 @@ func (rec *RequestReceipt) Write(w io.Writer) error {
 	ww.WriteGas64(rec.GasFeeCharged)
 	ww.WriteAmount64(rec.SDCharged)
 	ww.Write(rec.Request)
-	ww.WriteBool(rec.Error != nil)
+	var flags byte
+	if rec.Error != nil {
+		flags |= receiptFlagError
+	}
+	if rec.ContractFees != nil {
+		flags |= receiptFlagContractFees
+	}
+	ww.WriteByte(flags)
 	if rec.Error != nil {
 		ww.Write(rec.Error)
 	}
+	if rec.ContractFees != nil {
+		ww.Write(rec.ContractFees)
+	}
 	if rec.GasBurnLog != nil {
 		ww.Write(rec.GasBurnLog)
 	}
@@ -109,6 +132,9 @@ func (rec *RequestReceipt) String() string {
 	ret += fmt.Sprintf("Block/Request index: %d / %d\n", rec.BlockIndex, rec.RequestIndex)
 	ret += fmt.Sprintf("Gas budget / burned / fee charged: %d / %d /%d\n", rec.GasBudget, rec.GasBurned, rec.GasFeeCharged)
 	ret += fmt.Sprintf("Storage deposit charged: %d\n", rec.SDCharged)
+	if rec.ContractFees != nil {
+		ret += fmt.Sprintf("Contract fees: %s\n", rec.ContractFees)
+	}
 	ret += fmt.Sprintf("Call data: %s\n", rec.Request)
 	ret += fmt.Sprintf("burn log: %s\n", rec.GasBurnLog)
 	return ret
@@ -134,12 +160,17 @@ func (rec *RequestReceipt) LookupKey() RequestLookupKey {
 }
 
 func (rec *RequestReceipt) ToISCReceipt(resolvedError *isc.VMError) *isc.Receipt {
+	var contractFeesBytes []byte
+	if rec.ContractFees != nil {
+		contractFeesBytes = rec.ContractFees.Bytes()
+	}
 	return &isc.Receipt{
 		Request:       rec.Request.Bytes(),
 		Error:         rec.Error,
 		GasBudget:     rec.GasBudget,
 		GasBurned:     rec.GasBurned,
 		GasFeeCharged: rec.GasFeeCharged,
+		ContractFees:  contractFeesBytes,
 		BlockIndex:    rec.BlockIndex,
 		RequestIndex:  rec.RequestIndex,
 		ResolvedError: resolvedError.Error(),
@@ -9,17 +9,23 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/errors"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 )
 
 var All = map[isc.Hname]*coreutil.ContractInfo{
-	root.Contract.Hname():       root.Contract,
-	errors.Contract.Hname():     errors.Contract,
-	accounts.Contract.Hname():   accounts.Contract,
-	blob.Contract.Hname():       blob.Contract,
-	blocklog.Contract.Hname():   blocklog.Contract,
-	governance.Contract.Hname(): governance.Contract,
-	evm.Contract.Hname():        evm.Contract,
+	root.Contract.Hname():        root.Contract,
+	errors.Contract.Hname():      errors.Contract,
+	accounts.Contract.Hname():    accounts.Contract,
+	blob.Contract.Hname():        blob.Contract,
+	blocklog.Contract.Hname():    blocklog.Contract,
+	governance.Contract.Hname():  governance.Contract,
+	evm.Contract.Hname():         evm.Contract,
+	scheduler.Contract.Hname():   scheduler.Contract,
+	oracle.Contract.Hname():      oracle.Contract,
+	sponsorship.Contract.Hname(): sponsorship.Contract,
 }
 
 func IsCoreHname(hname isc.Hname) bool {
@@ -12,19 +12,25 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/governance/governanceimpl"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/core/root/rootimpl"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 )
 
 var All = map[hashing.HashValue]isc.VMProcessor{
-	root.Contract.ProgramHash:       rootimpl.Processor,
-	errors.Contract.ProgramHash:     errors.Processor,
-	accounts.Contract.ProgramHash:   accounts.Processor,
-	blob.Contract.ProgramHash:       blob.Processor,
-	blocklog.Contract.ProgramHash:   blocklog.Processor,
-	governance.Contract.ProgramHash: governanceimpl.Processor,
-	evm.Contract.ProgramHash:        evmimpl.Processor,
+	root.Contract.ProgramHash:        rootimpl.Processor,
+	errors.Contract.ProgramHash:      errors.Processor,
+	accounts.Contract.ProgramHash:    accounts.Processor,
+	blob.Contract.ProgramHash:        blob.Processor,
+	blocklog.Contract.ProgramHash:    blocklog.Processor,
+	governance.Contract.ProgramHash:  governanceimpl.Processor,
+	evm.Contract.ProgramHash:         evmimpl.Processor,
+	scheduler.Contract.ProgramHash:   scheduler.Processor,
+	oracle.Contract.ProgramHash:      oracle.Processor,
+	sponsorship.Contract.ProgramHash: sponsorship.Processor,
 }
 
 func init() {
@@ -132,6 +132,12 @@ func (bc *BlockchainDB) GetPendingHeader(timestamp uint64) *types.Header {
 	}
 }
 
+// GetPendingTxCount returns the amount of txs added to the pending block so
+// far, i.e. the index that the next tx will have in the block.
+func (bc *BlockchainDB) GetPendingTxCount() uint64 {
+	return uint64(bc.getTxArray(bc.GetPendingBlockNumber()).Len())
+}
+
 func (bc *BlockchainDB) getPendingCumulativeGasUsed() uint64 {
 	blockNumber := bc.GetPendingBlockNumber()
 	receiptArray := bc.getReceiptArray(blockNumber)
@@ -336,7 +342,7 @@ func (bc *BlockchainDB) getReceiptByBlockNumberAndIndex(
 		log.Index = logIndex + uint(i)
 	}
 	if tx.To() == nil {
-		from, _ := types.Sender(evmutil.Signer(big.NewInt(int64(bc.GetChainID()))), tx)
+		from, _ := evmutil.GetTransactionSender(tx)
 		r.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
 	}
 	r.GasUsed = r.CumulativeGasUsed - cumulativeGasUsed
@@ -22,6 +22,7 @@ import (
 	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
 	"github.com/iotaledger/wasp/packages/util/panicutil"
@@ -107,6 +108,12 @@ func StateDBSubrealmR(store kv.KVStoreReader) kv.KVStoreReader {
 	return subrealm.NewReadOnly(store, keyStateDB)
 }
 
+// StateDBSubrealmKey returns the key of a StateDB entry, relative to the
+// emulator state
+func StateDBSubrealmKey(key kv.Key) kv.Key {
+	return keyStateDB + key
+}
+
 func BlockchainDBSubrealm(store kv.KVStore) kv.KVStore {
 	return subrealm.New(store, keyBlockchainDB)
 }
@@ -204,8 +211,15 @@ func coreMsgFromCallMsg(call ethereum.CallMsg, gasEstimateMode bool, statedb *St
 	}
 }
 
-// CallContract executes a contract call, without committing changes to the state
-func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool) (*core.ExecutionResult, error) {
+// CallContract executes a contract call, without committing changes to the
+// state. If overrides is not nil, the state and block context are modified
+// accordingly before executing the call.
+func (e *EVMEmulator) CallContract(
+	call ethereum.CallMsg,
+	gasEstimateMode bool,
+	overrides *isc.EVMCallOverrides,
+	tracer tracers.Tracer,
+) (*core.ExecutionResult, error) {
 	// Ensure message is initialized properly.
 	if call.Gas == 0 {
 		call.Gas = e.ctx.GasLimits().Call
@@ -222,7 +236,63 @@ func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool)
 	i := statedb.Snapshot()
 	defer statedb.RevertToSnapshot(i)
 
-	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, nil)
+	applyOverrides(statedb, pendingHeader, overrides)
+
+	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, tracer)
+}
+
+// SimulateCall executes a contract call like CallContract, but the changes to
+// the state are kept. It returns the call as an impersonated transaction
+// (which is not added to the blockchain), along with its receipt containing
+// the emitted logs.
+func (e *EVMEmulator) SimulateCall(
+	call ethereum.CallMsg,
+	overrides *isc.EVMCallOverrides,
+	tracer tracers.Tracer,
+) (*types.Transaction, *types.Receipt, *core.ExecutionResult, error) {
+	if call.Gas == 0 {
+		call.Gas = e.ctx.GasLimits().Call
+	}
+	if call.Value == nil {
+		call.Value = big.NewInt(0)
+	}
+
+	pendingHeader := e.BlockchainDB().GetPendingHeader(e.ctx.Timestamp())
+
+	statedb := e.StateDB()
+	applyOverrides(statedb, pendingHeader, overrides)
+
+	msg := coreMsgFromCallMsg(call, true, statedb)
+	tx := evmutil.NewImpersonatedTransaction(msg.From, &types.LegacyTx{
+		Nonce:    msg.Nonce,
+		GasPrice: msg.GasPrice,
+		Gas:      msg.GasLimit,
+		To:       msg.To,
+		Value:    msg.Value,
+		Data:     msg.Data,
+	})
+	result, err := e.applyMessage(msg, statedb, pendingHeader, tracer)
+
+	gasUsed := uint64(0)
+	if result != nil {
+		gasUsed = result.UsedGas
+	}
+	receipt := &types.Receipt{
+		Type:              types.LegacyTxType,
+		CumulativeGasUsed: e.BlockchainDB().getPendingCumulativeGasUsed() + gasUsed,
+		GasUsed:           gasUsed,
+		Logs:              statedb.GetLogs(),
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	if result == nil || result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	} else {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+	if msg.To == nil {
+		receipt.ContractAddress = crypto.CreateAddress(msg.From, msg.Nonce)
+	}
+	return tx, receipt, result, err
 }
 
 func (e *EVMEmulator) applyMessage(
@@ -78,7 +78,7 @@ func estimateGas(callMsg ethereum.CallMsg, e *EVMEmulator) (uint64, error) {
 	var lastErr error
 	for hi >= lo {
 		callMsg.Gas = (lo + hi) / 2
-		res, err := e.CallContract(callMsg, true)
+		res, err := e.CallContract(callMsg, true, nil, nil)
 		if err != nil {
 			return 0, fmt.Errorf("CallContract failed: %w", err)
 		}
@@ -457,7 +457,7 @@ func TestStorageContract(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, callArguments)
 
-		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -485,7 +485,7 @@ func TestStorageContract(t *testing.T) {
 		res, err := emu.CallContract(ethereum.CallMsg{
 			To:   &contractAddress,
 			Data: callArguments,
-		}, false)
+		}, false, nil, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -499,6 +499,69 @@ func TestStorageContract(t *testing.T) {
 	}
 }
 
+// TestTransientStorageDraftEIP1153 checks the transient storage of the
+// StateDB. Cancun is not available, so the opcodes are enabled with the draft
+// version of EIP-1153 implemented by the go-ethereum version in use.
+func TestTransientStorageDraftEIP1153(t *testing.T) {
+	sender, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	senderAddress := crypto.PubkeyToAddress(sender.PublicKey)
+
+	ctx := newContext(map[common.Address]uint64{})
+	Init(ctx.State(), evm.DefaultChainID, ctx.GasLimits(), ctx.Timestamp(), map[common.Address]core.GenesisAccount{})
+	ctx.timestamp++
+
+	emu := NewEVMEmulator(ctx)
+	// TLOAD = 0xb3 and TSTORE = 0xb4 in the draft of EIP-1153 (0x5c and 0x5d
+	// in Cancun)
+	emu.vmConfig.ExtraEips = []int{1153}
+
+	// runtime code: tstore(0, tload(0) + 42); return tload(0)
+	// returns 42 only if the transient storage is empty at the beginning of
+	// each transaction
+	runtimeCode := common.FromHex("6000b3602a016000b46000b360005260206000f3")
+	// init code: return runtimeCode
+	initCode := append(common.FromHex("6014600c60003960146000f3"), runtimeCode...)
+
+	tx, err := types.SignTx(
+		types.NewContractCreation(0, big.NewInt(0), 100_000, gasPrice, initCode),
+		emu.Signer(),
+		sender,
+	)
+	require.NoError(t, err)
+	receipt, res, err := emu.SendTransaction(tx, nil)
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	emu.MintBlock()
+
+	for i := 0; i < 2; i++ {
+		res, err = emu.CallContract(ethereum.CallMsg{
+			From: senderAddress,
+			To:   &receipt.ContractAddress,
+		}, false, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, res.Err)
+		require.EqualValues(t, 42, new(big.Int).SetBytes(res.Return()).Uint64())
+	}
+}
+
+func TestTransientStorageSnapshot(t *testing.T) {
+	s := NewStateDB(newContext(map[common.Address]uint64{}))
+	addr := common.Address{1}
+	key := common.Hash{2}
+
+	s.SetTransientState(addr, key, common.Hash{3})
+	i := s.Snapshot()
+	s.SetTransientState(addr, key, common.Hash{4})
+	require.Equal(t, common.Hash{4}, s.GetTransientState(addr, key))
+	s.RevertToSnapshot(i)
+	require.Equal(t, common.Hash{3}, s.GetTransientState(addr, key))
+
+	s.Prepare(params.Rules{}, addr, common.Address{}, nil, nil, nil)
+	require.Equal(t, common.Hash{}, s.GetTransientState(addr, key))
+}
+
 func TestERC20Contract(t *testing.T) {
 	genesisAlloc := map[common.Address]core.GenesisAccount{}
 	ctx := newContext(map[common.Address]uint64{})
@@ -529,7 +592,7 @@ func TestERC20Contract(t *testing.T) {
 		callArguments, err2 := contractABI.Pack(name, args...)
 		require.NoError(t, err2)
 
-		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil, nil)
 		require.NoError(t, err2)
 
 		v := new(big.Int)
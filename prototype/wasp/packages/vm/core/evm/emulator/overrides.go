@@ -0,0 +1,68 @@
+package emulator
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+// applyOverrides modifies the state and the block header according to the
+// given overrides. The caller is responsible for reverting the state changes.
+func applyOverrides(statedb *StateDB, header *types.Header, overrides *isc.EVMCallOverrides) {
+	if overrides == nil {
+		return
+	}
+	applyStateOverrides(statedb, overrides.State)
+	if overrides.Block != nil {
+		if overrides.Block.Number != nil {
+			header.Number = overrides.Block.Number
+		}
+		if overrides.Block.Time != nil {
+			header.Time = *overrides.Block.Time
+		}
+	}
+}
+
+// ApplyStateOverrides modifies the state according to the given account
+// overrides, with no call being executed.
+func ApplyStateOverrides(ctx Context, overrides map[common.Address]*isc.EVMAccountOverride) {
+	applyStateOverrides(NewStateDB(ctx), overrides)
+}
+
+func applyStateOverrides(statedb *StateDB, overrides map[common.Address]*isc.EVMAccountOverride) {
+	for addr, account := range overrides {
+		if !statedb.Exist(addr) {
+			statedb.CreateAccount(addr)
+		}
+		if account.Nonce != nil {
+			statedb.SetNonce(addr, *account.Nonce)
+		}
+		if account.Code != nil {
+			statedb.SetCode(addr, account.Code)
+		}
+		if account.Balance != nil {
+			statedb.setBaseTokensBalance(addr, util.EthereumDecimalsToBaseTokenDecimals(account.Balance, statedb.ctx.BaseTokensDecimals()))
+		}
+		if account.State != nil {
+			statedb.deleteStorage(addr)
+		}
+		for key, value := range account.State {
+			statedb.SetState(addr, key, value)
+		}
+		for key, value := range account.StateDiff {
+			statedb.SetState(addr, key, value)
+		}
+	}
+}
+
+func (s *StateDB) setBaseTokensBalance(addr common.Address, amount uint64) {
+	current := s.ctx.GetBaseTokensBalance(addr)
+	switch {
+	case amount > current:
+		s.ctx.AddBaseTokensBalance(addr, amount-current)
+	case amount < current:
+		s.ctx.SubBaseTokensBalance(addr, current-amount)
+	}
+}
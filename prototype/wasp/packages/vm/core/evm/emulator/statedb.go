@@ -5,6 +5,7 @@ package emulator
 
 import (
 	"fmt"
+	"maps"
 	"math/big"
 	"slices"
 
@@ -46,23 +47,62 @@ func accountSuicidedKey(addr common.Address) kv.Key {
 	return accountKey(keyAccountSuicided, addr)
 }
 
+// NonceKey returns the key where the nonce of the account is stored,
+// relative to the StateDB subrealm
+func NonceKey(addr common.Address) kv.Key {
+	return accountNonceKey(addr)
+}
+
+// CodeKey returns the key where the code of the account is stored,
+// relative to the StateDB subrealm
+func CodeKey(addr common.Address) kv.Key {
+	return accountCodeKey(addr)
+}
+
+// StorageKey returns the key where the given storage slot of the account is
+// stored, relative to the StateDB subrealm
+func StorageKey(addr common.Address, key common.Hash) kv.Key {
+	return accountStateKey(addr, key)
+}
+
 // StateDB implements vm.StateDB with a kv.KVStore as backend.
 // The Ethereum account balance is tied to the L1 balance.
 type StateDB struct {
 	ctx       Context
 	kv        kv.KVStore // subrealm of ctx.State()
 	logs      []*types.Log
-	snapshots map[int][]*types.Log
+	transient transientStorage
+	snapshots map[int]*stateDBSnapshot
 	refund    uint64
 }
 
+// stateDBSnapshot contains the parts of the StateDB that are not stored in
+// the ctx state, which is reverted by ctx.RevertToSnapshot
+type stateDBSnapshot struct {
+	logs      []*types.Log
+	transient transientStorage
+}
+
+// transientStorage is the storage introduced by EIP-1153, which is discarded
+// at the end of each transaction
+type transientStorage map[common.Address]map[common.Hash]common.Hash
+
+func (t transientStorage) clone() transientStorage {
+	ret := make(transientStorage, len(t))
+	for addr, slots := range t {
+		ret[addr] = maps.Clone(slots)
+	}
+	return ret
+}
+
 var _ vm.StateDB = &StateDB{}
 
 func NewStateDB(ctx Context) *StateDB {
 	return &StateDB{
 		ctx:       ctx,
 		kv:        StateDBSubrealm(ctx.State()),
-		snapshots: make(map[int][]*types.Log),
+		transient: make(transientStorage),
+		snapshots: make(map[int]*stateDBSnapshot),
 	}
 }
 
@@ -199,7 +239,18 @@ func (s *StateDB) Suicide(addr common.Address) bool {
 
 	s.kv.Del(accountNonceKey(addr))
 	s.kv.Del(accountCodeKey(addr))
+	s.deleteStorage(addr)
+
+	// for some reason the EVM engine calls AddBalance to the beneficiary address,
+	// but not SubBalance for the suicided address.
+	s.ctx.SubBaseTokensBalance(addr, s.ctx.GetBaseTokensBalance(addr))
 
+	s.kv.Set(accountSuicidedKey(addr), []byte{1})
+
+	return true
+}
+
+func (s *StateDB) deleteStorage(addr common.Address) {
 	keys := make([]kv.Key, 0)
 	s.kv.IterateKeys(accountKey(keyAccountState, addr), func(key kv.Key) bool {
 		keys = append(keys, key)
@@ -208,14 +259,6 @@ func (s *StateDB) Suicide(addr common.Address) bool {
 	for _, k := range keys {
 		s.kv.Del(k)
 	}
-
-	// for some reason the EVM engine calls AddBalance to the beneficiary address,
-	// but not SubBalance for the suicided address.
-	s.ctx.SubBaseTokensBalance(addr, s.ctx.GetBaseTokensBalance(addr))
-
-	s.kv.Set(accountSuicidedKey(addr), []byte{1})
-
-	return true
 }
 
 func (s *StateDB) HasSuicided(addr common.Address) bool {
@@ -267,13 +310,17 @@ func (s *StateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
 
 func (s *StateDB) Snapshot() int {
 	i := s.ctx.TakeSnapshot()
-	s.snapshots[i] = slices.Clone(s.logs)
+	s.snapshots[i] = &stateDBSnapshot{
+		logs:      slices.Clone(s.logs),
+		transient: s.transient.clone(),
+	}
 	return i
 }
 
 func (s *StateDB) RevertToSnapshot(i int) {
 	s.ctx.RevertToSnapshot(i)
-	s.logs = s.snapshots[i]
+	s.logs = s.snapshots[i].logs
+	s.transient = s.snapshots[i].transient
 }
 
 func (s *StateDB) AddLog(log *types.Log) {
@@ -291,16 +338,24 @@ func (s *StateDB) ForEachStorage(common.Address, func(common.Hash, common.Hash)
 }
 
 // GetTransientState implements vm.StateDB
-func (*StateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
-	panic("unimplemented")
+func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return s.transient[addr][key]
 }
 
 // Prepare implements vm.StateDB
 func (s *StateDB) Prepare(rules params.Rules, sender common.Address, coinbase common.Address, dest *common.Address, precompiles []common.Address, txAccesses types.AccessList) {
-	// do nothing
+	// the transient storage is reset at the beginning of each transaction
+	s.transient = make(transientStorage)
 }
 
 // SetTransientState implements vm.StateDB
-func (*StateDB) SetTransientState(addr common.Address, key common.Hash, value common.Hash) {
-	panic("unimplemented")
+func (s *StateDB) SetTransientState(addr common.Address, key common.Hash, value common.Hash) {
+	if value == (common.Hash{}) {
+		delete(s.transient[addr], key)
+		return
+	}
+	if s.transient[addr] == nil {
+		s.transient[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.transient[addr][key] = value
 }
@@ -6,6 +6,7 @@ package evmimpl
 import (
 	"encoding/hex"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -34,6 +35,7 @@ import (
 var Processor = evm.Contract.Processor(nil,
 	evm.FuncSendTransaction.WithHandler(restricted(applyTransaction)),
 	evm.FuncCallContract.WithHandler(restricted(callContract)),
+	evm.FuncExecuteScheduledCall.WithHandler(restricted(executeScheduledCall)),
 
 	evm.FuncRegisterERC20NativeToken.WithHandler(registerERC20NativeToken),
 	evm.FuncRegisterERC20NativeTokenOnRemoteChain.WithHandler(restricted(registerERC20NativeTokenOnRemoteChain)),
@@ -103,7 +105,8 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	tx, err := evmtypes.DecodeTransaction(ctx.Params().Get(evm.FieldTransaction))
 	ctx.RequireNoError(err)
 
-	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), evmutil.MustGetSender(tx)))
+	sender := evmutil.MustGetSender(tx)
+	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), sender))
 
 	emu := createEmulator(ctx)
 
@@ -112,7 +115,7 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	}
 
 	// Execute the tx in the emulator.
-	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx), false)
+	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx, emu.BlockchainDB()), false)
 
 	// Any gas burned by the EVM is converted to ISC gas units and burned as
 	// ISC gas.
@@ -153,7 +156,7 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	// make sure we always store the EVM tx/receipt in the BlockchainDB, even
 	// if the ISC request is reverted
 	ctx.Privileged().OnWriteReceipt(func(evmPartition kv.KVStore) {
-		saveExecutedTx(evmPartition, chainInfo, tx, receipt)
+		saveExecutedTx(evmPartition, chainInfo, tx, sender, receipt)
 	})
 	// revert the changes in the state / txbuilder in case of error
 	ctx.RequireNoError(revertErr)
@@ -398,8 +401,9 @@ func tryGetRevertError(res *core.ExecutionResult) error {
 	return res.Err
 }
 
-// callContract is called from the jsonrpc eth_estimateGas and eth_call endpoints.
-// The VM is in estimate gas mode, and any state mutations are discarded.
+// callContract is called from the jsonrpc eth_estimateGas, eth_call and
+// eth_simulateV1 endpoints. The VM is in estimate gas mode, and any state
+// mutations are discarded, unless the VM is in simulation mode.
 func callContract(ctx isc.Sandbox) dict.Dict {
 	// We only want to charge gas for the actual execution of the ethereum tx.
 	// ISC magic calls enable gas burning temporarily when called.
@@ -411,24 +415,92 @@ func callContract(ctx isc.Sandbox) dict.Dict {
 	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), callMsg.From))
 
 	emu := createEmulator(ctx)
-	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode())
+	if ctx.SimulationMode() {
+		return simulateCall(ctx, emu, callMsg, ctx.RecordSimulatedCalls())
+	}
+	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode(), ctx.EVMCallOverrides(), getTracer(ctx, emu.BlockchainDB()))
 	ctx.RequireNoError(err)
 	ctx.RequireNoError(tryGetRevertError(res))
+	burnCallGas(ctx, res.UsedGas)
+	return result(res.ReturnData)
+}
 
-	gasRatio := getEVMGasRatio(ctx)
-	{
-		// burn the used EVM gas as it would be done for a normal request call
-		ctx.Privileged().GasBurnEnable(true)
-		gasErr := panicutil.CatchPanic(
-			func() {
-				ctx.Gas().Burn(gas.BurnCodeEVM1P, gas.EVMGasToISC(res.UsedGas, &gasRatio))
-			},
-		)
-		ctx.Privileged().GasBurnEnable(false)
-		ctx.RequireNoError(gasErr)
+var errNotScheduledCall = coreerrors.Register("only callable by scheduled calls").Create()
+
+// executeScheduledCall executes an EVM call registered with
+// ISCSandbox::schedule. Like any other call to the EVM from ISC, the call is
+// not recorded in the EVM block.
+func executeScheduledCall(ctx isc.Sandbox) dict.Dict {
+	if _, ok := ctx.Request().(isc.ScheduledRequest); !ok {
+		panic(errNotScheduledCall)
 	}
 
-	return result(res.ReturnData)
+	ctx.Privileged().GasBurnEnable(false)
+	defer ctx.Privileged().GasBurnEnable(true)
+
+	callMsg, err := evmtypes.DecodeCallMsg(ctx.Params().Get(evm.FieldCallMsg))
+	ctx.RequireNoError(err)
+	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), callMsg.From))
+
+	// the sender may be an EOA with pending transactions: a scheduled call
+	// must not affect its nonce
+	stateDB := emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(ctx.State()))
+	nonce := emulator.GetNonce(stateDB, callMsg.From)
+	defer emulator.SetNonce(stateDB, callMsg.From, nonce)
+
+	return simulateCall(ctx, createEmulator(ctx), callMsg, false)
+}
+
+// simulateCall executes the call keeping its changes to the state, and
+// returns the receipt along with the result, so that the caller can collect
+// the emitted logs. If record is true, the call is also recorded in the EVM
+// block as an impersonated transaction.
+func simulateCall(ctx isc.Sandbox, emu *emulator.EVMEmulator, callMsg ethereum.CallMsg, record bool) dict.Dict {
+	tx, receipt, res, err := emu.SimulateCall(callMsg, ctx.EVMCallOverrides(), getTracer(ctx, emu.BlockchainDB()))
+
+	revertErr := err
+	if revertErr == nil {
+		revertErr = tryGetRevertError(res)
+	}
+	if revertErr == nil {
+		revertErr = panicutil.CatchPanic(func() { burnCallGas(ctx, res.UsedGas) })
+	}
+
+	if record {
+		if revertErr != nil {
+			// mark receipt as failed
+			receipt.Status = types.ReceiptStatusFailed
+			// remove any events from the receipt
+			receipt.Logs = make([]*types.Log, 0)
+			receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		}
+		// make sure we always store the EVM tx/receipt in the BlockchainDB,
+		// even if the ISC request is reverted
+		chainInfo := ctx.ChainInfo()
+		ctx.Privileged().OnWriteReceipt(func(evmPartition kv.KVStore) {
+			saveExecutedTx(evmPartition, chainInfo, tx, callMsg.From, receipt)
+		})
+	}
+	ctx.RequireNoError(revertErr)
+
+	return dict.Dict{
+		evm.FieldResult:      res.ReturnData,
+		evm.FieldReceipt:     evmtypes.EncodeReceipt(receipt),
+		evm.FieldTransaction: evmtypes.EncodeTransaction(tx),
+	}
+}
+
+// burnCallGas burns the used EVM gas as it would be done for a normal request call
+func burnCallGas(ctx isc.Sandbox, usedGas uint64) {
+	gasRatio := getEVMGasRatio(ctx)
+	ctx.Privileged().GasBurnEnable(true)
+	gasErr := panicutil.CatchPanic(
+		func() {
+			ctx.Gas().Burn(gas.BurnCodeEVM1P, gas.EVMGasToISC(usedGas, &gasRatio))
+		},
+	)
+	ctx.Privileged().GasBurnEnable(false)
+	ctx.RequireNoError(gasErr)
 }
 
 func getEVMGasRatio(ctx isc.SandboxBase) util.Ratio32 {
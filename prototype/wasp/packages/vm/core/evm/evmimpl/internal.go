@@ -11,7 +11,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
-	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
@@ -30,12 +29,31 @@ func MintBlock(evmPartition kv.KVStore, chainInfo *isc.ChainInfo, blockTimestamp
 	createBlockchainDB(evmPartition, chainInfo).MintBlock(timestamp(blockTimestamp))
 }
 
-func getTracer(ctx isc.Sandbox) tracers.Tracer {
+// ApplyStateOverrides modifies the EVM state according to the state overrides
+// of a simulated block, before any of its requests is executed, so that the
+// changes are kept even if the requests of the block are reverted.
+// IMPORTANT: Must only be called from the ISC VM
+func ApplyStateOverrides(
+	evmPartition kv.KVStore,
+	accountsPartition kv.KVStore,
+	chainInfo *isc.ChainInfo,
+	overrides map[common.Address]*isc.EVMAccountOverride,
+) {
+	emulator.ApplyStateOverrides(&blockEmulatorContext{
+		evmPartition:      evmPartition,
+		accountsPartition: accountsPartition,
+		chainInfo:         chainInfo,
+	}, overrides)
+}
+
+// getTracer returns the tracer for the EVM tx that is about to be executed,
+// if any.
+func getTracer(ctx isc.Sandbox, bdb *emulator.BlockchainDB) tracers.Tracer {
 	tracer := ctx.EVMTracer()
 	if tracer == nil {
 		return nil
 	}
-	return tracer.Tracer
+	return tracer.TracerFor(bdb.GetPendingTxCount())
 }
 
 func createEmulator(ctx isc.Sandbox) *emulator.EVMEmulator {
@@ -50,12 +68,13 @@ func saveExecutedTx(
 	evmPartition kv.KVStore,
 	chainInfo *isc.ChainInfo,
 	tx *types.Transaction,
+	from common.Address,
 	receipt *types.Receipt,
 ) {
 	createBlockchainDB(evmPartition, chainInfo).AddTransaction(tx, receipt)
 	// make sure the nonce is incremented if the state was rolled back by the VM
 	if receipt.Status != types.ReceiptStatusSuccessful {
-		emulator.IncNonce(emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(evmPartition)), evmutil.MustGetSender(tx))
+		emulator.IncNonce(emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(evmPartition)), from)
 	}
 }
 
@@ -166,3 +185,65 @@ func (ctx *emulatorContext) WithoutGasBurn(f func()) {
 	f()
 	ctx.sandbox.Privileged().GasBurnEnable(prev)
 }
+
+// blockEmulatorContext is the emulator context used outside of any request,
+// where no gas is burned and the state cannot be reverted.
+type blockEmulatorContext struct {
+	evmPartition      kv.KVStore
+	accountsPartition kv.KVStore
+	chainInfo         *isc.ChainInfo
+}
+
+var _ emulator.Context = &blockEmulatorContext{}
+
+func (ctx *blockEmulatorContext) State() kv.KVStore {
+	return evm.EmulatorStateSubrealm(ctx.evmPartition)
+}
+
+func (*blockEmulatorContext) Timestamp() uint64 {
+	panic("not supported outside of a request")
+}
+
+func (ctx *blockEmulatorContext) GasLimits() emulator.GasLimits {
+	return gasLimits(ctx.chainInfo)
+}
+
+func (ctx *blockEmulatorContext) BlockKeepAmount() int32 {
+	return ctx.chainInfo.BlockKeepAmount
+}
+
+func (*blockEmulatorContext) MagicContracts() map[common.Address]vm.ISCMagicContract {
+	return nil
+}
+
+func (*blockEmulatorContext) TakeSnapshot() int {
+	panic("not supported outside of a request")
+}
+
+func (*blockEmulatorContext) RevertToSnapshot(int) {
+	panic("not supported outside of a request")
+}
+
+func (*blockEmulatorContext) BaseTokensDecimals() uint32 {
+	return parameters.L1().BaseToken.Decimals
+}
+
+func (ctx *blockEmulatorContext) GetBaseTokensBalance(addr common.Address) uint64 {
+	return accounts.GetBaseTokensBalance(ctx.accountsPartition, ctx.agentID(addr), ctx.chainInfo.ChainID)
+}
+
+func (ctx *blockEmulatorContext) AddBaseTokensBalance(addr common.Address, amount uint64) {
+	accounts.CreditToAccount(ctx.accountsPartition, ctx.agentID(addr), isc.NewAssetsBaseTokens(amount), ctx.chainInfo.ChainID)
+}
+
+func (ctx *blockEmulatorContext) SubBaseTokensBalance(addr common.Address, amount uint64) {
+	accounts.DebitFromAccount(ctx.accountsPartition, ctx.agentID(addr), isc.NewAssetsBaseTokens(amount), ctx.chainInfo.ChainID)
+}
+
+func (*blockEmulatorContext) WithoutGasBurn(f func()) {
+	f()
+}
+
+func (ctx *blockEmulatorContext) agentID(addr common.Address) isc.AgentID {
+	return isc.NewEthereumAddressAgentID(ctx.chainInfo.ChainID, addr)
+}
@@ -4,8 +4,12 @@
 package evmimpl
 
 import (
+	"time"
+
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/codec"
@@ -13,6 +17,8 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/iscmagic"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 // handler for ISCSandbox::getEntropy
@@ -101,6 +107,47 @@ func (h *magicContractHandler) Call(
 	return iscmagic.WrapISCDict(callRet)
 }
 
+// handler for ISCSandbox::schedule
+func (h *magicContractHandler) Schedule(
+	target common.Address,
+	data []byte,
+	deadline uint64,
+	period uint32,
+	gasLimit uint64,
+) uint32 {
+	callMsg := ethereum.CallMsg{
+		From: h.caller.Address(),
+		To:   &target,
+		Gas:  gasLimit,
+		Data: data,
+	}
+	gasRatio := getEVMGasRatio(h.ctx)
+	ret := h.call(
+		scheduler.Contract.Hname(),
+		scheduler.FuncSchedule.Hname(),
+		dict.Dict{
+			scheduler.ParamTargetContract:   codec.EncodeHname(evm.Contract.Hname()),
+			scheduler.ParamTargetEntryPoint: codec.EncodeHname(evm.FuncExecuteScheduledCall.Hname()),
+			scheduler.ParamCallParams:       dict.Dict{evm.FieldCallMsg: evmtypes.EncodeCallMsg(callMsg)}.Bytes(),
+			scheduler.ParamDeadline:         codec.EncodeTime(time.Unix(int64(deadline), 0)),
+			scheduler.ParamPeriod:           codec.EncodeUint32(period),
+			scheduler.ParamGasBudget:        codec.EncodeUint64(gas.EVMGasToISC(gasLimit, &gasRatio)),
+		},
+		nil,
+	)
+	return codec.MustDecodeUint32(ret.Get(scheduler.ParamScheduleID))
+}
+
+// handler for ISCSandbox::cancelSchedule
+func (h *magicContractHandler) CancelSchedule(scheduleID uint32) {
+	h.call(
+		scheduler.Contract.Hname(),
+		scheduler.FuncCancel.Hname(),
+		dict.Dict{scheduler.ParamScheduleID: codec.EncodeUint32(scheduleID)},
+		nil,
+	)
+}
+
 var errBaseTokensNotEnoughForStorageDeposit = coreerrors.Register("base tokens (%d) not enough to cover storage deposit (%d)")
 
 func (h *magicContractHandler) adjustStorageDeposit(req isc.RequestParameters) {
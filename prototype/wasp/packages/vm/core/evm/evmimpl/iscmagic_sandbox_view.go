@@ -17,6 +17,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/iscmagic"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
 )
 
 // handler for ISCSandbox::getChainID
@@ -46,6 +47,22 @@ func (h *magicContractHandler) GetIRC27NFTData(nftID iscmagic.NFTID) iscmagic.IR
 	}
 }
 
+// handler for ISCSandbox::getOracleValue
+func (h *magicContractHandler) GetOracleValue(feed string) iscmagic.ISCOracleValue {
+	r := h.callView(oracle.Contract.Hname(), oracle.ViewGetValue.Hname(), dict.Dict{
+		oracle.ParamFeed: codec.EncodeString(feed),
+	})
+	v, err := oracle.ValueFromBytes(r.Get(oracle.ParamValue))
+	h.ctx.RequireNoError(err)
+	return iscmagic.ISCOracleValue{
+		Value:      v.Value,
+		Timestamp:  v.Timestamp.Unix(),
+		BlockIndex: v.BlockIndex,
+		SignedData: v.SignedData,
+		Signature:  v.Signature,
+	}
+}
+
 // handler for ISCSandbox::getTimestampUnixSeconds
 func (h *magicContractHandler) GetTimestampUnixSeconds() int64 {
 	return h.ctx.Timestamp().Unix()
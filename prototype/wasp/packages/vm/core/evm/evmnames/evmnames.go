@@ -11,6 +11,8 @@ const (
 	FuncCallContract    = "callContract"
 	FuncGetChainID      = "getChainID"
 
+	FuncExecuteScheduledCall = "executeScheduledCall"
+
 	FuncRegisterERC20NativeToken              = "registerERC20NativeToken"
 	FuncRegisterERC20NativeTokenOnRemoteChain = "registerERC20NativeTokenOnRemoteChain"
 	FuncRegisterERC20ExternalNativeToken      = "registerERC20ExternalNativeToken"
@@ -30,6 +32,7 @@ const (
 	FieldBlockHash        = "bh"
 	FieldFilterQuery      = "fq"
 	FieldBlockKeepAmount  = "bk"
+	FieldReceipt          = "rc"
 
 	FieldNativeTokenID      = "N"
 	FieldFoundrySN          = "fs"
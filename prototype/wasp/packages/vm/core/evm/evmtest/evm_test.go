@@ -16,9 +16,11 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
@@ -32,7 +34,9 @@ import (
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/solo"
@@ -42,7 +46,10 @@ import (
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/iscmagic"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -197,6 +204,42 @@ func TestGasRatio(t *testing.T) {
 	require.Greater(t, res.iscReceipt.GasFeeCharged, initialGasFee)
 }
 
+func TestEVMContractFees(t *testing.T) {
+	env := initEVM(t)
+	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+	other := env.deployStorageContract(ethKey)
+
+	res, err := storage.store(43)
+	require.NoError(t, err)
+	require.Nil(t, res.iscReceipt.ContractFees)
+
+	// the overrides are keyed by the called EVM contract
+	fees := &governance.ContractFeesRecord{NoGasFee: true, OwnerFee: 1000}
+	err = env.setEVMContractFees(storage.address, fees, iscCallOptions{wallet: env.soloChain.OriginatorPrivateKey})
+	require.NoError(t, err)
+
+	res, err = storage.store(44)
+	require.NoError(t, err)
+	require.Equal(t, fees.Bytes(), res.iscReceipt.ContractFees)
+	require.EqualValues(t, 1000, res.iscReceipt.GasFeeCharged)
+
+	res, err = other.store(44)
+	require.NoError(t, err)
+	require.Nil(t, res.iscReceipt.ContractFees)
+	require.EqualValues(t, env.soloChain.GetGasFeePolicy().FeeFromGas(res.iscReceipt.GasBurned), res.iscReceipt.GasFeeCharged)
+
+	ret, err := env.soloChain.CallView(governance.Contract.Name, governance.ViewGetAllEVMContractFees.Name)
+	require.NoError(t, err)
+	require.Equal(t, fees.Bytes(), ret.Get(kv.Key(storage.address.Bytes())))
+
+	err = env.setEVMContractFees(storage.address, nil, iscCallOptions{wallet: env.soloChain.OriginatorPrivateKey})
+	require.NoError(t, err)
+	res, err = storage.store(45)
+	require.NoError(t, err)
+	require.Nil(t, res.iscReceipt.ContractFees)
+}
+
 // tests that the gas limits are correctly enforced based on the base tokens sent
 func TestGasLimit(t *testing.T) {
 	env := initEVM(t)
@@ -322,7 +365,7 @@ func TestEstimateGasWithoutFunds(t *testing.T) {
 		From: common.Address{},
 		To:   &iscTest.address,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 	t.Log(estimatedGas)
@@ -339,7 +382,7 @@ func TestLoopWithGasLeftEstimateGas(t *testing.T) {
 		From: ethAddr,
 		To:   &iscTest.address,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 	t.Log(estimatedGas)
@@ -375,7 +418,7 @@ func TestEstimateContractGas(t *testing.T) {
 	estimatedGas, err := env.evmChain.EstimateGas(ethereum.CallMsg{
 		From: contract.address,
 		To:   &ethAddr,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 }
@@ -393,7 +436,7 @@ func TestCallViewGasLimit(t *testing.T) {
 		Gas:  math.MaxUint64,
 		Data: callArguments,
 	})
-	_, err = loop.chain.evmChain.CallContract(callMsg, nil)
+	_, err = loop.chain.evmChain.CallContract(callMsg, nil, nil)
 	require.Contains(t, err.Error(), "out of gas")
 }
 
@@ -427,6 +470,34 @@ func TestISCChainOwnerID(t *testing.T) {
 	require.True(t, chainOwnerID.Equals(ret.MustUnwrap()))
 }
 
+func TestISCOracleValue(t *testing.T) {
+	env := initEVM(t)
+	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	// the feed does not exist yet
+	var ret struct {
+		iscmagic.ISCOracleValue
+	}
+	err := env.ISCMagicSandbox(ethKey).callView("getOracleValue", []interface{}{"price"}, &ret)
+	require.ErrorContains(t, err, "oracle feed price not found")
+
+	env.soloChain.SetOracleValues(isc.OracleValues{"price": 231_500})
+	require.NoError(t, env.soloChain.DepositBaseTokensToL2(isc.Million, nil))
+
+	err = env.ISCMagicSandbox(ethKey).callView("getOracleValue", []interface{}{"price"}, &ret)
+	require.NoError(t, err)
+	require.EqualValues(t, 231_500, ret.Value)
+	require.EqualValues(t, env.soloChain.LatestBlockIndex(), ret.BlockIndex)
+	require.EqualValues(t, env.soloChain.GetLatestBlockInfo().Timestamp.Unix(), ret.Timestamp)
+
+	data := &isc.OracleData{
+		Values:     isc.OracleValues{"price": ret.Value},
+		SignedData: ret.SignedData,
+		Signature:  ret.Signature,
+	}
+	require.NoError(t, data.Verify())
+}
+
 func TestISCTimestamp(t *testing.T) {
 	env := initEVM(t)
 	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -441,6 +512,67 @@ func TestISCTimestamp(t *testing.T) {
 	)
 }
 
+func TestISCSchedule(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+	iscSandbox := env.ISCMagicSandbox(ethKey)
+
+	callData, err := storage.abi.Pack("store", uint32(123))
+	require.NoError(t, err)
+	deadline := env.solo.GlobalTime().Add(time.Hour)
+	_, err = iscSandbox.callFn(nil, "schedule", storage.address, callData, uint64(deadline.Unix()), uint32(0), uint64(100_000))
+	require.NoError(t, err)
+
+	res, err := env.soloChain.CallView(scheduler.Contract.Name, scheduler.ViewGetScheduledCall.Name, scheduler.ParamScheduleID, uint32(0))
+	require.NoError(t, err)
+	call, err := isc.ScheduledCallFromBytes(res.Get(scheduler.ParamScheduledCall))
+	require.NoError(t, err)
+	require.True(t, call.Owner.Equals(isc.NewEthereumAddressAgentID(env.soloChain.ChainID, ethAddr)))
+	require.Equal(t, evm.FuncExecuteScheduledCall.Hname(), call.Target.EntryPoint)
+
+	_, err = storage.store(7)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, storage.retrieve())
+
+	// the next block executes the scheduled call before the regular transaction
+	env.solo.AdvanceClockBy(2 * time.Hour)
+	nonce := env.getNonce(ethAddr)
+	_, err = storage.store(8)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, storage.retrieve())
+	res, err = env.soloChain.CallView(scheduler.Contract.Name, scheduler.ViewGetScheduledCalls.Name)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, collections.NewArrayReadOnly(res, scheduler.ParamScheduledCalls).Len())
+
+	// the scheduled call is not recorded in the EVM block, and does not
+	// affect the nonce of the sender
+	require.EqualValues(t, nonce+1, env.getNonce(ethAddr))
+	block, err := env.evmChain.BlockByNumber(nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1)
+
+	// recurring calls can be cancelled
+	callData, err = storage.abi.Pack("store", uint32(456))
+	require.NoError(t, err)
+	_, err = iscSandbox.callFn(nil, "schedule", storage.address, callData, uint64(env.solo.GlobalTime().Add(time.Minute).Unix()), uint32(60), uint64(100_000))
+	require.NoError(t, err)
+	_, err = iscSandbox.callFn(nil, "cancelSchedule", uint32(1))
+	require.NoError(t, err)
+
+	env.solo.AdvanceClockBy(2 * time.Minute)
+	_, err = storage.store(9)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, storage.retrieve())
+
+	// only the owner can cancel a schedule
+	_, err = iscSandbox.callFn(nil, "schedule", storage.address, callData, uint64(env.solo.GlobalTime().Add(time.Minute).Unix()), uint32(60), uint64(100_000))
+	require.NoError(t, err)
+	otherKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, err = iscSandbox.callFn([]ethCallOptions{{sender: otherKey}}, "cancelSchedule", uint32(2))
+	require.ErrorContains(t, err, "unauthorized")
+}
+
 func TestISCCallView(t *testing.T) {
 	env := initEVM(t)
 	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -1677,7 +1809,7 @@ func TestSendEntireBalance(t *testing.T) {
 		To:    &someEthereumAddr,
 		Value: currentBalanceInEthDecimals,
 		Data:  []byte{},
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 
 	feePolicy := env.soloChain.GetGasFeePolicy()
@@ -1711,7 +1843,7 @@ func TestSolidityRevertMessage(t *testing.T) {
 		To:   &iscTest.address,
 		Gas:  100_000,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.ErrorContains(t, err, "execution reverted")
 
 	revertData, err := evmerrors.ExtractRevertData(err)
@@ -1930,6 +2062,11 @@ func TestGasPrice(t *testing.T) {
 	require.EqualValues(t, 0, price4)
 }
 
+func callTracerConfig() *tracers.TraceConfig {
+	tracer := "callTracer"
+	return &tracers.TraceConfig{Tracer: &tracer}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	env := initEVM(t)
 	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -1937,7 +2074,7 @@ func TestTraceTransaction(t *testing.T) {
 	traceLatestTx := func() *jsonrpc.CallFrame {
 		latestBlock, err := env.evmChain.BlockByNumber(nil)
 		require.NoError(t, err)
-		trace, err := env.evmChain.TraceTransaction(latestBlock.Transactions()[0].Hash(), &tracers.TraceConfig{})
+		trace, err := env.evmChain.TraceTransaction(latestBlock.Transactions()[0].Hash(), callTracerConfig())
 		require.NoError(t, err)
 		var ret jsonrpc.CallFrame
 		err = json.Unmarshal(trace.(json.RawMessage), &ret)
@@ -1965,6 +2102,178 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceBlock(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	ethKey2, ethAddr2 := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	storage := env.deployStorageContract(ethKey)
+	iscTest := env.deployISCTestContract(ethKey2)
+
+	// run two EVM txs in the same ISC block
+	tx1, err := storage.buildEthTx(nil, "store", uint32(43))
+	require.NoError(t, err)
+	tx2, err := iscTest.buildEthTx(nil, "triggerEvent", "Hi from EVM!")
+	require.NoError(t, err)
+	var reqs []isc.Request
+	for _, tx := range []*types.Transaction{tx1, tx2} {
+		req, err2 := isc.NewEVMOffLedgerTxRequest(env.soloChain.ChainID, tx)
+		require.NoError(t, err2)
+		reqs = append(reqs, req)
+	}
+	results := env.soloChain.RunOffLedgerRequests(reqs)
+	require.Len(t, results, 2)
+
+	latestBlock, err := env.evmChain.BlockByNumber(nil)
+	require.NoError(t, err)
+	require.Len(t, latestBlock.Transactions(), 2)
+
+	checkTraces := func(traces []*jsonrpc.TxTraceResult) {
+		require.Len(t, traces, 2)
+		for i, expected := range []struct {
+			tx   *types.Transaction
+			from common.Address
+			to   common.Address
+		}{
+			{tx1, ethAddr, storage.address},
+			{tx2, ethAddr2, iscTest.address},
+		} {
+			require.Empty(t, traces[i].Error)
+			require.Equal(t, expected.tx.Hash(), traces[i].TxHash)
+			var frame jsonrpc.CallFrame
+			err = json.Unmarshal(traces[i].Result.(json.RawMessage), &frame)
+			require.NoError(t, err)
+			require.EqualValues(t, expected.from, common.HexToAddress(frame.From))
+			require.EqualValues(t, expected.to, common.HexToAddress(frame.To))
+		}
+	}
+
+	traces, err := env.evmChain.TraceBlockByNumber(latestBlock.Number(), callTracerConfig())
+	require.NoError(t, err)
+	checkTraces(traces)
+
+	traces, err = env.evmChain.TraceBlockByHash(latestBlock.Hash(), callTracerConfig())
+	require.NoError(t, err)
+	checkTraces(traces)
+
+	// tracing a single tx only traces that tx
+	trace, err := env.evmChain.TraceTransaction(tx2.Hash(), callTracerConfig())
+	require.NoError(t, err)
+	var frame jsonrpc.CallFrame
+	err = json.Unmarshal(trace.(json.RawMessage), &frame)
+	require.NoError(t, err)
+	require.EqualValues(t, iscTest.address, common.HexToAddress(frame.To))
+}
+
+func TestTraceCall(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	iscTest := env.deployISCTestContract(ethKey)
+	blockNumber := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(env.getBlockNumber()))
+
+	callData, err := iscTest.abi.Pack("triggerEvent", "Hi from EVM!")
+	require.NoError(t, err)
+	trace, err := env.evmChain.TraceCall(iscTest.callMsg(ethereum.CallMsg{
+		From: ethAddr,
+		Data: callData,
+	}), &blockNumber, callTracerConfig())
+	require.NoError(t, err)
+
+	var frame jsonrpc.CallFrame
+	err = json.Unmarshal(trace.(json.RawMessage), &frame)
+	require.NoError(t, err)
+	require.EqualValues(t, ethAddr, common.HexToAddress(frame.From))
+	require.EqualValues(t, iscTest.address, common.HexToAddress(frame.To))
+	require.NotEmpty(t, frame.Calls)
+}
+
+func TestTraceTransactionGethTracers(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+	res, err := storage.store(43)
+	require.NoError(t, err)
+	txHash := res.tx.Hash()
+
+	trace := func(config *tracers.TraceConfig) json.RawMessage {
+		ret, err2 := env.evmChain.TraceTransaction(txHash, config)
+		require.NoError(t, err2)
+		return ret.(json.RawMessage)
+	}
+	withTracer := func(tracer string, tracerConfig string) *tracers.TraceConfig {
+		config := &tracers.TraceConfig{Tracer: &tracer}
+		if tracerConfig != "" {
+			config.TracerConfig = json.RawMessage(tracerConfig)
+		}
+		return config
+	}
+
+	type account struct {
+		Balance *hexutil.Big                `json:"balance"`
+		Nonce   uint64                      `json:"nonce"`
+		Code    hexutil.Bytes               `json:"code"`
+		Storage map[common.Hash]common.Hash `json:"storage"`
+	}
+	slot0 := common.Hash{}
+
+	t.Run("prestateTracer", func(t *testing.T) {
+		var pre map[common.Address]*account
+		require.NoError(t, json.Unmarshal(trace(withTracer("prestateTracer", "")), &pre))
+		require.Contains(t, pre, ethAddr)
+		require.Contains(t, pre, storage.address)
+		require.NotEmpty(t, pre[storage.address].Code)
+		require.EqualValues(t, 42, pre[storage.address].Storage[slot0].Big().Uint64())
+	})
+
+	t.Run("prestateTracer diffMode", func(t *testing.T) {
+		var diff struct {
+			Pre  map[common.Address]*account `json:"pre"`
+			Post map[common.Address]*account `json:"post"`
+		}
+		require.NoError(t, json.Unmarshal(trace(withTracer("prestateTracer", `{"diffMode": true}`)), &diff))
+		require.EqualValues(t, 42, diff.Pre[storage.address].Storage[slot0].Big().Uint64())
+		require.EqualValues(t, 43, diff.Post[storage.address].Storage[slot0].Big().Uint64())
+	})
+
+	t.Run("4byteTracer", func(t *testing.T) {
+		var ids map[string]int
+		require.NoError(t, json.Unmarshal(trace(withTracer("4byteTracer", "")), &ids))
+		selector := hexutil.Encode(storage.abi.Methods["store"].ID)
+		require.Equal(t, map[string]int{selector + "-32": 1}, ids)
+	})
+
+	t.Run("structLogger", func(t *testing.T) {
+		var result logger.ExecutionResult
+		require.NoError(t, json.Unmarshal(trace(nil), &result))
+		require.False(t, result.Failed)
+		require.NotZero(t, result.Gas)
+		require.NotEmpty(t, result.StructLogs)
+		var sstore *logger.StructLogRes
+		for i := range result.StructLogs {
+			if result.StructLogs[i].Op == "SSTORE" {
+				sstore = &result.StructLogs[i]
+			}
+		}
+		require.NotNil(t, sstore)
+		require.NotNil(t, sstore.Stack)
+		require.NotEmpty(t, *sstore.Storage)
+		require.Nil(t, sstore.Memory)
+
+		// options are embedded in the main config object
+		result = logger.ExecutionResult{}
+		require.NoError(t, json.Unmarshal(trace(&tracers.TraceConfig{Config: &logger.Config{
+			EnableMemory: true,
+			DisableStack: true,
+			Limit:        5,
+		}}), &result))
+		require.Len(t, result.StructLogs, 5)
+		for _, l := range result.StructLogs {
+			require.Nil(t, l.Stack)
+		}
+		require.NotNil(t, result.StructLogs[len(result.StructLogs)-1].Memory)
+	})
+}
+
 func TestMagicContractExamples(t *testing.T) {
 	env := initEVM(t)
 	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -157,6 +157,18 @@ func (e *soloChainEnv) setEVMGasRatio(newGasRatio util.Ratio32, opts ...iscCallO
 	return err
 }
 
+func (e *soloChainEnv) setEVMContractFees(contract common.Address, fees *governance.ContractFeesRecord, opts ...iscCallOptions) error {
+	opt := e.parseISCCallOptions(opts)
+	params := dict.Dict{governance.ParamEVMContractAddress: contract.Bytes()}
+	if fees != nil {
+		params[governance.ParamContractFeesBytes] = fees.Bytes()
+	}
+	req := solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFees.Name, params).
+		WithMaxAffordableGasBudget()
+	_, err := e.soloChain.PostRequestSync(req, opt.wallet)
+	return err
+}
+
 func (e *soloChainEnv) setFeePolicy(p gas.FeePolicy, opts ...iscCallOptions) error { //nolint:unparam
 	opt := e.parseISCCallOptions(opts)
 	req := solo.NewCallParams(
@@ -322,7 +334,7 @@ func (e *soloChainEnv) deployContract(creator *ecdsa.PrivateKey, abiJSON string,
 		From:  creatorAddress,
 		Value: value,
 		Data:  data,
-	}, nil)
+	}, nil, nil)
 	require.NoError(e.t, err)
 
 	tx, err := types.SignTx(
@@ -435,7 +447,7 @@ func (e *evmContractInstance) parseEthCallOptions(opts []ethCallOptions, callDat
 			GasPrice: opt.gasPrice,
 			Value:    opt.value,
 			Data:     callData,
-		}, nil)
+		}, nil, nil)
 		if err != nil {
 			return opt, fmt.Errorf("error estimating gas limit: %w", e.chain.resolveError(err))
 		}
@@ -515,7 +527,7 @@ func (e *evmContractInstance) callView(fnName string, args []interface{}, v inte
 	if len(blockNumberOrHash) > 0 {
 		bn = &blockNumberOrHash[0]
 	}
-	ret, err := e.chain.evmChain.CallContract(callMsg, bn)
+	ret, err := e.chain.evmChain.CallContract(callMsg, bn, nil)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,10 @@ var (
 	// in order to process a view call or gas estimation (e.g. eth_call, eth_estimateGas).
 	FuncCallContract = coreutil.Func(evmnames.FuncCallContract)
 
+	// FuncExecuteScheduledCall is the target of the calls registered with
+	// ISCSandbox::schedule. It is called by the VM when the call is due.
+	FuncExecuteScheduledCall = coreutil.Func(evmnames.FuncExecuteScheduledCall)
+
 	FuncGetChainID = coreutil.ViewFunc(evmnames.FuncGetChainID)
 
 	FuncRegisterERC20NativeToken              = coreutil.Func(evmnames.FuncRegisterERC20NativeToken)
@@ -43,6 +47,7 @@ const (
 	FieldBlockHash        = evmnames.FieldBlockHash
 	FieldFilterQuery      = evmnames.FieldFilterQuery
 	FieldBlockKeepAmount  = evmnames.FieldBlockKeepAmount // int32
+	FieldReceipt          = evmnames.FieldReceipt
 
 	FieldNativeTokenID      = evmnames.FieldNativeTokenID
 	FieldFoundrySN          = evmnames.FieldFoundrySN         // uint32
@@ -67,6 +67,24 @@ interface ISCSandbox {
         ISCAssets memory allowance
     ) external returns (ISCDict memory);
 
+    // Schedule a call to `target` with the given `data`, executed on behalf of
+    // the caller in the first block with a timestamp not before `deadline`
+    // (Unix seconds). If `period` is not 0, the call is repeated every `period`
+    // seconds until it is cancelled.
+    // The gas fee of each execution (up to `gasLimit`) is charged to the
+    // caller's L2 account. The execution does not modify the caller's nonce.
+    // Returns the ID of the schedule.
+    function schedule(
+        address target,
+        bytes memory data,
+        uint64 deadline,
+        uint32 period,
+        uint64 gasLimit
+    ) external returns (uint32);
+
+    // Cancel a call scheduled by the caller.
+    function cancelSchedule(uint32 scheduleID) external;
+
     // Call a view entry point of an ISC contract on the same chain.
     // The called entry point will have the `evm` core contract as caller.
     function callView(
@@ -102,6 +120,12 @@ interface ISCSandbox {
         view
         returns (NativeTokenScheme memory);
 
+    // Get the latest value of an oracle feed, along with the proof of its provenance
+    function getOracleValue(string memory feed)
+        external
+        view
+        returns (ISCOracleValue memory);
+
     // Get information about an on-chain NFT
     function getNFTData(NFTID id) external view returns (ISCNFT memory);
 
@@ -135,6 +135,18 @@ struct ISCTokenProperties {
     uint256 totalSupply;
 }
 
+// The latest value of an oracle feed. signedData is the message signed by
+// the committee (the anchor output ID followed by all the values agreed for
+// the block), and signature is the BLS threshold signature of signedData,
+// prefixed by the public key of the committee.
+struct ISCOracleValue {
+    int64 value;
+    int64 timestamp;
+    uint32 blockIndex;
+    bytes signedData;
+    bytes signature;
+}
+
 library ISCTypes {
     function L1AddressType(
         L1Address memory addr
@@ -376,6 +376,15 @@ func (i *ISCSendOptions) Unwrap() isc.SendOptions {
 	return ret
 }
 
+// ISCOracleValue matches the struct definition in ISCTypes.sol
+type ISCOracleValue struct {
+	Value      int64
+	Timestamp  int64
+	BlockIndex uint32
+	SignedData []byte
+	Signature  []byte
+}
+
 type ISCTokenProperties struct {
 	Name         string
 	TickerSymbol string
@@ -33,6 +33,12 @@ func EmulatorStateSubrealmR(evmPartition kv.KVStoreReader) kv.KVStoreReader {
 	return subrealm.NewReadOnly(evmPartition, keyEmulatorState)
 }
 
+// EmulatorStateKey returns the key in the chain state of an entry stored by
+// the emulator
+func EmulatorStateKey(key kv.Key) kv.Key {
+	return kv.Key(Contract.Hname().Bytes()) + keyEmulatorState + key
+}
+
 func ISCMagicSubrealm(evmPartition kv.KVStore) kv.KVStore {
 	return subrealm.New(evmPartition, keyISCMagic)
 }
@@ -4,17 +4,31 @@
 package governance
 
 import (
+	"fmt"
 	"io"
+	"math"
 
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/util/rwutil"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
-// ContractFeesRecord is a structure which contains the fee information for a contract
+// ContractFeesRecord is a structure which contains the fee information for a contract.
+// It overrides the fee policy of the chain for the requests sent to the contract.
 type ContractFeesRecord struct {
-	// Chain owner part of the fee. If it is 0, it means chain-global default is in effect
+	// Chain owner part of the fee: a fixed amount of base tokens charged for each
+	// request on top of the gas fee. If it is 0, no fixed fee is charged.
 	OwnerFee uint64
-	// Validator part of the fee. If it is 0, it means chain-global default is in effect
+	// Validator part of the fee: a fixed amount of base tokens charged for each
+	// request on top of the gas fee. If it is 0, no fixed fee is charged.
 	ValidatorFee uint64
+	// NoGasFee makes the gas free for the requests to the contract. The gas is
+	// still burned, and limited by the gas budget of the request.
+	NoGasFee bool
+	// GasPerTokenMultiplier multiplies the GasPerToken of the chain fee policy,
+	// e.g. 2:1 makes the gas twice as cheap. If it is 0:0, the chain-global
+	// default is in effect.
+	GasPerTokenMultiplier util.Ratio32
 }
 
 func ContractFeesRecordFromBytes(data []byte) (*ContractFeesRecord, error) {
@@ -25,10 +39,66 @@ func (p *ContractFeesRecord) Bytes() []byte {
 	return rwutil.WriteToBytes(p)
 }
 
+func (p *ContractFeesRecord) IsValid() bool {
+	return p.GasPerTokenMultiplier.IsValid()
+}
+
+// FixedFee returns the total fixed fee charged for each request
+func (p *ContractFeesRecord) FixedFee() uint64 {
+	if p.OwnerFee > math.MaxUint64-p.ValidatorFee {
+		return math.MaxUint64
+	}
+	return p.OwnerFee + p.ValidatorFee
+}
+
+// FeePolicy returns the fee policy applied to the gas burned by the requests
+// to the contract, given the fee policy of the chain.
+func (p *ContractFeesRecord) FeePolicy(chainPolicy *gas.FeePolicy) *gas.FeePolicy {
+	ret := *chainPolicy
+	switch {
+	case p.NoGasFee:
+		ret.GasPerToken = util.Ratio32{}
+	case !p.GasPerTokenMultiplier.IsZero() && !chainPolicy.GasPerToken.IsZero():
+		ret.GasPerToken = mulRatio32(chainPolicy.GasPerToken, p.GasPerTokenMultiplier)
+	}
+	return &ret
+}
+
+// mulRatio32 multiplies two ratios, reducing the precision of the result if
+// it does not fit in a Ratio32
+func mulRatio32(r1, r2 util.Ratio32) util.Ratio32 {
+	a := uint64(r1.A) * uint64(r2.A)
+	b := uint64(r1.B) * uint64(r2.B)
+	if d := gcd(a, b); d > 1 {
+		a /= d
+		b /= d
+	}
+	if m := max(a, b); m > math.MaxUint32 {
+		scale := m/math.MaxUint32 + 1
+		a = max(a/scale, 1)
+		b = max(b/scale, 1)
+	}
+	return util.Ratio32{A: uint32(a), B: uint32(b)}
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func (p *ContractFeesRecord) String() string {
+	return fmt.Sprintf("ContractFeesRecord{OwnerFee: %d, ValidatorFee: %d, NoGasFee: %v, GasPerTokenMultiplier: %s}",
+		p.OwnerFee, p.ValidatorFee, p.NoGasFee, p.GasPerTokenMultiplier)
+}
+
 func (p *ContractFeesRecord) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
 	p.OwnerFee = rr.ReadAmount64()
 	p.ValidatorFee = rr.ReadAmount64()
+	p.NoGasFee = rr.ReadBool()
+	rr.Read(&p.GasPerTokenMultiplier)
 	return rr.Err
 }
 
@@ -36,5 +106,7 @@ func (p *ContractFeesRecord) Write(w io.Writer) error {
 	ww := rwutil.NewWriter(w)
 	ww.WriteAmount64(p.OwnerFee)
 	ww.WriteAmount64(p.ValidatorFee)
+	ww.WriteBool(p.NoGasFee)
+	ww.Write(&p.GasPerTokenMultiplier)
 	return ww.Err
 }
@@ -0,0 +1,126 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governanceimpl
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+)
+
+var (
+	errInvalidContractFees      = coreerrors.Register("invalid contract fees").Create()
+	errContractFeesNotSupported = coreerrors.Register("contract fees cannot be set for contract %s").Create
+	errContractNotFound         = coreerrors.Register("contract %s not found").Create
+	errInvalidEVMAddress        = coreerrors.Register("invalid EVM address").Create()
+)
+
+// setContractFees sets the fee overrides for the requests sent to a contract.
+// The overrides of EVM transactions are keyed by the address of the called
+// EVM contract, so the evm contract itself cannot be configured.
+// Input:
+// - governance.ParamContractHname: isc.Hname, optional if
+// governance.ParamEVMContractAddress is provided
+// - governance.ParamEVMContractAddress: EVM address, optional
+// - governance.ParamContractFeesBytes: ContractFeesRecord, optional; if not
+// provided, the overrides are removed and the fee policy of the chain applies
+func setContractFees(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+
+	params := ctx.Params()
+	evmContract, isEVM := evmContractFromParams(params)
+	var contract isc.Hname
+	if !isEVM {
+		contract = params.MustGetHname(governance.ParamContractHname)
+		if contract == coreutil.CoreContractEVMHname {
+			panic(errContractFeesNotSupported(contract.String()))
+		}
+	}
+	var fees *governance.ContractFeesRecord
+	if data := params.MustGetBytes(governance.ParamContractFeesBytes, nil); data != nil {
+		var err error
+		fees, err = governance.ContractFeesRecordFromBytes(data)
+		if err != nil || !fees.IsValid() {
+			panic(errInvalidContractFees)
+		}
+	}
+	if isEVM {
+		governance.SetEVMContractFees(ctx.State(), evmContract, fees)
+		return nil
+	}
+	if fees != nil {
+		found := codec.MustDecodeBool(ctx.CallView(root.Contract.Hname(), root.ViewFindContract.Hname(), dict.Dict{
+			root.ParamHname: contract.Bytes(),
+		}).Get(root.ParamContractFound), false)
+		if !found {
+			panic(errContractNotFound(contract.String()))
+		}
+	}
+	governance.SetContractFees(ctx.State(), contract, fees)
+	return nil
+}
+
+// evmContractFromParams returns the EVM contract address, if provided
+func evmContractFromParams(params *isc.Params) (common.Address, bool) {
+	data := params.MustGetBytes(governance.ParamEVMContractAddress, nil)
+	if data == nil {
+		return common.Address{}, false
+	}
+	if len(data) != common.AddressLength {
+		panic(errInvalidEVMAddress)
+	}
+	return common.BytesToAddress(data), true
+}
+
+// getContractFees returns the fee overrides of a contract
+// Input:
+// - governance.ParamContractHname: isc.Hname, optional if
+// governance.ParamEVMContractAddress is provided
+// - governance.ParamEVMContractAddress: EVM address, optional
+// Output:
+// - governance.ParamContractFeesBytes: ContractFeesRecord, or nil if the fee
+// policy of the chain applies
+func getContractFees(ctx isc.SandboxView) dict.Dict {
+	var fees *governance.ContractFeesRecord
+	if evmContract, ok := evmContractFromParams(ctx.Params()); ok {
+		fees = governance.MustGetEVMContractFees(ctx.StateR(), evmContract)
+	} else {
+		fees = governance.MustGetContractFees(ctx.StateR(), ctx.Params().MustGetHname(governance.ParamContractHname))
+	}
+	if fees == nil {
+		return nil
+	}
+	return dict.Dict{governance.ParamContractFeesBytes: fees.Bytes()}
+}
+
+// getAllContractFees returns the fee overrides of all contracts
+// Output: map Hname => ContractFeesRecord
+func getAllContractFees(ctx isc.SandboxView) dict.Dict {
+	all, err := governance.GetAllContractFees(ctx.StateR())
+	ctx.RequireNoError(err)
+	ret := dict.New()
+	for contract, fees := range all {
+		ret.Set(kv.Key(contract.Bytes()), fees.Bytes())
+	}
+	return ret
+}
+
+// getAllEVMContractFees returns the fee overrides of all EVM contracts
+// Output: map EVM address => ContractFeesRecord
+func getAllEVMContractFees(ctx isc.SandboxView) dict.Dict {
+	all, err := governance.GetAllEVMContractFees(ctx.StateR())
+	ctx.RequireNoError(err)
+	ret := dict.New()
+	for contract, fees := range all {
+		ret.Set(kv.Key(contract.Bytes()), fees.Bytes())
+	}
+	return ret
+}
@@ -72,3 +72,25 @@ func getGasLimits(ctx isc.SandboxView) dict.Dict {
 		governance.ParamGasLimitsBytes: governance.MustGetGasLimits(ctx.StateR()).Bytes(),
 	}
 }
+
+// setBaseFeeConfig enables, disables or configures the dynamic adjustment of
+// the gas price. When enabled, the base gas price starts from the GasPerToken
+// of the fee policy.
+// Input:
+// - governance.ParamBaseFeeConfigBytes must contain bytes of the gas.BaseFeeConfig record
+func setBaseFeeConfig(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+
+	data := ctx.Params().MustGetBytes(governance.ParamBaseFeeConfigBytes)
+	_, err := gas.BaseFeeConfigFromBytes(data)
+	ctx.RequireNoError(err)
+
+	ctx.State().Set(governance.VarBaseFeeConfigBytes, data)
+	return nil
+}
+
+func getBaseFeeConfig(ctx isc.SandboxView) dict.Dict {
+	return dict.Dict{
+		governance.ParamBaseFeeConfigBytes: governance.MustGetBaseFeeConfig(ctx.StateR()).Bytes(),
+	}
+}
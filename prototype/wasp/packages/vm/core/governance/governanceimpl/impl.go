@@ -34,6 +34,14 @@ var Processor = governance.Contract.Processor(nil,
 	governance.ViewGetEVMGasRatio.WithHandler(getEVMGasRatio),
 	governance.FuncSetGasLimits.WithHandler(setGasLimits),
 	governance.ViewGetGasLimits.WithHandler(getGasLimits),
+	governance.FuncSetBaseFeeConfig.WithHandler(setBaseFeeConfig),
+	governance.ViewGetBaseFeeConfig.WithHandler(getBaseFeeConfig),
+
+	// contract fees
+	governance.FuncSetContractFees.WithHandler(setContractFees),
+	governance.ViewGetContractFees.WithHandler(getContractFees),
+	governance.ViewGetAllContractFees.WithHandler(getAllContractFees),
+	governance.ViewGetAllEVMContractFees.WithHandler(getAllEVMContractFees),
 
 	// chain info
 	governance.ViewGetChainInfo.WithHandler(getChainInfo),
@@ -33,6 +33,16 @@ var (
 	ViewGetFeePolicy = coreutil.ViewFunc("getFeePolicy")
 	ViewGetGasLimits = coreutil.ViewFunc("getGasLimits")
 
+	// dynamic base fee
+	FuncSetBaseFeeConfig = coreutil.Func("setBaseFeeConfig")
+	ViewGetBaseFeeConfig = coreutil.ViewFunc("getBaseFeeConfig")
+
+	// contract fees
+	FuncSetContractFees       = coreutil.Func("setContractFees")
+	ViewGetContractFees       = coreutil.ViewFunc("getContractFees")
+	ViewGetAllContractFees    = coreutil.ViewFunc("getAllContractFees")
+	ViewGetAllEVMContractFees = coreutil.ViewFunc("getAllEVMContractFees")
+
 	// evm fees
 	FuncSetEVMGasRatio = coreutil.Func("setEVMGasRatio")
 	ViewGetEVMGasRatio = coreutil.ViewFunc("getEVMGasRatio")
@@ -73,6 +83,15 @@ const (
 	VarGasFeePolicyBytes = "g"
 	VarGasLimitsBytes    = "l"
 
+	// dynamic base fee
+	VarBaseFeeConfigBytes = "bf"
+	VarBaseGasPerToken    = "bg"
+
+	// contract fees: map Hname => ContractFeesRecord
+	VarContractFees = "cf"
+	// contract fees of EVM contracts: map EVM address => ContractFeesRecord
+	VarEVMContractFees = "ce"
+
 	// access nodes
 	VarAccessNodes          = "an"
 	VarAccessNodeCandidates = "ac"
@@ -104,6 +123,14 @@ const (
 	ParamEVMGasRatio    = "e"
 	ParamGasLimitsBytes = "l"
 
+	// dynamic base fee
+	ParamBaseFeeConfigBytes = "bf"
+
+	// contract fees
+	ParamContractHname      = "ch"
+	ParamContractFeesBytes  = "cf"
+	ParamEVMContractAddress = "ea"
+
 	// chain info
 	ParamChainID = "c"
 
@@ -4,6 +4,8 @@
 package governance
 
 import (
+	"github.com/ethereum/go-ethereum/common"
+
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
@@ -36,7 +38,7 @@ func GetChainInfo(state kv.KVStoreReader, chainID isc.ChainID) (*isc.ChainInfo,
 		return nil, err
 	}
 
-	if ret.GasFeePolicy, err = GetGasFeePolicy(state); err != nil {
+	if ret.GasFeePolicy, err = GetCurrentGasFeePolicy(state); err != nil {
 		return nil, err
 	}
 
@@ -45,6 +47,7 @@ func GetChainInfo(state kv.KVStoreReader, chainID isc.ChainID) (*isc.ChainInfo,
 	}
 
 	ret.BlockKeepAmount = GetBlockKeepAmount(state)
+
 	if ret.PublicURL, err = GetPublicURL(state); err != nil {
 		return nil, err
 	}
@@ -103,6 +106,180 @@ func GetGasLimits(state kv.KVStoreReader) (*gas.Limits, error) {
 	return gas.LimitsFromBytes(data)
 }
 
+func MustGetBaseFeeConfig(state kv.KVStoreReader) *gas.BaseFeeConfig {
+	c, err := GetBaseFeeConfig(state)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func GetBaseFeeConfig(state kv.KVStoreReader) (*gas.BaseFeeConfig, error) {
+	data := state.Get(VarBaseFeeConfigBytes)
+	if data == nil {
+		return gas.DefaultBaseFeeConfig(), nil
+	}
+	return gas.BaseFeeConfigFromBytes(data)
+}
+
+// GetCurrentGasFeePolicy returns the fee policy in effect: if the dynamic base
+// fee is enabled, its GasPerToken is the current base gas price
+func GetCurrentGasFeePolicy(state kv.KVStoreReader) (*gas.FeePolicy, error) {
+	policy, err := GetGasFeePolicy(state)
+	if err != nil {
+		return nil, err
+	}
+	config, err := GetBaseFeeConfig(state)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled || policy.GasPerToken.HasZeroComponent() {
+		return policy, nil
+	}
+	base, err := codec.DecodeRatio32(state.Get(VarBaseGasPerToken), policy.GasPerToken)
+	if err != nil {
+		return nil, err
+	}
+	// the GasPerToken of the policy is the lowest gas price
+	policy.GasPerToken = gas.MaxGasPrice(base, policy.GasPerToken)
+	return policy, nil
+}
+
+func MustGetCurrentGasFeePolicy(state kv.KVStoreReader) *gas.FeePolicy {
+	policy, err := GetCurrentGasFeePolicy(state)
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
+// UpdateBaseGasPrice calculates the base gas price of the next block, given
+// the gas burned in the current one. It is called by the VM when closing the
+// block.
+func UpdateBaseGasPrice(state kv.KVStore, gasBurned uint64) {
+	config := MustGetBaseFeeConfig(state)
+	if !config.Enabled {
+		if state.Has(VarBaseGasPerToken) {
+			state.Del(VarBaseGasPerToken)
+		}
+		return
+	}
+	policy := MustGetGasFeePolicy(state)
+	current := MustGetCurrentGasFeePolicy(state).GasPerToken
+	next := config.NextGasPerToken(current, gasBurned, policy.GasPerToken)
+	state.Set(VarBaseGasPerToken, codec.EncodeRatio32(next))
+}
+
+func contractFeesMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarContractFees)
+}
+
+func contractFeesMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarContractFees)
+}
+
+// GetContractFees returns the fee overrides of the contract, or nil if the
+// fee policy of the chain applies
+func GetContractFees(state kv.KVStoreReader, contract isc.Hname) (*ContractFeesRecord, error) {
+	data := contractFeesMapR(state).GetAt(contract.Bytes())
+	if data == nil {
+		return nil, nil
+	}
+	return ContractFeesRecordFromBytes(data)
+}
+
+func MustGetContractFees(state kv.KVStoreReader, contract isc.Hname) *ContractFeesRecord {
+	fees, err := GetContractFees(state, contract)
+	if err != nil {
+		panic(err)
+	}
+	return fees
+}
+
+// GetAllContractFees returns the fee overrides of all contracts
+func GetAllContractFees(state kv.KVStoreReader) (map[isc.Hname]*ContractFeesRecord, error) {
+	ret := map[isc.Hname]*ContractFeesRecord{}
+	var err error
+	contractFeesMapR(state).Iterate(func(key []byte, value []byte) bool {
+		var hname isc.Hname
+		if hname, err = isc.HnameFromBytes(key); err != nil {
+			return false
+		}
+		if ret[hname], err = ContractFeesRecordFromBytes(value); err != nil {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SetContractFees sets the fee overrides of the contract, or removes them if
+// fees is nil
+func SetContractFees(state kv.KVStore, contract isc.Hname, fees *ContractFeesRecord) {
+	if fees == nil {
+		contractFeesMap(state).DelAt(contract.Bytes())
+		return
+	}
+	contractFeesMap(state).SetAt(contract.Bytes(), fees.Bytes())
+}
+
+func evmContractFeesMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarEVMContractFees)
+}
+
+func evmContractFeesMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarEVMContractFees)
+}
+
+// GetEVMContractFees returns the fee overrides of the EVM contract deployed at
+// the given address, or nil if the fee policy of the chain applies
+func GetEVMContractFees(state kv.KVStoreReader, contract common.Address) (*ContractFeesRecord, error) {
+	data := evmContractFeesMapR(state).GetAt(contract.Bytes())
+	if data == nil {
+		return nil, nil
+	}
+	return ContractFeesRecordFromBytes(data)
+}
+
+func MustGetEVMContractFees(state kv.KVStoreReader, contract common.Address) *ContractFeesRecord {
+	fees, err := GetEVMContractFees(state, contract)
+	if err != nil {
+		panic(err)
+	}
+	return fees
+}
+
+// GetAllEVMContractFees returns the fee overrides of all EVM contracts
+func GetAllEVMContractFees(state kv.KVStoreReader) (map[common.Address]*ContractFeesRecord, error) {
+	ret := map[common.Address]*ContractFeesRecord{}
+	var err error
+	evmContractFeesMapR(state).Iterate(func(key []byte, value []byte) bool {
+		var fees *ContractFeesRecord
+		if fees, err = ContractFeesRecordFromBytes(value); err != nil {
+			return false
+		}
+		ret[common.BytesToAddress(key)] = fees
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SetEVMContractFees sets the fee overrides of the EVM contract deployed at
+// the given address, or removes them if fees is nil
+func SetEVMContractFees(state kv.KVStore, contract common.Address, fees *ContractFeesRecord) {
+	if fees == nil {
+		evmContractFeesMap(state).DelAt(contract.Bytes())
+		return
+	}
+	evmContractFeesMap(state).SetAt(contract.Bytes(), fees.Bytes())
+}
+
 func GetBlockKeepAmount(state kv.KVStoreReader) int32 {
 	return codec.MustDecodeInt32(state.Get(VarBlockKeepAmount), DefaultBlockKeepAmount)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 type StateAccess struct {
@@ -58,6 +59,11 @@ func (sa *StateAccess) ChainInfo(chainID isc.ChainID) *isc.ChainInfo {
 	return MustGetChainInfo(sa.state, chainID)
 }
 
+// BaseFeeConfig returns the configuration of the dynamic gas price
+func (sa *StateAccess) BaseFeeConfig() *gas.BaseFeeConfig {
+	return MustGetBaseFeeConfig(sa.state)
+}
+
 func (sa *StateAccess) ChainOwnerID() isc.AgentID {
 	return mustGetChainOwnerID(sa.state)
 }
@@ -2,6 +2,9 @@ package allmigrations
 
 import (
 	"github.com/iotaledger/wasp/packages/vm/core/migrations"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 )
 
 var DefaultScheme = &migrations.MigrationScheme{
@@ -14,5 +17,9 @@ var DefaultScheme = &migrations.MigrationScheme{
 	// incremented.
 	// Old migrations can be pruned; for each migration pruned increment
 	// BaseSchemaVersion by one.
-	Migrations: []migrations.Migration{},
+	Migrations: []migrations.Migration{
+		migrations.DeployCoreContract(scheduler.Contract),
+		migrations.DeployCoreContract(oracle.Contract),
+		migrations.DeployCoreContract(sponsorship.Contract),
+	},
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
 	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
 )
 
 type Migration struct {
@@ -19,3 +20,22 @@ type MigrationScheme struct {
 func (m *MigrationScheme) LatestSchemaVersion() uint32 {
 	return m.BaseSchemaVersion + uint32(len(m.Migrations))
 }
+
+// DeployCoreContract registers a core contract introduced after the chain was
+// created. The initial state of the contract must be empty, as it is not
+// initialized here.
+func DeployCoreContract(contract *coreutil.ContractInfo) Migration {
+	return Migration{
+		Contract: root.Contract,
+		Apply: func(state kv.KVStore, log *logger.Logger) error {
+			registry := root.GetContractRegistry(state)
+			hname := contract.Hname()
+			if registry.HasAt(hname.Bytes()) {
+				return nil
+			}
+			registry.SetAt(hname.Bytes(), root.ContractRecordFromContractInfo(contract).Bytes())
+			log.Infof("%s core contract registered", contract.Name)
+			return nil
+		},
+	}
+}
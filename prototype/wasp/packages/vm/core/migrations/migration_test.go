@@ -0,0 +1,74 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/blob"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/errors"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/migrations/allmigrations"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
+)
+
+func TestDeployCoreContract(t *testing.T) {
+	log := testlogger.NewLogger(t)
+
+	// the state of a chain created before the migrations
+	oldContracts := []*coreutil.ContractInfo{
+		root.Contract,
+		blob.Contract,
+		accounts.Contract,
+		blocklog.Contract,
+		errors.Contract,
+		governance.Contract,
+		evm.Contract,
+	}
+	chainState := dict.New()
+	rootState := subrealm.New(chainState, kv.Key(root.Contract.Hname().Bytes()))
+	for _, c := range oldContracts {
+		root.GetContractRegistry(rootState).SetAt(c.Hname().Bytes(), root.ContractRecordFromContractInfo(c).Bytes())
+	}
+	root.SetSchemaVersion(rootState, allmigrations.DefaultScheme.BaseSchemaVersion)
+
+	applyAll := func() {
+		for _, m := range allmigrations.DefaultScheme.Migrations {
+			require.NoError(t, m.Apply(subrealm.New(chainState, kv.Key(m.Contract.Hname().Bytes())), log))
+		}
+	}
+	applyAll()
+
+	deployed := []*coreutil.ContractInfo{
+		scheduler.Contract,
+		oracle.Contract,
+		sponsorship.Contract,
+	}
+	for _, c := range deployed {
+		t.Run(c.Name, func(t *testing.T) {
+			rec := root.FindContract(rootState, c.Hname())
+			require.NotNil(t, rec)
+			require.Equal(t, c.Name, rec.Name)
+			require.Equal(t, c.ProgramHash, rec.ProgramHash)
+		})
+	}
+	for _, c := range oldContracts {
+		require.Equal(t, root.ContractRecordFromContractInfo(c), root.FindContract(rootState, c.Hname()))
+	}
+	require.EqualValues(t, len(oldContracts)+len(deployed), root.GetContractRegistryR(rootState).Len())
+
+	// applying them again is a no-op
+	applyAll()
+	require.EqualValues(t, len(oldContracts)+len(deployed), root.GetContractRegistryR(rootState).Len())
+}
@@ -0,0 +1,82 @@
+package oracle
+
+import (
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
+)
+
+var Processor = Contract.Processor(nil,
+	FuncSetFeedDecimals.WithHandler(setFeedDecimals),
+	ViewGetValue.WithHandler(getValue),
+	ViewGetValues.WithHandler(getValues),
+	ViewGetFeedDecimals.WithHandler(getFeedDecimals),
+)
+
+func SetInitialState(state kv.KVStore) {
+	// does not do anything
+}
+
+var (
+	errFeedNotFound    = coreerrors.Register("oracle feed %s not found").Create
+	errInvalidFeedName = coreerrors.Register("invalid oracle feed name %q").Create
+	errInvalidDecimals = coreerrors.Register("invalid decimals of oracle feed: %d > %d").Create
+)
+
+// setFeedDecimals sets the decimals of an oracle feed, used by the nodes to
+// scale the observed values. Can only be called by the chain owner.
+// Input:
+// - ParamFeed: string
+// - ParamDecimals: uint8
+func setFeedDecimals(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+	feed := ctx.Params().MustGetString(ParamFeed)
+	if feed == "" || len(feed) > isc.MaxOracleFeedNameLength {
+		panic(errInvalidFeedName(feed))
+	}
+	decimals := codec.MustDecodeUint8(ctx.Params().Get(ParamDecimals))
+	if decimals > MaxDecimals {
+		panic(errInvalidDecimals(decimals, MaxDecimals))
+	}
+	SetDecimals(ctx.State(), feed, decimals)
+	return nil
+}
+
+// getFeedDecimals returns the decimals of an oracle feed
+// Input:
+// - ParamFeed: string
+// Output:
+// - ParamDecimals: uint8
+func getFeedDecimals(ctx isc.SandboxView) dict.Dict {
+	feed := ctx.Params().MustGetString(ParamFeed)
+	return dict.Dict{ParamDecimals: codec.EncodeUint8(GetDecimals(ctx.StateR(), feed))}
+}
+
+// getValue returns the latest value of an oracle feed
+// Input:
+// - ParamFeed: string
+// Output:
+// - ParamValue: oracle.Value
+func getValue(ctx isc.SandboxView) dict.Dict {
+	feed := ctx.Params().MustGetString(ParamFeed)
+	v := GetValue(ctx.StateR(), feed)
+	if v == nil {
+		panic(errFeedNotFound(feed))
+	}
+	return dict.Dict{ParamValue: v.Bytes()}
+}
+
+// getValues returns the latest value of all oracle feeds
+// Output:
+// - ParamValues: map feed name => oracle.Value
+func getValues(ctx isc.SandboxView) dict.Dict {
+	ret := dict.New()
+	m := collections.NewMap(ret, ParamValues)
+	for feed, v := range GetValues(ctx.StateR()) {
+		m.SetAt([]byte(feed), v.Bytes())
+	}
+	return ret
+}
@@ -0,0 +1,49 @@
+// Package oracle implements the oracle core contract, which keeps the latest
+// values of the oracle feeds. The values are proposed by the committee nodes
+// from their locally configured sources, aggregated by median in the
+// consensus, and written by the VM at the beginning of the block, along with
+// the threshold signature of the committee as a proof of provenance.
+//
+// The values are integers: each feed is scaled by 10^decimals, where the
+// decimals of the feed are set by the chain owner (DefaultDecimals if not
+// set), so that all the nodes report the feed with the same scale.
+package oracle
+
+import (
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+)
+
+var Contract = coreutil.NewContract(coreutil.CoreContractOracle)
+
+var (
+	// Funcs
+	FuncSetFeedDecimals = coreutil.Func("setFeedDecimals")
+
+	// Views
+	ViewGetValue        = coreutil.ViewFunc("getValue")
+	ViewGetValues       = coreutil.ViewFunc("getValues")
+	ViewGetFeedDecimals = coreutil.ViewFunc("getFeedDecimals")
+)
+
+const (
+	// DefaultDecimals are the decimals of the feeds not set by the chain owner
+	DefaultDecimals = 6
+	// MaxDecimals are the maximum decimals of a feed, as values are int64
+	MaxDecimals = 18
+)
+
+// state variables
+const (
+	// prefixValues: map feed name => Value
+	prefixValues = "v"
+	// prefixDecimals: map feed name => uint8
+	prefixDecimals = "d"
+)
+
+// request parameters
+const (
+	ParamFeed     = "f"
+	ParamValue    = "v"
+	ParamValues   = "l"
+	ParamDecimals = "d"
+)
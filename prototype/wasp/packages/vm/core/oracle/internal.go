@@ -0,0 +1,82 @@
+package oracle
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+)
+
+func valuesMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, prefixValues)
+}
+
+func valuesMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixValues)
+}
+
+func decimalsMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, prefixDecimals)
+}
+
+func decimalsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixDecimals)
+}
+
+// GetDecimals returns the decimals of the feed, or DefaultDecimals if they
+// were never set
+func GetDecimals(state kv.KVStoreReader, feed string) uint8 {
+	return codec.MustDecodeUint8(decimalsMapR(state).GetAt([]byte(feed)), DefaultDecimals)
+}
+
+func SetDecimals(state kv.KVStore, feed string, decimals uint8) {
+	decimalsMap(state).SetAt([]byte(feed), codec.EncodeUint8(decimals))
+}
+
+// GetValue returns the latest value of the feed, or nil if the feed was never
+// reported
+func GetValue(state kv.KVStoreReader, feed string) *Value {
+	data := valuesMapR(state).GetAt([]byte(feed))
+	if data == nil {
+		return nil
+	}
+	return mustValueFromBytes(data)
+}
+
+// GetValues returns the latest value of each feed, by feed name
+func GetValues(state kv.KVStoreReader) map[string]*Value {
+	ret := map[string]*Value{}
+	valuesMapR(state).Iterate(func(feed []byte, data []byte) bool {
+		ret[string(feed)] = mustValueFromBytes(data)
+		return true
+	})
+	return ret
+}
+
+// SaveValues stores the values agreed by the committee for the block with the
+// given index and timestamp. The feeds not included in data keep their
+// previous value.
+// IMPORTANT: Must only be called from the ISC VM
+func SaveValues(state kv.KVStore, data *isc.OracleData, blockIndex uint32, timestamp time.Time) {
+	m := valuesMap(state)
+	for _, feed := range data.Values.Names() {
+		v := &Value{
+			Value:      data.Values[feed],
+			Timestamp:  timestamp,
+			BlockIndex: blockIndex,
+			SignedData: data.SignedData,
+			Signature:  data.Signature,
+		}
+		m.SetAt([]byte(feed), v.Bytes())
+	}
+}
+
+func mustValueFromBytes(data []byte) *Value {
+	v, err := ValueFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
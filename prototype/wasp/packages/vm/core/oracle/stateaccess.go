@@ -0,0 +1,19 @@
+package oracle
+
+import (
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+)
+
+type StateAccess struct {
+	state kv.KVStoreReader
+}
+
+func NewStateAccess(store kv.KVStoreReader) *StateAccess {
+	state := subrealm.NewReadOnly(store, kv.Key(Contract.Hname().Bytes()))
+	return &StateAccess{state: state}
+}
+
+func (sa *StateAccess) Decimals(feed string) uint8 {
+	return GetDecimals(sa.state, feed)
+}
@@ -0,0 +1,67 @@
+package oracle
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// Value is the latest value of an oracle feed, along with the data needed to
+// verify that it was agreed by the committee.
+type Value struct {
+	Value int64
+	// Timestamp is the time assumption of the block the value was written in
+	Timestamp  time.Time
+	BlockIndex uint32
+	// SignedData and Signature are the proof of provenance, see isc.OracleData
+	SignedData []byte
+	Signature  []byte
+}
+
+func ValueFromBytes(data []byte) (*Value, error) {
+	return rwutil.ReadFromBytes(data, new(Value))
+}
+
+func (v *Value) Bytes() []byte {
+	return rwutil.WriteToBytes(v)
+}
+
+// OracleData returns all the values signed along with this one
+func (v *Value) OracleData() (*isc.OracleData, error) {
+	values, err := isc.OracleValuesFromSigningMessage(v.SignedData)
+	if err != nil {
+		return nil, err
+	}
+	return &isc.OracleData{
+		Values:     values,
+		SignedData: v.SignedData,
+		Signature:  v.Signature,
+	}, nil
+}
+
+func (v *Value) String() string {
+	return fmt.Sprintf("oracle.Value{Value: %d, Timestamp: %s, BlockIndex: %d}", v.Value, v.Timestamp.UTC(), v.BlockIndex)
+}
+
+func (v *Value) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	v.Value = rr.ReadInt64()
+	v.Timestamp = time.Unix(0, rr.ReadInt64())
+	v.BlockIndex = rr.ReadUint32()
+	v.SignedData = rr.ReadBytes()
+	v.Signature = rr.ReadBytes()
+	return rr.Err
+}
+
+func (v *Value) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteInt64(v.Value)
+	ww.WriteInt64(v.Timestamp.UnixNano())
+	ww.WriteUint32(v.BlockIndex)
+	ww.WriteBytes(v.SignedData)
+	ww.WriteBytes(v.Signature)
+	return ww.Err
+}
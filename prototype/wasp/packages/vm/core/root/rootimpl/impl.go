@@ -22,7 +22,10 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 )
 
 var Processor = root.Contract.Processor(nil,
@@ -53,6 +56,9 @@ func SetInitialState(state kv.KVStore) {
 			errors.Contract,
 			governance.Contract,
 			evm.Contract,
+			scheduler.Contract,
+			oracle.Contract,
+			sponsorship.Contract,
 		}
 
 		for _, c := range contracts {
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
+)
+
+var Processor = Contract.Processor(nil,
+	FuncSchedule.WithHandler(schedule),
+	FuncCancel.WithHandler(cancel),
+	ViewGetScheduledCall.WithHandler(getScheduledCall),
+	ViewGetScheduledCalls.WithHandler(getScheduledCalls),
+)
+
+func SetInitialState(state kv.KVStore) {
+	// does not do anything
+}
+
+var (
+	errTooManyScheduledCalls    = coreerrors.Register("too many scheduled calls for agent %s").Create
+	errScheduledCallNotFound    = coreerrors.Register("scheduled call %d not found").Create
+	errCannotScheduleScheduler  = coreerrors.Register("cannot schedule a call to the scheduler contract").Create()
+	errInvalidScheduleGasBudget = coreerrors.Register("the gas budget of a scheduled call must not be zero").Create()
+)
+
+// schedule registers a call to be executed on behalf of the caller once the
+// deadline is reached, and returns its ID. The gas fee of each execution is
+// charged to the L2 account of the caller.
+// Input:
+// - ParamTargetContract: isc.Hname
+// - ParamTargetEntryPoint: isc.Hname
+// - ParamCallParams: dict.Dict, optional
+// - ParamDeadline: time.Time, the time of the first call
+// - ParamPeriod: uint32, optional, interval in seconds for recurring calls
+// - ParamGasBudget: uint64
+// Output:
+// - ParamScheduleID: uint32
+func schedule(ctx isc.Sandbox) dict.Dict {
+	params := ctx.Params()
+	call := &isc.ScheduledCall{
+		Owner: ctx.Caller(),
+		Target: isc.NewCallTarget(
+			params.MustGetHname(ParamTargetContract),
+			params.MustGetHname(ParamTargetEntryPoint),
+		),
+		GasBudget: params.MustGetUint64(ParamGasBudget),
+		Deadline:  params.MustGetTime(ParamDeadline),
+		Period:    time.Duration(params.MustGetUint32(ParamPeriod, 0)) * time.Second,
+	}
+	callParams, err := dict.FromBytes(params.MustGetBytes(ParamCallParams, dict.New().Bytes()))
+	ctx.RequireNoError(err)
+	call.Params = callParams
+
+	if call.Target.Contract == Contract.Hname() {
+		panic(errCannotScheduleScheduler)
+	}
+	if call.GasBudget == 0 {
+		panic(errInvalidScheduleGasBudget)
+	}
+	state := ctx.State()
+	if countPerAgent(state, call.Owner) >= MaxScheduledCallsPerAgent {
+		panic(errTooManyScheduledCalls(call.Owner.String()))
+	}
+	id := addScheduledCall(state, call)
+	ctx.Log().Debugf("scheduler.schedule: %s", call)
+	return dict.Dict{ParamScheduleID: codec.EncodeUint32(id)}
+}
+
+// cancel removes a scheduled call. Only the owner of the schedule and the
+// chain owner are allowed to cancel it.
+// Input:
+// - ParamScheduleID: uint32
+func cancel(ctx isc.Sandbox) dict.Dict {
+	id := ctx.Params().MustGetUint32(ParamScheduleID)
+	state := ctx.State()
+	call := GetScheduledCall(state, id)
+	if call == nil {
+		panic(errScheduledCallNotFound(id))
+	}
+	if !call.Owner.Equals(ctx.Caller()) && !ctx.ChainOwnerID().Equals(ctx.Caller()) {
+		panic(vm.ErrUnauthorized)
+	}
+	removeScheduledCall(state, call)
+	return nil
+}
+
+// getScheduledCall returns a scheduled call
+// Input:
+// - ParamScheduleID: uint32
+// Output:
+// - ParamScheduledCall: isc.ScheduledCall
+func getScheduledCall(ctx isc.SandboxView) dict.Dict {
+	id := ctx.Params().MustGetUint32(ParamScheduleID)
+	call := GetScheduledCall(ctx.StateR(), id)
+	if call == nil {
+		panic(errScheduledCallNotFound(id))
+	}
+	return dict.Dict{ParamScheduledCall: call.Bytes()}
+}
+
+// getScheduledCalls returns the list of scheduled calls, sorted by ID
+// Input:
+// - ParamAgentID: isc.AgentID, optional, return only the calls owned by the agent
+// Output:
+// - ParamScheduledCalls: array of isc.ScheduledCall
+func getScheduledCalls(ctx isc.SandboxView) dict.Dict {
+	owner := ctx.Params().MustGetAgentID(ParamAgentID, nil)
+	ret := dict.New()
+	arr := collections.NewArray(ret, ParamScheduledCalls)
+	for _, call := range GetScheduledCalls(ctx.StateR(), owner) {
+		arr.Push(call.Bytes())
+	}
+	return ret
+}
@@ -0,0 +1,63 @@
+// Package scheduler implements the scheduler core contract, which keeps the
+// calls to be executed by the VM at a later time, once or periodically.
+// The due calls are executed at the beginning of the first block produced
+// after their deadline; the scheduler does not trigger the production of
+// blocks by itself.
+package scheduler
+
+import (
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+)
+
+var Contract = coreutil.NewContract(coreutil.CoreContractScheduler)
+
+var (
+	// Funcs
+	FuncSchedule = coreutil.Func("schedule")
+	FuncCancel   = coreutil.Func("cancel")
+
+	// Views
+	ViewGetScheduledCall  = coreutil.ViewFunc("getScheduledCall")
+	ViewGetScheduledCalls = coreutil.ViewFunc("getScheduledCalls")
+)
+
+const (
+	// MaxScheduledCallsPerAgent is the maximum amount of active schedules
+	// registered by a single agent
+	MaxScheduledCallsPerAgent = 16
+
+	// MaxScheduledCallsPerBlock is the maximum amount of scheduled calls
+	// executed in a single block. Due calls in excess are executed in the
+	// following blocks.
+	MaxScheduledCallsPerBlock = 32
+)
+
+// state variables
+const (
+	// varNextID: uint32
+	varNextID = "n"
+	// prefixScheduledCalls: map id => isc.ScheduledCall
+	prefixScheduledCalls = "s"
+	// prefixCountPerAgent: map AgentID => uint32
+	prefixCountPerAgent = "c"
+	// prefixDeadlines: index deadline|id => id, sorted by deadline
+	prefixDeadlines = "d"
+)
+
+// request parameters
+const (
+	ParamTargetContract   = "c"
+	ParamTargetEntryPoint = "e"
+	ParamCallParams       = "p"
+	// ParamDeadline is the time of the (first) call
+	ParamDeadline = "t"
+	// ParamPeriod is the interval in seconds between calls of a recurring
+	// schedule; 0 (default) for a one-shot call
+	ParamPeriod    = "r"
+	ParamGasBudget = "g"
+
+	ParamScheduleID     = "i"
+	ParamAgentID        = "a"
+	ParamScheduledCall  = "s"
+	ParamScheduledCalls = "l"
+)
@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+)
+
+func scheduledCallsMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, prefixScheduledCalls)
+}
+
+func scheduledCallsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixScheduledCalls)
+}
+
+func countPerAgentMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, prefixCountPerAgent)
+}
+
+func countPerAgent(state kv.KVStoreReader, agentID isc.AgentID) uint32 {
+	return codec.MustDecodeUint32(collections.NewMapReadOnly(state, prefixCountPerAgent).GetAt(agentID.Bytes()), 0)
+}
+
+func setCountPerAgent(state kv.KVStore, agentID isc.AgentID, n uint32) {
+	if n == 0 {
+		countPerAgentMap(state).DelAt(agentID.Bytes())
+		return
+	}
+	countPerAgentMap(state).SetAt(agentID.Bytes(), codec.EncodeUint32(n))
+}
+
+// keyDeadline is the key of the call in the deadline index. The deadline
+// and the ID are encoded in big endian, so that iterating over the sorted
+// keys yields the calls in order of execution.
+func keyDeadline(call *isc.ScheduledCall) kv.Key {
+	key := []byte(prefixDeadlines)
+	key = binary.BigEndian.AppendUint64(key, uint64(max(call.Deadline.UnixNano(), 0)))
+	key = binary.BigEndian.AppendUint32(key, call.ID)
+	return kv.Key(key)
+}
+
+// addScheduledCall stores the call with a new ID, and returns the ID
+func addScheduledCall(state kv.KVStore, call *isc.ScheduledCall) uint32 {
+	call.ID = codec.MustDecodeUint32(state.Get(varNextID), 0)
+	state.Set(varNextID, codec.EncodeUint32(call.ID+1))
+	scheduledCallsMap(state).SetAt(codec.EncodeUint32(call.ID), call.Bytes())
+	state.Set(keyDeadline(call), codec.EncodeUint32(call.ID))
+	setCountPerAgent(state, call.Owner, countPerAgent(state, call.Owner)+1)
+	return call.ID
+}
+
+func removeScheduledCall(state kv.KVStore, call *isc.ScheduledCall) {
+	scheduledCallsMap(state).DelAt(codec.EncodeUint32(call.ID))
+	state.Del(keyDeadline(call))
+	setCountPerAgent(state, call.Owner, countPerAgent(state, call.Owner)-1)
+}
+
+func GetScheduledCall(state kv.KVStoreReader, id uint32) *isc.ScheduledCall {
+	data := scheduledCallsMapR(state).GetAt(codec.EncodeUint32(id))
+	if data == nil {
+		return nil
+	}
+	return mustScheduledCallFromBytes(data)
+}
+
+// GetScheduledCalls returns all scheduled calls, or the ones owned by the
+// given agent if not nil, sorted by ID.
+func GetScheduledCalls(state kv.KVStoreReader, owner isc.AgentID) []*isc.ScheduledCall {
+	var ret []*isc.ScheduledCall
+	scheduledCallsMapR(state).Iterate(func(_ []byte, data []byte) bool {
+		call := mustScheduledCallFromBytes(data)
+		if owner == nil || call.Owner.Equals(owner) {
+			ret = append(ret, call)
+		}
+		return true
+	})
+	slices.SortFunc(ret, func(a, b *isc.ScheduledCall) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return ret
+}
+
+// DueCalls returns the calls that must be executed in a block with the given
+// timestamp, sorted by deadline, without modifying them. Only the due part of
+// the deadline index is read.
+// IMPORTANT: Must only be called from the ISC VM
+func DueCalls(state kv.KVStoreReader, blockTimestamp time.Time) []*isc.ScheduledCall {
+	var due []*isc.ScheduledCall
+	state.IterateKeysSorted(kv.Key(prefixDeadlines), func(key kv.Key) bool {
+		deadline := binary.BigEndian.Uint64([]byte(key[len(prefixDeadlines):]))
+		if deadline > uint64(max(blockTimestamp.UnixNano(), 0)) {
+			return false
+		}
+		id := binary.BigEndian.Uint32([]byte(key[len(prefixDeadlines)+8:]))
+		due = append(due, GetScheduledCall(state, id))
+		return len(due) < MaxScheduledCallsPerBlock
+	})
+	return due
+}
+
+// IsDue returns true if the call is still scheduled with the same deadline,
+// i.e. it was neither cancelled nor executed since it was returned by
+// DueCalls.
+func IsDue(state kv.KVStoreReader, call *isc.ScheduledCall) bool {
+	current := GetScheduledCall(state, call.ID)
+	return current != nil && current.Deadline.Equal(call.Deadline)
+}
+
+// MarkExecuted moves the deadline of a recurring call to the next period, or
+// removes a one-shot call. It is called by the VM once the call was executed
+// (even if it failed), so that a call that is skipped is retried in the next
+// block.
+// IMPORTANT: Must only be called from the ISC VM
+func MarkExecuted(state kv.KVStore, call *isc.ScheduledCall, blockTimestamp time.Time) {
+	if !call.IsRecurring() {
+		removeScheduledCall(state, call)
+		return
+	}
+	state.Del(keyDeadline(call))
+	next := *call
+	next.Deadline = call.NextDeadline(blockTimestamp)
+	scheduledCallsMap(state).SetAt(codec.EncodeUint32(next.ID), next.Bytes())
+	state.Set(keyDeadline(&next), codec.EncodeUint32(next.ID))
+}
+
+func mustScheduledCallFromBytes(data []byte) *isc.ScheduledCall {
+	call, err := isc.ScheduledCallFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return call
+}
@@ -0,0 +1,152 @@
+package sponsorship
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
+)
+
+var Processor = Contract.Processor(nil,
+	FuncSponsor.WithHandler(sponsor),
+	FuncRevoke.WithHandler(revoke),
+	ViewGetSponsorship.WithHandler(getSponsorship),
+	ViewGetSponsorships.WithHandler(getSponsorships),
+	ViewGetRemaining.WithHandler(getRemaining),
+)
+
+func SetInitialState(state kv.KVStore) {
+	// does not do anything
+}
+
+var (
+	errAlreadySponsored      = coreerrors.Register("%s %s is already sponsored by another agent").Create
+	errSponsorshipNotFound   = coreerrors.Register("sponsorship not found").Create()
+	errInvalidSponsorshipArg = coreerrors.Register("either the sender or the contract must be specified").Create()
+)
+
+func targetFromParams(params *isc.Params) (Kind, isc.AgentID) {
+	sender := params.MustGetAgentID(ParamSender, nil)
+	contract := params.MustGetAgentID(ParamContract, nil)
+	switch {
+	case sender != nil && contract == nil:
+		return KindSender, sender
+	case sender == nil && contract != nil:
+		return KindContract, contract
+	default:
+		panic(errInvalidSponsorshipArg)
+	}
+}
+
+// sponsor authorizes the caller to pay the gas fees of the off-ledger
+// requests of a sender, or of the ones sent to a contract. If the caller
+// already sponsors the sender or contract, the limits are updated.
+// Input:
+// - ParamSender: isc.AgentID, or
+// - ParamContract: isc.AgentID
+// - ParamMaxPerPeriod: uint64, optional
+// - ParamPeriod: uint32, optional, in seconds
+func sponsor(ctx isc.Sandbox) dict.Dict {
+	params := ctx.Params()
+	kind, target := targetFromParams(params)
+	s := &Sponsorship{
+		Sponsor:      ctx.Caller(),
+		Kind:         kind,
+		Target:       target,
+		MaxPerPeriod: params.MustGetUint64(ParamMaxPerPeriod, 0),
+		Period:       time.Duration(params.MustGetUint32(ParamPeriod, 0)) * time.Second,
+	}
+	state := ctx.State()
+	if prev := GetSponsorship(state, kind, target); prev != nil && !prev.Sponsor.Equals(s.Sponsor) {
+		panic(errAlreadySponsored(kind.String(), target.String()))
+	}
+	setSponsorship(state, s)
+	ctx.Log().Debugf("sponsorship.sponsor: %s", s)
+	return nil
+}
+
+// revoke removes a sponsorship. Only the sponsor, the sponsored sender and
+// the chain owner are allowed to remove it.
+// Input:
+// - ParamSender: isc.AgentID, or
+// - ParamContract: isc.AgentID
+func revoke(ctx isc.Sandbox) dict.Dict {
+	kind, target := targetFromParams(ctx.Params())
+	state := ctx.State()
+	s := GetSponsorship(state, kind, target)
+	if s == nil {
+		panic(errSponsorshipNotFound)
+	}
+	caller := ctx.Caller()
+	if !caller.Equals(s.Sponsor) &&
+		!(kind == KindSender && caller.Equals(target)) &&
+		!caller.Equals(ctx.ChainOwnerID()) {
+		panic(vm.ErrUnauthorized)
+	}
+	removeSponsorship(state, s)
+	return nil
+}
+
+// getSponsorship returns the sponsorship of a sender or a contract
+// Input:
+// - ParamSender: isc.AgentID, or
+// - ParamContract: isc.AgentID
+// Output:
+// - ParamSponsorship: Sponsorship
+func getSponsorship(ctx isc.SandboxView) dict.Dict {
+	kind, target := targetFromParams(ctx.Params())
+	s := GetSponsorship(ctx.StateR(), kind, target)
+	if s == nil {
+		panic(errSponsorshipNotFound)
+	}
+	return dict.Dict{ParamSponsorship: s.Bytes()}
+}
+
+// getSponsorships returns the sponsorships of a sponsor
+// Input:
+// - ParamSponsor: isc.AgentID
+// Output:
+// - ParamSponsorships: array of Sponsorship
+func getSponsorships(ctx isc.SandboxView) dict.Dict {
+	sponsor := ctx.Params().MustGetAgentID(ParamSponsor)
+	ret := dict.New()
+	arr := collections.NewArray(ret, ParamSponsorships)
+	for _, s := range GetSponsorships(ctx.StateR(), sponsor) {
+		arr.Push(s.Bytes())
+	}
+	return ret
+}
+
+// getRemaining returns the sponsorship applying to the off-ledger requests of
+// a sender, and the amount of base tokens the sponsor can still pay for them
+// in the current period, taking into account the balance of the sponsor.
+// Input:
+// - ParamSender: isc.AgentID
+// - ParamContract: isc.AgentID, optional, the target of the requests
+// Output:
+// - ParamSponsorship: Sponsorship
+// - ParamRemaining: uint64
+func getRemaining(ctx isc.SandboxView) dict.Dict {
+	params := ctx.Params()
+	sender := params.MustGetAgentID(ParamSender)
+	state := ctx.StateR()
+	s := Find(state, sender, params.MustGetAgentID(ParamContract, nil))
+	if s == nil {
+		panic(errSponsorshipNotFound)
+	}
+	balance := codec.MustDecodeUint64(ctx.CallView(
+		accounts.Contract.Hname(),
+		accounts.ViewBalanceBaseToken.Hname(),
+		dict.Dict{accounts.ParamAgentID: codec.EncodeAgentID(s.Sponsor)},
+	).Get(accounts.ParamBalance), 0)
+	return dict.Dict{
+		ParamSponsorship: s.Bytes(),
+		ParamRemaining:   codec.EncodeUint64(min(balance, Remaining(state, s, sender, ctx.Timestamp()))),
+	}
+}
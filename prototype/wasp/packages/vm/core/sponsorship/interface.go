@@ -0,0 +1,55 @@
+// Package sponsorship implements the sponsorship core contract, which allows
+// an L2 account (the sponsor) to pay the gas fees of the off-ledger requests
+// of other accounts. A sponsorship applies either to all the requests of a
+// sender, or to all the requests sent to a target contract, and can be
+// limited to an amount of base tokens per sender and per period.
+//
+// The sponsor pays only while it has enough funds and the limit for the
+// sender is not reached; otherwise the gas fee is charged to the sender as
+// usual.
+package sponsorship
+
+import (
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+)
+
+var Contract = coreutil.NewContract(coreutil.CoreContractSponsorship)
+
+var (
+	// Funcs
+	FuncSponsor = coreutil.Func("sponsor")
+	FuncRevoke  = coreutil.Func("revoke")
+
+	// Views
+	ViewGetSponsorship  = coreutil.ViewFunc("getSponsorship")
+	ViewGetSponsorships = coreutil.ViewFunc("getSponsorships")
+	ViewGetRemaining    = coreutil.ViewFunc("getRemaining")
+)
+
+// state variables
+const (
+	// prefixSponsorships: map Kind + target AgentID => Sponsorship
+	prefixSponsorships = "s"
+	// prefixUsage + Kind + target AgentID: map sender AgentID => usage
+	prefixUsage = "u"
+)
+
+// request parameters
+const (
+	// ParamSender is the sponsored sender
+	ParamSender = "s"
+	// ParamContract is the sponsored target contract: a ContractAgentID for
+	// ISC contracts, or an EthereumAddressAgentID for EVM contracts
+	ParamContract = "c"
+	// ParamMaxPerPeriod is the maximum amount of base tokens paid for each
+	// sender in each period; 0 (default) for no limit
+	ParamMaxPerPeriod = "m"
+	// ParamPeriod is the duration of a period in seconds; 0 (default) if the
+	// limit is never reset
+	ParamPeriod = "p"
+
+	ParamSponsor      = "a"
+	ParamSponsorship  = "r"
+	ParamSponsorships = "l"
+	ParamRemaining    = "x"
+)
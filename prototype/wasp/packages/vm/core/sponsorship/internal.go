@@ -0,0 +1,112 @@
+package sponsorship
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+)
+
+func sponsorshipKey(kind Kind, target isc.AgentID) []byte {
+	return append([]byte{byte(kind)}, target.Bytes()...)
+}
+
+func sponsorshipsMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, prefixSponsorships)
+}
+
+func sponsorshipsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixSponsorships)
+}
+
+func usageMap(state kv.KVStore, s *Sponsorship) *collections.Map {
+	return collections.NewMap(state, prefixUsage+string(s.key()))
+}
+
+func usageMapR(state kv.KVStoreReader, s *Sponsorship) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, prefixUsage+string(s.key()))
+}
+
+func setSponsorship(state kv.KVStore, s *Sponsorship) {
+	sponsorshipsMap(state).SetAt(s.key(), s.Bytes())
+}
+
+func removeSponsorship(state kv.KVStore, s *Sponsorship) {
+	sponsorshipsMap(state).DelAt(s.key())
+	usageMap(state, s).Erase()
+}
+
+func GetSponsorship(state kv.KVStoreReader, kind Kind, target isc.AgentID) *Sponsorship {
+	data := sponsorshipsMapR(state).GetAt(sponsorshipKey(kind, target))
+	if data == nil {
+		return nil
+	}
+	return mustSponsorshipFromBytes(data)
+}
+
+// GetSponsorships returns all the sponsorships of the sponsor
+func GetSponsorships(state kv.KVStoreReader, sponsor isc.AgentID) []*Sponsorship {
+	var ret []*Sponsorship
+	sponsorshipsMapR(state).Iterate(func(_ []byte, data []byte) bool {
+		s := mustSponsorshipFromBytes(data)
+		if s.Sponsor.Equals(sponsor) {
+			ret = append(ret, s)
+		}
+		return true
+	})
+	return ret
+}
+
+// Find returns the sponsorship that applies to an off-ledger request of the
+// sender to the target contract, or nil if there is none. The sponsorship of
+// the sender has priority over the one of the contract.
+func Find(state kv.KVStoreReader, sender, target isc.AgentID) *Sponsorship {
+	if s := GetSponsorship(state, KindSender, sender); s != nil {
+		return s
+	}
+	if target == nil {
+		return nil
+	}
+	return GetSponsorship(state, KindContract, target)
+}
+
+// Remaining returns the amount of base tokens the sponsor is still willing
+// to pay for the sender at the given time, regardless of the balance of the
+// sponsor. It is math.MaxUint64 if the sponsorship is not limited.
+func Remaining(state kv.KVStoreReader, s *Sponsorship, sender isc.AgentID, now time.Time) uint64 {
+	return s.remaining(getUsage(state, s, sender), now)
+}
+
+// AddUsage records an amount of base tokens paid by the sponsor for the
+// sender.
+// IMPORTANT: Must only be called from the ISC VM
+func AddUsage(state kv.KVStore, s *Sponsorship, sender isc.AgentID, amount uint64, now time.Time) {
+	if s.MaxPerPeriod == 0 {
+		// no need to keep track of the usage
+		return
+	}
+	u := getUsage(state, s, sender)
+	u.add(s.Period, now, amount)
+	usageMap(state, s).SetAt(sender.Bytes(), u.Bytes())
+}
+
+func getUsage(state kv.KVStoreReader, s *Sponsorship, sender isc.AgentID) *usage {
+	data := usageMapR(state, s).GetAt(sender.Bytes())
+	if data == nil {
+		return &usage{}
+	}
+	u, err := usageFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func mustSponsorshipFromBytes(data []byte) *Sponsorship {
+	s, err := SponsorshipFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
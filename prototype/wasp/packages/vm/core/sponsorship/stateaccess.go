@@ -0,0 +1,26 @@
+package sponsorship
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+)
+
+type StateAccess struct {
+	state kv.KVStoreReader
+}
+
+func NewStateAccess(store kv.KVStoreReader) *StateAccess {
+	state := subrealm.NewReadOnly(store, kv.Key(Contract.Hname().Bytes()))
+	return &StateAccess{state: state}
+}
+
+func (sa *StateAccess) Find(sender, target isc.AgentID) *Sponsorship {
+	return Find(sa.state, sender, target)
+}
+
+func (sa *StateAccess) Remaining(s *Sponsorship, sender isc.AgentID, now time.Time) uint64 {
+	return Remaining(sa.state, s, sender, now)
+}
@@ -0,0 +1,146 @@
+package sponsorship
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+type Kind byte
+
+const (
+	// KindSender is a sponsorship of all the off-ledger requests of a sender
+	KindSender Kind = iota
+	// KindContract is a sponsorship of all the off-ledger requests sent to a
+	// target contract
+	KindContract
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindSender:
+		return "sender"
+	case KindContract:
+		return "contract"
+	default:
+		return fmt.Sprintf("Kind(%d)", byte(k))
+	}
+}
+
+// Sponsorship is the authorization of a sponsor to pay the gas fees of the
+// off-ledger requests of a sender, or of the ones sent to a contract.
+type Sponsorship struct {
+	Sponsor isc.AgentID
+	Kind    Kind
+	// Target is the sponsored sender or contract, depending on Kind
+	Target isc.AgentID
+	// MaxPerPeriod is the maximum amount of base tokens paid for each sender
+	// in each period, or 0 for no limit
+	MaxPerPeriod uint64
+	// Period is the duration of a period, or 0 if the limit is never reset
+	Period time.Duration
+}
+
+func SponsorshipFromBytes(data []byte) (*Sponsorship, error) {
+	return rwutil.ReadFromBytes(data, new(Sponsorship))
+}
+
+func (s *Sponsorship) Bytes() []byte {
+	return rwutil.WriteToBytes(s)
+}
+
+func (s *Sponsorship) key() []byte {
+	return sponsorshipKey(s.Kind, s.Target)
+}
+
+// remaining returns the amount of base tokens the sponsor is still willing to
+// pay for a sender at the given time, given the sender's usage.
+func (s *Sponsorship) remaining(u *usage, now time.Time) uint64 {
+	if s.MaxPerPeriod == 0 {
+		return math.MaxUint64
+	}
+	spent := u.spentAt(s.Period, now)
+	if spent >= s.MaxPerPeriod {
+		return 0
+	}
+	return s.MaxPerPeriod - spent
+}
+
+func (s *Sponsorship) String() string {
+	return fmt.Sprintf("Sponsorship{Sponsor: %s, %s: %s, MaxPerPeriod: %d, Period: %s}",
+		s.Sponsor, s.Kind, s.Target, s.MaxPerPeriod, s.Period)
+}
+
+func (s *Sponsorship) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	s.Sponsor = isc.AgentIDFromReader(rr)
+	s.Kind = Kind(rr.ReadByte())
+	s.Target = isc.AgentIDFromReader(rr)
+	s.MaxPerPeriod = rr.ReadAmount64()
+	s.Period = rr.ReadDuration()
+	return rr.Err
+}
+
+func (s *Sponsorship) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.Write(s.Sponsor)
+	ww.WriteByte(byte(s.Kind))
+	ww.Write(s.Target)
+	ww.WriteAmount64(s.MaxPerPeriod)
+	ww.WriteDuration(s.Period)
+	return ww.Err
+}
+
+// usage is the amount paid by the sponsor for a sender in the current period
+type usage struct {
+	periodStart time.Time
+	spent       uint64
+}
+
+func usageFromBytes(data []byte) (*usage, error) {
+	return rwutil.ReadFromBytes(data, new(usage))
+}
+
+func (u *usage) Bytes() []byte {
+	return rwutil.WriteToBytes(u)
+}
+
+// spentAt returns the amount spent in the period including the given time
+func (u *usage) spentAt(period time.Duration, now time.Time) uint64 {
+	if period > 0 && !now.Before(u.periodStart.Add(period)) {
+		return 0
+	}
+	return u.spent
+}
+
+// add records an amount spent at the given time, starting a new period if
+// the current one is over. Periods are aligned to the start of the first one.
+func (u *usage) add(period time.Duration, now time.Time, amount uint64) {
+	switch {
+	case u.periodStart.IsZero():
+		u.periodStart = now
+	case period > 0 && !now.Before(u.periodStart.Add(period)):
+		elapsed := now.Sub(u.periodStart) / period
+		u.periodStart = u.periodStart.Add(elapsed * period)
+		u.spent = 0
+	}
+	u.spent += amount
+}
+
+func (u *usage) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	u.periodStart = time.Unix(0, rr.ReadInt64())
+	u.spent = rr.ReadAmount64()
+	return rr.Err
+}
+
+func (u *usage) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteInt64(u.periodStart.UnixNano())
+	ww.WriteAmount64(u.spent)
+	return ww.Err
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
@@ -899,3 +900,221 @@ func TestGasPayout(t *testing.T) {
 	require.Equal(t, governance.DefaultMinBaseTokensOnCommonAccount, commonBal5.BaseTokens)
 	require.Equal(t, user1Bal4.BaseTokens+gasFees-10, user1Bal5.BaseTokens)
 }
+
+func TestContractFees(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor)
+	ch := env.NewChain()
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := isc.NewAgentID(userAddr)
+	require.NoError(t, ch.DepositBaseTokensToL2(10*isc.Million, user))
+
+	setContractFees := func(contract isc.Hname, fees *governance.ContractFeesRecord, keyPair *cryptolib.KeyPair) error {
+		params := dict.Dict{governance.ParamContractHname: codec.EncodeHname(contract)}
+		if fees != nil {
+			params[governance.ParamContractFeesBytes] = fees.Bytes()
+		}
+		_, err2 := ch.PostRequestSync(
+			solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFees.Name, params).
+				WithMaxAffordableGasBudget(),
+			keyPair,
+		)
+		return err2
+	}
+	getContractFees := func(contract isc.Hname) *governance.ContractFeesRecord {
+		ret, err2 := ch.CallView(governance.Contract.Name, governance.ViewGetContractFees.Name,
+			governance.ParamContractHname, contract,
+		)
+		require.NoError(t, err2)
+		if !ret.Has(governance.ParamContractFeesBytes) {
+			return nil
+		}
+		fees, err2 := governance.ContractFeesRecordFromBytes(ret.Get(governance.ParamContractFeesBytes))
+		require.NoError(t, err2)
+		return fees
+	}
+	incCounter := func(keyPair *cryptolib.KeyPair) *isc.Receipt {
+		_, err2 := ch.PostRequestOffLedger(
+			solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).WithGasBudget(100_000),
+			keyPair,
+		)
+		require.NoError(t, err2)
+		return ch.LastReceipt()
+	}
+
+	// without overrides, the fee policy of the chain applies
+	receipt := incCounter(user)
+	require.Nil(t, receipt.ContractFees)
+	require.EqualValues(t, ch.GetGasFeePolicy().FeeFromGas(receipt.GasBurned), receipt.GasFeeCharged)
+
+	// only the chain owner can set the overrides, for existing contracts
+	cheaper := &governance.ContractFeesRecord{GasPerTokenMultiplier: util.Ratio32{A: 10, B: 1}}
+	err = setContractFees(inccounter.Contract.Hname(), cheaper, user)
+	require.ErrorContains(t, err, "unauthorized")
+	err = setContractFees(isc.Hn("unknown"), cheaper, nil)
+	require.ErrorContains(t, err, "not found")
+	err = setContractFees(coreutil.CoreContractEVMHname, cheaper, nil)
+	require.ErrorContains(t, err, "cannot be set")
+
+	err = setContractFees(inccounter.Contract.Hname(), cheaper, nil)
+	require.NoError(t, err)
+	require.Equal(t, cheaper, getContractFees(inccounter.Contract.Hname()))
+
+	receipt = incCounter(user)
+	require.Equal(t, cheaper.Bytes(), receipt.ContractFees)
+	policy := cheaper.FeePolicy(ch.GetGasFeePolicy())
+	require.Equal(t, util.Ratio32{A: 10 * gas.DefaultGasPerToken.A, B: gas.DefaultGasPerToken.B}, policy.GasPerToken)
+	require.EqualValues(t, policy.FeeFromGas(receipt.GasBurned), receipt.GasFeeCharged)
+	require.Less(t, receipt.GasFeeCharged, ch.GetGasFeePolicy().FeeFromGas(receipt.GasBurned))
+
+	// free gas, with a fixed fee per request
+	fixed := &governance.ContractFeesRecord{NoGasFee: true, OwnerFee: 1000, ValidatorFee: 10}
+	err = setContractFees(inccounter.Contract.Hname(), fixed, nil)
+	require.NoError(t, err)
+	balance := ch.L2BaseTokens(userAgentID)
+	receipt = incCounter(user)
+	require.NotZero(t, receipt.GasBurned)
+	require.EqualValues(t, 1010, receipt.GasFeeCharged)
+	require.EqualValues(t, balance-1010, ch.L2BaseTokens(userAgentID))
+
+	// zero-fee contract: senders without funds can call it
+	err = setContractFees(inccounter.Contract.Hname(), &governance.ContractFeesRecord{NoGasFee: true}, nil)
+	require.NoError(t, err)
+	poor, _ := env.NewKeyPair()
+	receipt = incCounter(poor)
+	require.NotZero(t, receipt.GasBurned)
+	require.Zero(t, receipt.GasFeeCharged)
+
+	ret, err := ch.CallView(governance.Contract.Name, governance.ViewGetAllContractFees.Name)
+	require.NoError(t, err)
+	require.Len(t, ret, 1)
+	require.True(t, ret.Has(kv.Key(inccounter.Contract.Hname().Bytes())))
+
+	// remove the overrides
+	err = setContractFees(inccounter.Contract.Hname(), nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, getContractFees(inccounter.Contract.Hname()))
+	receipt = incCounter(user)
+	require.Nil(t, receipt.ContractFees)
+	require.EqualValues(t, ch.GetGasFeePolicy().FeeFromGas(receipt.GasBurned), receipt.GasFeeCharged)
+}
+
+func TestBaseFee(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor)
+	ch := env.NewChain()
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+
+	user, _ := env.NewKeyPairWithFunds()
+	require.NoError(t, ch.DepositBaseTokensToL2(10*isc.Million, user))
+	_, validatorAddr := env.NewKeyPair()
+	ch.ValidatorFeeTarget = isc.NewAgentID(validatorAddr)
+
+	minGasPerToken := ch.GetGasFeePolicy().GasPerToken
+
+	setBaseFeeConfig := func(config *gas.BaseFeeConfig, keyPair *cryptolib.KeyPair) error {
+		_, err2 := ch.PostRequestSync(
+			solo.NewCallParams(governance.Contract.Name, governance.FuncSetBaseFeeConfig.Name,
+				governance.ParamBaseFeeConfigBytes, config.Bytes(),
+			).WithMaxAffordableGasBudget(),
+			keyPair,
+		)
+		return err2
+	}
+	currentGasPerToken := func() util.Ratio32 {
+		ret, err2 := ch.CallView(governance.Contract.Name, governance.ViewGetChainInfo.Name)
+		require.NoError(t, err2)
+		return gas.MustFeePolicyFromBytes(ret.Get(governance.VarGasFeePolicyBytes)).GasPerToken
+	}
+	const gasBudget = 100_000
+	incCounter := func(maxFee, tip uint64) (*isc.Receipt, error) {
+		_, err2 := ch.PostRequestOffLedger(
+			solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).
+				WithGasBudget(gasBudget).
+				WithMaxFee(maxFee, tip),
+			user,
+		)
+		return ch.LastReceipt(), err2
+	}
+
+	// disabled by default
+	ret, err := ch.CallView(governance.Contract.Name, governance.ViewGetBaseFeeConfig.Name)
+	require.NoError(t, err)
+	config, err := gas.BaseFeeConfigFromBytes(ret.Get(governance.ParamBaseFeeConfigBytes))
+	require.NoError(t, err)
+	require.False(t, config.Enabled)
+	_, err = incCounter(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, minGasPerToken, ch.GetLatestBlockInfo().GasPerToken)
+	require.Equal(t, minGasPerToken, currentGasPerToken())
+
+	// each block burns more than twice the target: the price goes up by 1/2
+	config = &gas.BaseFeeConfig{Enabled: true, TargetGasPerBlock: 1, MaxChangeDenominator: 2}
+	err = setBaseFeeConfig(config, user)
+	require.ErrorContains(t, err, "unauthorized")
+	err = setBaseFeeConfig(config, nil)
+	require.NoError(t, err)
+	require.Equal(t, minGasPerToken, ch.GetLatestBlockInfo().GasPerToken)
+	require.Equal(t, util.Ratio32{A: 200, B: 3}, currentGasPerToken())
+
+	receipt, err := incCounter(0, 0)
+	require.NoError(t, err)
+	blockInfo := ch.GetLatestBlockInfo()
+	require.Equal(t, util.Ratio32{A: 200, B: 3}, blockInfo.GasPerToken)
+	require.EqualValues(t, gas.FeeFromGas(receipt.GasBurned, blockInfo.GasPerToken), receipt.GasFeeCharged)
+	require.Greater(t, receipt.GasFeeCharged, gas.FeeFromGas(receipt.GasBurned, minGasPerToken))
+	require.Equal(t, util.Ratio32{A: 400, B: 9}, currentGasPerToken())
+
+	// the request is skipped while the fee for its gas budget exceeds the max fee
+	maxFee := gas.FeeFromGas(gasBudget, currentGasPerToken())
+	_, err = incCounter(maxFee-1, 0)
+	require.ErrorContains(t, err, "skipped")
+
+	// the tip is paid to the validators, up to the max fee
+	maxFee = gas.FeeFromGas(gasBudget, currentGasPerToken()) + 5
+	validatorBalance := ch.L2BaseTokens(ch.ValidatorFeeTarget)
+	receipt, err = incCounter(maxFee, 7)
+	require.NoError(t, err)
+	blockInfo = ch.GetLatestBlockInfo()
+	require.EqualValues(t, gas.FeeFromGas(receipt.GasBurned, blockInfo.GasPerToken)+5, receipt.GasFeeCharged)
+	require.EqualValues(t, validatorBalance+5, ch.L2BaseTokens(ch.ValidatorFeeTarget))
+
+	// the blocks burn less than the target: the price goes down to the one of
+	// the fee policy
+	config = &gas.BaseFeeConfig{Enabled: true, TargetGasPerBlock: gas.LimitsDefault.MaxGasPerBlock, MaxChangeDenominator: 8}
+	err = setBaseFeeConfig(config, nil)
+	require.NoError(t, err)
+	for current := currentGasPerToken(); current != minGasPerToken; {
+		_, err = incCounter(0, 0)
+		require.NoError(t, err)
+		next := currentGasPerToken()
+		require.Equal(t, current, gas.MaxGasPrice(current, next))
+		require.NotEqual(t, current, next)
+		current = next
+	}
+
+	config.Enabled = false
+	err = setBaseFeeConfig(config, nil)
+	require.NoError(t, err)
+	require.Equal(t, minGasPerToken, currentGasPerToken())
+
+	// the fee overrides of the target contract count towards the max fee
+	fees := &governance.ContractFeesRecord{OwnerFee: 1000}
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.Contract.Name, governance.FuncSetContractFees.Name,
+			governance.ParamContractHname, inccounter.Contract.Hname(),
+			governance.ParamContractFeesBytes, fees.Bytes(),
+		).WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.NoError(t, err)
+	maxFee = gas.FeeFromGas(gasBudget, currentGasPerToken())
+	_, err = incCounter(maxFee, 0)
+	require.ErrorContains(t, err, "skipped")
+	_, err = incCounter(maxFee+fees.OwnerFee, 0)
+	require.NoError(t, err)
+}
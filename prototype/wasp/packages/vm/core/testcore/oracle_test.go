@@ -0,0 +1,113 @@
+package testcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+)
+
+func TestOracle(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+	user, _ := env.NewKeyPairWithFunds()
+
+	_, err := ch.GetOracleValue("price")
+	require.ErrorContains(t, err, "oracle feed price not found")
+
+	ch.SetOracleValues(isc.OracleValues{"price": 1_234_500, "temp": -5})
+	require.NoError(t, ch.DepositBaseTokensToL2(isc.Million, user))
+	block1 := ch.LatestBlockIndex()
+
+	price, err := ch.GetOracleValue("price")
+	require.NoError(t, err)
+	require.EqualValues(t, 1_234_500, price.Value)
+	require.EqualValues(t, block1, price.BlockIndex)
+	require.WithinDuration(t, ch.GetLatestBlockInfo().Timestamp, price.Timestamp, time.Millisecond)
+
+	// the value is signed along with the other values agreed for the block
+	data, err := price.OracleData()
+	require.NoError(t, err)
+	require.NoError(t, data.Verify())
+	require.Equal(t, isc.OracleValues{"price": 1_234_500, "temp": -5}, data.Values)
+	sig, _, err := bls.SignatureWithPublicKeyFromBytes(price.Signature)
+	require.NoError(t, err)
+	require.Equal(t, ch.OraclePublicKey().Bytes(), sig.PublicKey.Bytes())
+
+	// tampered values are detected
+	data.Values["price"]++
+	data.SignedData = isc.OracleSigningMessage(ch.GetAnchorOutputFromL1().OutputID(), data.Values)
+	require.Error(t, data.Verify())
+
+	// feeds not reported in a block keep their previous value
+	ch.SetOracleValues(isc.OracleValues{"price": 1_300_000})
+	require.NoError(t, ch.DepositBaseTokensToL2(isc.Million, user))
+	block2 := ch.LatestBlockIndex()
+
+	price, err = ch.GetOracleValue("price")
+	require.NoError(t, err)
+	require.EqualValues(t, 1_300_000, price.Value)
+	require.EqualValues(t, block2, price.BlockIndex)
+	temp, err := ch.GetOracleValue("temp")
+	require.NoError(t, err)
+	require.EqualValues(t, -5, temp.Value)
+	require.EqualValues(t, block1, temp.BlockIndex)
+
+	ret, err := ch.CallView(oracle.Contract.Name, oracle.ViewGetValues.Name)
+	require.NoError(t, err)
+	values := collections.NewMapReadOnly(ret, oracle.ParamValues)
+	require.EqualValues(t, 2, values.Len())
+	v, err := oracle.ValueFromBytes(values.GetAt([]byte("temp")))
+	require.NoError(t, err)
+	require.EqualValues(t, -5, v.Value)
+
+	// no more values are written once the oracle is stopped
+	ch.SetOracleValues(nil)
+	require.NoError(t, ch.DepositBaseTokensToL2(isc.Million, user))
+	price, err = ch.GetOracleValue("price")
+	require.NoError(t, err)
+	require.EqualValues(t, block2, price.BlockIndex)
+}
+
+func TestOracleFeedDecimals(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+	user, _ := env.NewKeyPairWithFunds()
+	require.NoError(t, ch.DepositBaseTokensToL2(isc.Million, user))
+
+	getDecimals := func(feed string) uint8 {
+		ret, err := ch.CallView(oracle.Contract.Name, oracle.ViewGetFeedDecimals.Name, oracle.ParamFeed, feed)
+		require.NoError(t, err)
+		return codec.MustDecodeUint8(ret.Get(oracle.ParamDecimals))
+	}
+	setDecimals := func(feed string, decimals uint8, keyPair *cryptolib.KeyPair) error {
+		_, err := ch.PostRequestSync(solo.NewCallParams(oracle.Contract.Name, oracle.FuncSetFeedDecimals.Name,
+			oracle.ParamFeed, feed,
+			oracle.ParamDecimals, decimals,
+		).WithMaxAffordableGasBudget(), keyPair)
+		return err
+	}
+
+	require.EqualValues(t, oracle.DefaultDecimals, getDecimals("price"))
+	require.NoError(t, setDecimals("price", 8, nil))
+	require.EqualValues(t, 8, getDecimals("price"))
+	require.EqualValues(t, oracle.DefaultDecimals, getDecimals("temp"))
+	latestState, err := ch.LatestState(chain.ActiveOrCommittedState)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, oracle.NewStateAccess(latestState).Decimals("price"))
+
+	// only the chain owner can set the decimals, within the limits
+	require.ErrorContains(t, setDecimals("price", 2, user), "unauthorized")
+	require.ErrorContains(t, setDecimals("price", oracle.MaxDecimals+1, nil), "invalid decimals")
+	require.ErrorContains(t, setDecimals("", 2, nil), "invalid oracle feed name")
+	require.EqualValues(t, 8, getDecimals("price"))
+}
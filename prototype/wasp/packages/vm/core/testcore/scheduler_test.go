@@ -0,0 +1,238 @@
+package testcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/contracts/native/inccounter"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/testutil/testmisc"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
+)
+
+type schedulerEnv struct {
+	t     *testing.T
+	env   *solo.Solo
+	ch    *solo.Chain
+	owner *cryptolib.KeyPair
+}
+
+func newSchedulerEnv(t *testing.T) *schedulerEnv {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor)
+	ch := env.NewChain()
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+
+	owner, _ := env.NewKeyPairWithFunds()
+	err = ch.DepositBaseTokensToL2(10*isc.Million, owner)
+	require.NoError(t, err)
+
+	return &schedulerEnv{t: t, env: env, ch: ch, owner: owner}
+}
+
+func (e *schedulerEnv) schedule(keyPair *cryptolib.KeyPair, deadline time.Time, period uint32) (uint32, error) {
+	req := solo.NewCallParams(scheduler.Contract.Name, scheduler.FuncSchedule.Name,
+		scheduler.ParamTargetContract, inccounter.Contract.Hname(),
+		scheduler.ParamTargetEntryPoint, inccounter.FuncIncCounter.Hname(),
+		scheduler.ParamDeadline, deadline,
+		scheduler.ParamPeriod, period,
+		scheduler.ParamGasBudget, uint64(100_000),
+	).WithMaxAffordableGasBudget()
+	ret, err := e.ch.PostRequestSync(req, keyPair)
+	if err != nil {
+		return 0, err
+	}
+	return codec.MustDecodeUint32(ret.Get(scheduler.ParamScheduleID)), nil
+}
+
+func (e *schedulerEnv) cancel(keyPair *cryptolib.KeyPair, id uint32) error {
+	req := solo.NewCallParams(scheduler.Contract.Name, scheduler.FuncCancel.Name,
+		scheduler.ParamScheduleID, id,
+	).WithMaxAffordableGasBudget()
+	_, err := e.ch.PostRequestSync(req, keyPair)
+	return err
+}
+
+func (e *schedulerEnv) scheduledCalls(owner isc.AgentID) []*isc.ScheduledCall {
+	var params []interface{}
+	if owner != nil {
+		params = append(params, scheduler.ParamAgentID, owner)
+	}
+	ret, err := e.ch.CallView(scheduler.Contract.Name, scheduler.ViewGetScheduledCalls.Name, params...)
+	require.NoError(e.t, err)
+	arr := collections.NewArrayReadOnly(ret, scheduler.ParamScheduledCalls)
+	calls := make([]*isc.ScheduledCall, arr.Len())
+	for i := range calls {
+		calls[i], err = isc.ScheduledCallFromBytes(arr.GetAt(uint32(i)))
+		require.NoError(e.t, err)
+	}
+	return calls
+}
+
+// produceBlock produces a block with a dummy request, so that the due
+// scheduled calls are executed
+func (e *schedulerEnv) produceBlock() {
+	err := e.ch.DepositBaseTokensToL2(isc.Million, nil)
+	require.NoError(e.t, err)
+}
+
+func (e *schedulerEnv) counter() int64 {
+	ret, err := e.ch.CallView(inccounter.Contract.Name, inccounter.ViewGetCounter.Name)
+	require.NoError(e.t, err)
+	return codec.MustDecodeInt64(ret.Get(inccounter.VarCounter))
+}
+
+func TestSchedulerOneShot(t *testing.T) {
+	e := newSchedulerEnv(t)
+	ownerAgentID := isc.NewAgentID(e.owner.Address())
+
+	id, err := e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 0)
+	require.NoError(t, err)
+
+	calls := e.scheduledCalls(ownerAgentID)
+	require.Len(t, calls, 1)
+	require.EqualValues(t, id, calls[0].ID)
+	require.True(t, calls[0].Owner.Equals(ownerAgentID))
+	require.False(t, calls[0].IsRecurring())
+
+	e.produceBlock()
+	require.EqualValues(t, 0, e.counter())
+
+	e.env.AdvanceClockBy(2 * time.Hour)
+	balanceBefore := e.ch.L2BaseTokens(ownerAgentID)
+	e.produceBlock()
+	require.EqualValues(t, 1, e.counter())
+
+	// the scheduled call is the first request of the block, and its gas fee
+	// is charged to the owner
+	receipts := e.ch.GetRequestReceiptsForBlock()
+	require.Len(t, receipts, 2)
+	scheduledReq, ok := receipts[0].Request.(isc.ScheduledRequest)
+	require.True(t, ok)
+	require.EqualValues(t, id, scheduledReq.ScheduledCall().ID)
+	require.Nil(t, receipts[0].Error)
+	require.Positive(t, receipts[0].GasFeeCharged)
+	require.EqualValues(t, balanceBefore-receipts[0].GasFeeCharged, e.ch.L2BaseTokens(ownerAgentID))
+
+	// one-shot calls are removed after the execution
+	require.Empty(t, e.scheduledCalls(nil))
+	e.env.AdvanceClockBy(2 * time.Hour)
+	e.produceBlock()
+	require.EqualValues(t, 1, e.counter())
+}
+
+func TestSchedulerRecurring(t *testing.T) {
+	e := newSchedulerEnv(t)
+
+	_, err := e.schedule(e.owner, e.env.GlobalTime(), 60)
+	require.NoError(t, err)
+
+	e.produceBlock()
+	require.EqualValues(t, 1, e.counter())
+
+	e.env.AdvanceClockBy(30 * time.Second)
+	e.produceBlock()
+	require.EqualValues(t, 1, e.counter())
+
+	e.env.AdvanceClockBy(30 * time.Second)
+	e.produceBlock()
+	require.EqualValues(t, 2, e.counter())
+
+	// missed periods are skipped
+	e.env.AdvanceClockBy(10 * time.Minute)
+	e.produceBlock()
+	require.EqualValues(t, 3, e.counter())
+
+	calls := e.scheduledCalls(nil)
+	require.Len(t, calls, 1)
+	require.True(t, calls[0].Deadline.After(e.env.GlobalTime()))
+	require.EqualValues(t, time.Minute, calls[0].Period)
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	e := newSchedulerEnv(t)
+
+	id, err := e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 60)
+	require.NoError(t, err)
+
+	// only the owner (or the chain owner) can cancel the schedule
+	other, _ := e.env.NewKeyPairWithFunds()
+	err = e.cancel(other, id)
+	testmisc.RequireErrorToBe(t, err, "unauthorized access")
+	require.Len(t, e.scheduledCalls(nil), 1)
+
+	err = e.cancel(e.owner, id)
+	require.NoError(t, err)
+	require.Empty(t, e.scheduledCalls(nil))
+
+	err = e.cancel(e.owner, id)
+	testmisc.RequireErrorToBe(t, err, "not found")
+
+	e.env.AdvanceClockBy(2 * time.Hour)
+	e.produceBlock()
+	require.EqualValues(t, 0, e.counter())
+
+	// the chain owner can cancel any schedule
+	id, err = e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 0)
+	require.NoError(t, err)
+	err = e.cancel(nil, id)
+	require.NoError(t, err)
+	require.Empty(t, e.scheduledCalls(nil))
+}
+
+func TestSchedulerLimits(t *testing.T) {
+	e := newSchedulerEnv(t)
+
+	for i := 0; i < scheduler.MaxScheduledCallsPerAgent; i++ {
+		_, err := e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 0)
+		require.NoError(t, err)
+	}
+	_, err := e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 0)
+	testmisc.RequireErrorToBe(t, err, "too many scheduled calls")
+
+	// the schedules of other agents are not affected
+	other, _ := e.env.NewKeyPairWithFunds()
+	_, err = e.schedule(other, e.env.GlobalTime().Add(time.Hour), 0)
+	require.NoError(t, err)
+	require.Len(t, e.scheduledCalls(isc.NewAgentID(e.owner.Address())), scheduler.MaxScheduledCallsPerAgent)
+	require.Len(t, e.scheduledCalls(nil), scheduler.MaxScheduledCallsPerAgent+1)
+
+	// the scheduler itself cannot be the target
+	req := solo.NewCallParams(scheduler.Contract.Name, scheduler.FuncSchedule.Name,
+		scheduler.ParamTargetContract, scheduler.Contract.Hname(),
+		scheduler.ParamTargetEntryPoint, scheduler.FuncSchedule.Hname(),
+		scheduler.ParamDeadline, e.env.GlobalTime(),
+		scheduler.ParamGasBudget, uint64(100_000),
+	).WithMaxAffordableGasBudget()
+	_, err = e.ch.PostRequestSync(req, other)
+	testmisc.RequireErrorToBe(t, err, "cannot schedule a call to the scheduler")
+}
+
+func TestSchedulerPostponedInMaintenance(t *testing.T) {
+	e := newSchedulerEnv(t)
+
+	_, err := e.schedule(e.owner, e.env.GlobalTime().Add(time.Hour), 0)
+	require.NoError(t, err)
+	_, err = e.ch.PostRequestSync(solo.NewCallParams(governance.Contract.Name, governance.FuncStartMaintenance.Name).WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+
+	// the call is due, but the block is produced in maintenance mode
+	e.env.AdvanceClockBy(2 * time.Hour)
+	_, err = e.ch.PostRequestSync(solo.NewCallParams(governance.Contract.Name, governance.FuncStopMaintenance.Name).WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, e.counter())
+	require.Len(t, e.scheduledCalls(nil), 1)
+
+	// the call is still due in the next block
+	e.produceBlock()
+	require.EqualValues(t, 1, e.counter())
+	require.Empty(t, e.scheduledCalls(nil))
+}
@@ -0,0 +1,255 @@
+package testcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/contracts/native/inccounter"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
+)
+
+type sponsorshipEnv struct {
+	t         *testing.T
+	env       *solo.Solo
+	ch        *solo.Chain
+	sponsor   *cryptolib.KeyPair
+	sponsorID isc.AgentID
+	sender    *cryptolib.KeyPair
+	senderID  isc.AgentID
+	counterID isc.AgentID
+}
+
+func newSponsorshipEnv(t *testing.T) *sponsorshipEnv {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor)
+	ch := env.NewChain()
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash, inccounter.VarCounter, 0)
+	require.NoError(t, err)
+
+	sponsor, sponsorAddr := env.NewKeyPairWithFunds()
+	err = ch.DepositBaseTokensToL2(10*isc.Million, sponsor)
+	require.NoError(t, err)
+
+	// the sender has no funds, neither on L1 nor on L2
+	sender, senderAddr := env.NewKeyPair()
+
+	return &sponsorshipEnv{
+		t:         t,
+		env:       env,
+		ch:        ch,
+		sponsor:   sponsor,
+		sponsorID: isc.NewAgentID(sponsorAddr),
+		sender:    sender,
+		senderID:  isc.NewAgentID(senderAddr),
+		counterID: isc.NewContractAgentID(ch.ID(), inccounter.Contract.Hname()),
+	}
+}
+
+func (e *sponsorshipEnv) sponsorSender(keyPair *cryptolib.KeyPair, sender isc.AgentID, maxPerPeriod uint64, period uint32) error {
+	_, err := e.ch.PostRequestSync(solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncSponsor.Name,
+		sponsorship.ParamSender, sender,
+		sponsorship.ParamMaxPerPeriod, maxPerPeriod,
+		sponsorship.ParamPeriod, period,
+	).WithMaxAffordableGasBudget(), keyPair)
+	return err
+}
+
+func (e *sponsorshipEnv) revoke(keyPair *cryptolib.KeyPair, param string, target isc.AgentID) error {
+	_, err := e.ch.PostRequestSync(solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncRevoke.Name,
+		param, target,
+	).WithMaxAffordableGasBudget(), keyPair)
+	return err
+}
+
+// incCounter sends an off-ledger request from the sender, returning the gas
+// fee charged for it
+func (e *sponsorshipEnv) incCounter() (uint64, error) {
+	_, err := e.ch.PostRequestOffLedger(
+		solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).WithGasBudget(100_000),
+		e.sender,
+	)
+	return e.ch.LastReceipt().GasFeeCharged, err
+}
+
+func (e *sponsorshipEnv) remaining(contract isc.AgentID) (*sponsorship.Sponsorship, uint64) {
+	params := []interface{}{sponsorship.ParamSender, e.senderID}
+	if contract != nil {
+		params = append(params, sponsorship.ParamContract, contract)
+	}
+	ret, err := e.ch.CallView(sponsorship.Contract.Name, sponsorship.ViewGetRemaining.Name, params...)
+	require.NoError(e.t, err)
+	s, err := sponsorship.SponsorshipFromBytes(ret.Get(sponsorship.ParamSponsorship))
+	require.NoError(e.t, err)
+	return s, codec.MustDecodeUint64(ret.Get(sponsorship.ParamRemaining))
+}
+
+func (e *sponsorshipEnv) sponsorships(sponsor isc.AgentID) []*sponsorship.Sponsorship {
+	ret, err := e.ch.CallView(sponsorship.Contract.Name, sponsorship.ViewGetSponsorships.Name,
+		sponsorship.ParamSponsor, sponsor,
+	)
+	require.NoError(e.t, err)
+	arr := collections.NewArrayReadOnly(ret, sponsorship.ParamSponsorships)
+	sponsorships := make([]*sponsorship.Sponsorship, arr.Len())
+	for i := range sponsorships {
+		sponsorships[i], err = sponsorship.SponsorshipFromBytes(arr.GetAt(uint32(i)))
+		require.NoError(e.t, err)
+	}
+	return sponsorships
+}
+
+func (e *sponsorshipEnv) counter() int64 {
+	ret, err := e.ch.CallView(inccounter.Contract.Name, inccounter.ViewGetCounter.Name)
+	require.NoError(e.t, err)
+	return codec.MustDecodeInt64(ret.Get(inccounter.VarCounter))
+}
+
+func TestSponsorshipSender(t *testing.T) {
+	e := newSponsorshipEnv(t)
+
+	// without a sponsorship, the sender cannot pay for the request
+	_, err := e.incCounter()
+	require.Error(t, err)
+	require.EqualValues(t, 0, e.counter())
+
+	err = e.sponsorSender(e.sponsor, e.senderID, 0, 0)
+	require.NoError(t, err)
+
+	s, remaining := e.remaining(nil)
+	require.True(t, s.Sponsor.Equals(e.sponsorID))
+	require.Equal(t, sponsorship.KindSender, s.Kind)
+	require.True(t, s.Target.Equals(e.senderID))
+	require.EqualValues(t, e.ch.L2BaseTokens(e.sponsorID), remaining)
+
+	sponsorBalance := e.ch.L2BaseTokens(e.sponsorID)
+	fee, err := e.incCounter()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, e.counter())
+	require.NotZero(t, fee)
+	require.EqualValues(t, sponsorBalance-fee, e.ch.L2BaseTokens(e.sponsorID))
+	require.Zero(t, e.ch.L2BaseTokens(e.senderID))
+
+	sponsorships := e.sponsorships(e.sponsorID)
+	require.Len(t, sponsorships, 1)
+	require.True(t, sponsorships[0].Target.Equals(e.senderID))
+
+	// the max fee of the sender does not apply to the fee paid by the sponsor
+	_, err = e.ch.PostRequestOffLedger(
+		solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).
+			WithGasBudget(100_000).
+			WithMaxFee(1, 0),
+		e.sender,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, e.counter())
+
+	// another agent cannot take over the sponsorship
+	other, _ := e.env.NewKeyPairWithFunds()
+	err = e.sponsorSender(other, e.senderID, 0, 0)
+	require.ErrorContains(t, err, "already sponsored")
+}
+
+func TestSponsorshipLimit(t *testing.T) {
+	e := newSponsorshipEnv(t)
+
+	// the sponsor pays at most one request per hour
+	minFee := e.ch.GetGasFeePolicy().MinFee()
+	err := e.sponsorSender(e.sponsor, e.senderID, minFee, uint32(time.Hour/time.Second))
+	require.NoError(t, err)
+	_, remaining := e.remaining(nil)
+	require.EqualValues(t, minFee, remaining)
+
+	fee, err := e.incCounter()
+	require.NoError(t, err)
+	require.EqualValues(t, minFee, fee)
+	_, remaining = e.remaining(nil)
+	require.Zero(t, remaining)
+
+	// the limit is reached, the fee is charged to the sender
+	_, err = e.incCounter()
+	require.Error(t, err)
+	require.EqualValues(t, 1, e.counter())
+
+	// the limit is reset in the next period
+	e.env.AdvanceClockBy(time.Hour)
+	_, err = e.incCounter()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, e.counter())
+}
+
+func TestSponsorshipContract(t *testing.T) {
+	e := newSponsorshipEnv(t)
+
+	_, err := e.ch.PostRequestSync(solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncSponsor.Name,
+		sponsorship.ParamContract, e.counterID,
+	).WithMaxAffordableGasBudget(), e.sponsor)
+	require.NoError(t, err)
+
+	s, remaining := e.remaining(e.counterID)
+	require.Equal(t, sponsorship.KindContract, s.Kind)
+	require.True(t, s.Target.Equals(e.counterID))
+	require.EqualValues(t, e.ch.L2BaseTokens(e.sponsorID), remaining)
+
+	// the requests to the contract are sponsored
+	sponsorBalance := e.ch.L2BaseTokens(e.sponsorID)
+	fee, err := e.incCounter()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, e.counter())
+	require.EqualValues(t, sponsorBalance-fee, e.ch.L2BaseTokens(e.sponsorID))
+
+	// the requests to other contracts are not
+	_, err = e.ch.PostRequestOffLedger(
+		solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncSponsor.Name,
+			sponsorship.ParamSender, e.sponsorID,
+		).WithGasBudget(100_000),
+		e.sender,
+	)
+	require.Error(t, err)
+	require.Empty(t, e.sponsorships(e.senderID))
+}
+
+func TestSponsorshipRevoke(t *testing.T) {
+	e := newSponsorshipEnv(t)
+	other, _ := e.env.NewKeyPairWithFunds()
+
+	err := e.revoke(e.sponsor, sponsorship.ParamSender, e.senderID)
+	require.ErrorContains(t, err, "sponsorship not found")
+
+	err = e.sponsorSender(e.sponsor, e.senderID, 0, 0)
+	require.NoError(t, err)
+
+	// only the sponsor, the sponsored sender and the chain owner can revoke
+	err = e.revoke(other, sponsorship.ParamSender, e.senderID)
+	require.ErrorContains(t, err, "unauthorized")
+
+	err = e.revoke(e.sponsor, sponsorship.ParamSender, e.senderID)
+	require.NoError(t, err)
+	require.Empty(t, e.sponsorships(e.sponsorID))
+	_, err = e.incCounter()
+	require.Error(t, err)
+
+	err = e.sponsorSender(e.sponsor, e.senderID, 0, 0)
+	require.NoError(t, err)
+	err = e.revoke(e.ch.OriginatorPrivateKey, sponsorship.ParamSender, e.senderID)
+	require.NoError(t, err)
+	require.Empty(t, e.sponsorships(e.sponsorID))
+
+	// the sponsored sender can revoke the sponsorship, even without funds
+	err = e.sponsorSender(e.sponsor, e.senderID, 0, 0)
+	require.NoError(t, err)
+	_, err = e.ch.PostRequestOffLedger(
+		solo.NewCallParams(sponsorship.Contract.Name, sponsorship.FuncRevoke.Name,
+			sponsorship.ParamSender, e.senderID,
+		).WithGasBudget(100_000),
+		e.sender,
+	)
+	require.NoError(t, err)
+	require.Empty(t, e.sponsorships(e.sponsorID))
+
+}
@@ -32,4 +32,5 @@ var (
 	ErrIllegalCall               = coreerrors.Register("illegal call - entrypoint cannot be called from contracts")
 	ErrSendMultipleNFTs          = coreerrors.Register("cannot send more than 1 NFT").Create()
 	ErrEVMExecutionReverted      = coreerrors.Register("execution reverted: %s") // hex-encoded revert data
+	ErrRequestNotDecrypted       = coreerrors.Register("encrypted request could not be decrypted").Create()
 )
@@ -0,0 +1,134 @@
+package gas
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// BaseFeeConfig configures the dynamic adjustment of the gas price, similar to
+// the base fee of EIP-1559.
+// When enabled, the GasPerToken of the fee policy is the lowest gas price of
+// the chain, and the base gas price of each block is derived from the base gas
+// price and the gas burned of the previous block: it goes up when the block
+// burned more gas than the target, and down when it burned less.
+type BaseFeeConfig struct {
+	Enabled bool `json:"enabled" swagger:"desc(Whether the base gas price is adjusted dynamically),required"`
+	// TargetGasPerBlock is the gas burned per block for which the base gas price stays the same
+	TargetGasPerBlock uint64 `json:"targetGasPerBlock" swagger:"desc(The gas burned per block for which the base gas price stays the same),required"`
+	// MaxChangeDenominator bounds the change of the base gas price between two blocks
+	// to 1/MaxChangeDenominator, reached when a block burns no gas or at least twice the target
+	MaxChangeDenominator uint32 `json:"maxChangeDenominator" swagger:"desc(The maximum change of the base gas price per block is 1/maxChangeDenominator),required"`
+}
+
+func DefaultBaseFeeConfig() *BaseFeeConfig {
+	return &BaseFeeConfig{
+		Enabled:              false,
+		TargetGasPerBlock:    LimitsDefault.MaxGasPerBlock / 2,
+		MaxChangeDenominator: 8,
+	}
+}
+
+func BaseFeeConfigFromBytes(data []byte) (*BaseFeeConfig, error) {
+	return rwutil.ReadFromBytes(data, new(BaseFeeConfig))
+}
+
+func (c *BaseFeeConfig) IsValid() bool {
+	return c.TargetGasPerBlock > 0 && c.MaxChangeDenominator > 0
+}
+
+func (c *BaseFeeConfig) Bytes() []byte {
+	return rwutil.WriteToBytes(c)
+}
+
+func (c *BaseFeeConfig) String() string {
+	return fmt.Sprintf("BaseFeeConfig(enabled: %v, target/block: %d, max change: 1/%d)",
+		c.Enabled,
+		c.TargetGasPerBlock,
+		c.MaxChangeDenominator,
+	)
+}
+
+// NextGasPerToken calculates the base gas price of the next block, given the
+// base gas price of the current block and the gas it burned. The result is
+// never cheaper than minGasPerToken, which is usually the GasPerToken of the
+// fee policy.
+// Only integer arithmetics is used, so that the result is the same on all
+// nodes.
+func (c *BaseFeeConfig) NextGasPerToken(current util.Ratio32, gasBurned uint64, minGasPerToken util.Ratio32) util.Ratio32 {
+	if minGasPerToken.HasZeroComponent() {
+		// free gas
+		return minGasPerToken
+	}
+	if current.HasZeroComponent() {
+		current = minGasPerToken
+	}
+	// price = B/A tokens per gas unit
+	// next price = price * (1 + (burned - target) / (target * denominator))
+	target := new(big.Int).SetUint64(c.TargetGasPerBlock)
+	burned := new(big.Int).SetUint64(gasBurned)
+	if maxBurned := new(big.Int).Lsh(target, 1); burned.Cmp(maxBurned) > 0 {
+		burned = maxBurned
+	}
+	divisor := new(big.Int).Mul(target, new(big.Int).SetUint64(uint64(c.MaxChangeDenominator)))
+	multiplier := new(big.Int).Add(divisor, burned)
+	multiplier.Sub(multiplier, target)
+	if multiplier.Sign() <= 0 {
+		return minGasPerToken
+	}
+	next := ratio32FromBig(
+		divisor.Mul(divisor, new(big.Int).SetUint64(uint64(current.A))),
+		multiplier.Mul(multiplier, new(big.Int).SetUint64(uint64(current.B))),
+	)
+	return MaxGasPrice(next, minGasPerToken)
+}
+
+// MaxGasPrice returns the most expensive of the two GasPerToken ratios, i.e.
+// the one which pays for less gas units with each token
+func MaxGasPrice(r1, r2 util.Ratio32) util.Ratio32 {
+	// r1.B/r1.A < r2.B/r2.A
+	if uint64(r1.B)*uint64(r2.A) < uint64(r2.B)*uint64(r1.A) {
+		return r2
+	}
+	return r1
+}
+
+// ratio32FromBig converts the a:b ratio to a Ratio32, reducing its precision
+// if it does not fit
+func ratio32FromBig(a, b *big.Int) util.Ratio32 {
+	if d := new(big.Int).GCD(nil, nil, a, b); d.Sign() > 0 {
+		a.Quo(a, d)
+		b.Quo(b, d)
+	}
+	if n := max(a.BitLen(), b.BitLen()) - 32; n > 0 {
+		a.Rsh(a, uint(n))
+		b.Rsh(b, uint(n))
+	}
+	return util.Ratio32{
+		A: uint32(max(a.Uint64(), 1)),
+		B: uint32(max(b.Uint64(), 1)),
+	}
+}
+
+func (c *BaseFeeConfig) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	c.Enabled = rr.ReadBool()
+	c.TargetGasPerBlock = rr.ReadGas64()
+	c.MaxChangeDenominator = rr.ReadUint32()
+	if rr.Err == nil && !c.IsValid() {
+		rr.Err = errors.New("invalid base fee config")
+	}
+	return rr.Err
+}
+
+func (c *BaseFeeConfig) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteBool(c.Enabled)
+	ww.WriteGas64(c.TargetGasPerBlock)
+	ww.WriteUint32(c.MaxChangeDenominator)
+	return ww.Err
+}
@@ -0,0 +1,55 @@
+package gas_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+func TestBaseFeeConfigSerialization(t *testing.T) {
+	rwutil.ReadWriteTest(t, &gas.BaseFeeConfig{
+		Enabled:              true,
+		TargetGasPerBlock:    1234,
+		MaxChangeDenominator: 8,
+	}, new(gas.BaseFeeConfig))
+}
+
+func TestNextGasPerToken(t *testing.T) {
+	config := &gas.BaseFeeConfig{
+		Enabled:              true,
+		TargetGasPerBlock:    1000,
+		MaxChangeDenominator: 8,
+	}
+	minGasPerToken := util.Ratio32{A: 100, B: 1}
+
+	// on target: the price stays the same
+	require.Equal(t, minGasPerToken, config.NextGasPerToken(minGasPerToken, 1000, minGasPerToken))
+
+	// full block: the price goes up by 1/8
+	next := config.NextGasPerToken(minGasPerToken, 2000, minGasPerToken)
+	require.Equal(t, util.Ratio32{A: 800, B: 9}, next)
+	// the change is capped
+	require.Equal(t, next, config.NextGasPerToken(minGasPerToken, 1_000_000, minGasPerToken))
+
+	next = config.NextGasPerToken(next, 2000, minGasPerToken)
+	require.Equal(t, util.Ratio32{A: 6400, B: 81}, next)
+
+	// empty block: the price goes down by 1/8, but not below the minimum
+	require.Equal(t, util.Ratio32{A: 51200, B: 567}, config.NextGasPerToken(next, 0, minGasPerToken))
+	require.Equal(t, minGasPerToken, config.NextGasPerToken(util.Ratio32{A: 800, B: 9}, 0, minGasPerToken))
+
+	// free gas
+	require.Equal(t, util.Ratio32{}, config.NextGasPerToken(next, 2000, util.Ratio32{}))
+
+	// the precision is reduced when the ratio does not fit in 32 bits
+	r := minGasPerToken
+	for i := 0; i < 1000; i++ {
+		r = config.NextGasPerToken(r, 2000, minGasPerToken)
+		require.True(t, r.IsValid())
+		require.Equal(t, r, gas.MaxGasPrice(r, minGasPerToken))
+	}
+}
@@ -53,6 +53,10 @@ func (p *FeePolicy) FeeFromGasBurned(gasUnits, availableTokens uint64) (sendToOw
 }
 
 func FeeFromGas(gasUnits uint64, gasPerToken util.Ratio32) uint64 {
+	// special case '0:0' for free gas
+	if gasPerToken.IsZero() {
+		return 0
+	}
 	return gasPerToken.YCeil64(gasUnits)
 }
 
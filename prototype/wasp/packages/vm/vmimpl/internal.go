@@ -15,6 +15,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/corecontracts"
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
 )
 
@@ -148,6 +149,7 @@ func (reqctx *requestContext) writeReceiptToBlockLog(vmError *isc.VMError) *bloc
 		GasFeeCharged: reqctx.gas.feeCharged,
 		GasBurnLog:    reqctx.gas.burnLog,
 		SDCharged:     reqctx.sdCharged,
+		ContractFees:  reqctx.gas.contractFees,
 	}
 
 	if vmError != nil {
@@ -231,6 +233,14 @@ func (reqctx *requestContext) updateOffLedgerRequestNonce() {
 	})
 }
 
+// markScheduledCallExecuted advances or removes the scheduled call executed by
+// the request, whether it succeeded or not
+func (reqctx *requestContext) markScheduledCallExecuted(call *isc.ScheduledCall) {
+	reqctx.callCore(scheduler.Contract, func(s kv.KVStore) {
+		scheduler.MarkExecuted(s, call, reqctx.vm.task.TimeAssumption)
+	})
+}
+
 // adjustL2BaseTokensIfNeeded adjust L2 ledger for base tokens if the L1 changed because of storage deposit changes
 func (reqctx *requestContext) adjustL2BaseTokensIfNeeded(adjustment int64, account isc.AgentID) {
 	if adjustment == 0 {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
@@ -23,6 +24,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/processors"
 	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
@@ -163,9 +165,167 @@ func (reqctx *requestContext) prepareGasBudget() {
 	if !reqctx.shouldChargeGasFee() {
 		return
 	}
+	reqctx.loadGasFees()
+	reqctx.gas.tip = reqctx.requestTip()
 	reqctx.gasSetBudget(reqctx.calculateAffordableGasBudget())
 }
 
+// loadGasFees loads the fee overrides of the target contract and the
+// sponsorship of the request, which determine the fee paid by the sender
+func (reqctx *requestContext) loadGasFees() {
+	reqctx.callCore(governance.Contract, func(s kv.KVStore) {
+		reqctx.gas.contractFees = reqctx.targetContractFees(s)
+	})
+	reqctx.gas.sponsorship = reqctx.findGasSponsorship()
+}
+
+// maxGasFee returns the fee for the gas budget of the request at the current
+// gas price of the chain, including the fee overrides of the target contract
+func (reqctx *requestContext) maxGasFee() uint64 {
+	gasBudget, _ := reqctx.req.GasBudget()
+	gasBudget = min(gasBudget, reqctx.vm.chainInfo.GasLimits.MaxGasPerRequest)
+	fee := reqctx.gasFeePolicy().FeeFromGas(gasBudget)
+	fixedFee := reqctx.fixedFee()
+	if fee > math.MaxUint64-fixedFee {
+		return math.MaxUint64
+	}
+	return fee + fixedFee
+}
+
+// requestTip returns the tip paid by the request to the validators, reduced
+// so that the fee for the gas budget plus the tip does not exceed the max fee.
+// For EVM transactions it is the priority fee for the whole gas limit, see
+// chargedTip.
+func (reqctx *requestContext) requestTip() uint64 {
+	offLedgerReq, ok := reqctx.req.(isc.OffLedgerRequest)
+	if !ok {
+		return 0
+	}
+	if tx := offLedgerReq.EVMTransaction(); tx != nil {
+		return evmutil.TipBaseTokens(tx, reqctx.vm.chainInfo.GasFeePolicy, tx.Gas())
+	}
+	tip, maxFee := offLedgerReq.Tip(), offLedgerReq.MaxFee()
+	if maxFee == 0 {
+		return tip
+	}
+	fee := reqctx.maxGasFee()
+	if fee >= maxFee {
+		return 0
+	}
+	return min(tip, maxFee-fee)
+}
+
+// findGasSponsorship returns the sponsorship that pays the gas fee of the
+// request instead of the sender, if any. Only off-ledger requests can be
+// sponsored, and only while the sponsor can pay at least the minimum fee.
+func (reqctx *requestContext) findGasSponsorship() *sponsorship.Sponsorship {
+	if !reqctx.req.IsOffLedger() {
+		return nil
+	}
+	sender := reqctx.req.SenderAccount()
+	var s *sponsorship.Sponsorship
+	reqctx.callCore(sponsorship.Contract, func(state kv.KVStore) {
+		s = sponsorship.Find(state, sender, reqctx.requestTarget())
+	})
+	if s == nil || s.Sponsor.Equals(sender) {
+		return nil
+	}
+	if !reqctx.gasFeePolicy().IsEnoughForMinimumFee(reqctx.sponsoredFeeTokens(s)) {
+		return nil
+	}
+	return s
+}
+
+// sponsoredFeeTokens returns the maximum amount of tokens the sponsor can pay
+// for the gas fee of the request
+func (reqctx *requestContext) sponsoredFeeTokens(s *sponsorship.Sponsorship) uint64 {
+	var remaining uint64
+	reqctx.callCore(sponsorship.Contract, func(state kv.KVStore) {
+		remaining = sponsorship.Remaining(state, s, reqctx.req.SenderAccount(), reqctx.vm.task.TimeAssumption)
+	})
+	return min(remaining, reqctx.GetBaseTokensBalance(s.Sponsor))
+}
+
+// requestTarget returns the contract called by the request, see
+// isc.RequestTargetAgentID
+func (reqctx *requestContext) requestTarget() isc.AgentID {
+	return isc.RequestTargetAgentID(reqctx.req, reqctx.ChainID())
+}
+
+// targetContractFees returns the fee overrides of the contract called by the
+// request: EVM transactions are keyed by the called EVM contract, and contract
+// creations always follow the fee policy of the chain
+func (reqctx *requestContext) targetContractFees(governanceState kv.KVStoreReader) *governance.ContractFeesRecord {
+	switch target := reqctx.requestTarget().(type) {
+	case nil:
+		return nil
+	case *isc.EthereumAddressAgentID:
+		return governance.MustGetEVMContractFees(governanceState, target.EthAddress())
+	default:
+		return governance.MustGetContractFees(governanceState, reqctx.req.CallTarget().Contract)
+	}
+}
+
+// gasFeePolicy returns the fee policy applied to the gas burned by the
+// request, taking into account the fee overrides of the target contract
+func (reqctx *requestContext) gasFeePolicy() *gas.FeePolicy {
+	if reqctx.gas.contractFees == nil {
+		return reqctx.vm.chainInfo.GasFeePolicy
+	}
+	return reqctx.gas.contractFees.FeePolicy(reqctx.vm.chainInfo.GasFeePolicy)
+}
+
+// fixedFee returns the fixed fee charged for the request by the target
+// contract, if any
+func (reqctx *requestContext) fixedFee() uint64 {
+	if reqctx.gas.contractFees == nil {
+		return 0
+	}
+	return reqctx.gas.contractFees.FixedFee()
+}
+
+// feeFromGasBurned calculates the fee charged for the request: first the
+// fixed fees of the target contract, then the fee for the gas burned, and
+// finally the tip
+func (reqctx *requestContext) feeFromGasBurned(availableTokens uint64) (sendToOwner, sendToValidator uint64) {
+	var ownerFee, validatorFee uint64
+	if fees := reqctx.gas.contractFees; fees != nil {
+		ownerFee = min(fees.OwnerFee, availableTokens)
+		availableTokens -= ownerFee
+		validatorFee = min(fees.ValidatorFee, availableTokens)
+		availableTokens -= validatorFee
+	}
+	sendToOwner, sendToValidator = reqctx.gasFeePolicy().FeeFromGasBurned(reqctx.GasBurned(), availableTokens)
+	// the tip goes to the validators, if there are tokens left
+	tip := min(reqctx.chargedTip(), availableTokens-sendToOwner-sendToValidator)
+	return sendToOwner + ownerFee, sendToValidator + validatorFee + tip
+}
+
+// chargedTip returns the tip charged for the request. Like in Ethereum, EVM
+// transactions pay the priority fee for the gas used only, the rest of the
+// requests pay the whole tip.
+func (reqctx *requestContext) chargedTip() uint64 {
+	offLedgerReq, ok := reqctx.req.(isc.OffLedgerRequest)
+	if !ok || reqctx.gas.tip == 0 {
+		return reqctx.gas.tip
+	}
+	tx := offLedgerReq.EVMTransaction()
+	if tx == nil {
+		return reqctx.gas.tip
+	}
+	policy := reqctx.vm.chainInfo.GasFeePolicy
+	evmGasUsed := gas.ISCGasBurnedToEVM(reqctx.GasBurned(), &policy.EVMGasRatio)
+	return min(reqctx.gas.tip, evmutil.TipBaseTokens(tx, policy, evmGasUsed))
+}
+
+// gasFeePayer returns the account charged for the gas fee of the request
+func (reqctx *requestContext) gasFeePayer() isc.AgentID {
+	if reqctx.gas.sponsorship != nil {
+		return reqctx.gas.sponsorship.Sponsor
+	}
+	return reqctx.req.SenderAccount()
+}
+
 // callTheContract runs the contract. if an error is returned, the request will be skipped
 func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 	// TODO: do not mutate vmContext's txbuilder
@@ -242,6 +402,9 @@ func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 		if reqctx.req.IsOffLedger() {
 			reqctx.updateOffLedgerRequestNonce()
 		}
+		if req, ok := reqctx.req.(isc.ScheduledRequest); ok {
+			reqctx.markScheduledCallExecuted(req.ScheduledCall())
+		}
 	})
 
 	if err != nil {
@@ -284,6 +447,10 @@ func (reqctx *requestContext) callFromRequest() dict.Dict {
 		// if sender unknown, follow panic path
 		panic(vm.ErrSenderUnknown)
 	}
+	if encryptedReq, ok := req.(isc.EncryptedOffLedgerRequest); ok && !encryptedReq.IsDecrypted() {
+		// the committee could not decrypt the call, the sender still pays for the gas
+		panic(vm.ErrRequestNotDecrypted)
+	}
 
 	contract := req.CallTarget().Contract
 	entryPoint := req.CallTarget().EntryPoint
@@ -333,11 +500,16 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget, maxTokensT
 
 	// calculate how many tokens for gas fee can be guaranteed after taking into account the allowance
 	guaranteedFeeTokens := reqctx.calcGuaranteedFeeTokens()
+	// the fixed fee of the target contract is reserved first
+	fixedFee := min(reqctx.fixedFee(), guaranteedFeeTokens)
+	guaranteedFeeTokens -= fixedFee
 	// calculate how many tokens maximum will be charged taking into account the budget
-	f1, f2 := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(gasBudget, guaranteedFeeTokens)
-	maxTokensToSpendForGasFee = f1 + f2
+	feePolicy := reqctx.gasFeePolicy()
+	f1, f2 := feePolicy.FeeFromGasBurned(gasBudget, guaranteedFeeTokens)
+	tip := min(reqctx.gas.tip, guaranteedFeeTokens-f1-f2)
+	maxTokensToSpendForGasFee = f1 + f2 + fixedFee + tip
 	// calculate affordableGas gas budget
-	affordableGas := reqctx.vm.chainInfo.GasFeePolicy.GasBudgetFromTokens(guaranteedFeeTokens)
+	affordableGas := feePolicy.GasBudgetFromTokens(guaranteedFeeTokens, reqctx.vm.chainInfo.GasLimits)
 	// adjust gas budget to what is affordable
 	affordableGas = min(gasBudget, affordableGas)
 	// cap gas to the maximum allowed per tx
@@ -347,6 +519,9 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget, maxTokensT
 // calcGuaranteedFeeTokens return the maximum tokens (base tokens or native) can be guaranteed for the fee,
 // taking into account allowance (which must be 'reserved')
 func (reqctx *requestContext) calcGuaranteedFeeTokens() uint64 {
+	if s := reqctx.gas.sponsorship; s != nil {
+		return reqctx.sponsoredFeeTokens(s)
+	}
 	tokensGuaranteed := reqctx.GetBaseTokensBalance(reqctx.req.SenderAccount())
 	// safely subtract the allowed from the sender to the target
 	if allowed := reqctx.req.Allowance(); allowed != nil {
@@ -359,7 +534,7 @@ func (reqctx *requestContext) calcGuaranteedFeeTokens() uint64 {
 	return tokensGuaranteed
 }
 
-// chargeGasFee takes burned tokens from the sender's account
+// chargeGasFee takes burned tokens from the account of the sender (or of the sponsor)
 // It should always be enough because gas budget is set affordable
 func (reqctx *requestContext) chargeGasFee() {
 	defer func() {
@@ -378,13 +553,16 @@ func (reqctx *requestContext) chargeGasFee() {
 	}
 
 	availableToPayFee := reqctx.gas.maxTokensToSpendForGasFee
-	if !reqctx.vm.task.EstimateGasMode && !reqctx.vm.chainInfo.GasFeePolicy.IsEnoughForMinimumFee(availableToPayFee) {
+	if s := reqctx.gas.sponsorship; s != nil && !reqctx.vm.task.EstimateGasMode {
+		// the balance of the sponsor may have changed during the execution
+		availableToPayFee = min(availableToPayFee, reqctx.sponsoredFeeTokens(s))
+	} else if !reqctx.vm.task.EstimateGasMode && !reqctx.gasFeePolicy().IsEnoughForMinimumFee(availableToPayFee) {
 		// user didn't specify enough base tokens to cover the minimum request fee, charge whatever is present in the user's account
 		availableToPayFee = reqctx.GetSenderTokenBalanceForFees()
 	}
 
 	// total fees to charge
-	sendToPayout, sendToValidator := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(reqctx.GasBurned(), availableToPayFee)
+	sendToPayout, sendToValidator := reqctx.feeFromGasBurned(availableToPayFee)
 	reqctx.gas.feeCharged = sendToPayout + sendToValidator
 
 	// calc gas totals
@@ -395,13 +573,19 @@ func (reqctx *requestContext) chargeGasFee() {
 		return
 	}
 
-	sender := reqctx.req.SenderAccount()
+	if s := reqctx.gas.sponsorship; s != nil {
+		reqctx.callCore(sponsorship.Contract, func(state kv.KVStore) {
+			sponsorship.AddUsage(state, s, reqctx.req.SenderAccount(), reqctx.gas.feeCharged, reqctx.vm.task.TimeAssumption)
+		})
+	}
+
+	payer := reqctx.gasFeePayer()
 	if sendToValidator != 0 {
 		transferToValidator := &isc.Assets{}
 		transferToValidator.BaseTokens = sendToValidator
 		mustMoveBetweenAccounts(
 			reqctx.uncommittedState,
-			sender,
+			payer,
 			reqctx.vm.task.ValidatorFeeTarget,
 			transferToValidator,
 			reqctx.ChainID(),
@@ -425,7 +609,7 @@ func (reqctx *requestContext) chargeGasFee() {
 			sendToPayout = excess
 		}
 		mustMoveBetweenAccounts(reqctx.uncommittedState,
-			sender,
+			payer,
 			accounts.CommonAccount(),
 			isc.NewAssetsBaseTokens(transferToCommonAcc),
 			reqctx.ChainID(),
@@ -435,7 +619,7 @@ func (reqctx *requestContext) chargeGasFee() {
 		payoutAgentID := reqctx.vm.payoutAgentID()
 		mustMoveBetweenAccounts(
 			reqctx.uncommittedState,
-			sender,
+			payer,
 			payoutAgentID,
 			isc.NewAssetsBaseTokens(sendToPayout),
 			reqctx.ChainID(),
@@ -461,7 +645,9 @@ func (reqctx *requestContext) GetContractRecord(contractHname isc.Hname) (ret *r
 }
 
 func (vmctx *vmContext) loadChainConfig() {
-	vmctx.chainInfo = governance.NewStateAccess(vmctx.stateDraft).ChainInfo(vmctx.ChainID())
+	governanceState := governance.NewStateAccess(vmctx.stateDraft)
+	vmctx.chainInfo = governanceState.ChainInfo(vmctx.ChainID())
+	vmctx.dynamicGasPrice = governanceState.BaseFeeConfig().Enabled
 }
 
 // checkTransactionSize panics with ErrMaxTransactionSizeExceeded if the estimated transaction size exceeds the limit
@@ -15,9 +15,13 @@ import (
 	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/migrations"
 	"github.com/iotaledger/wasp/packages/vm/core/migrations/allmigrations"
+	"github.com/iotaledger/wasp/packages/vm/core/oracle"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
 	"github.com/iotaledger/wasp/packages/vm/vmtxbuilder"
 )
@@ -56,11 +60,14 @@ func runTask(task *vm.VMTask) *vm.VMTaskResult {
 	}
 
 	vmctx.init(prevL1Commitment)
+	vmctx.applyEVMStateOverrides()
 
-	// run the batch of requests
+	maintenanceMode := governance.NewStateAccess(stateDraft).MaintenanceStatus()
+
+	// run the scheduled calls that are due, followed by the batch of requests
 	requestResults, numSuccess, numOffLedger, unprocessable := vmctx.runRequests(
-		vmctx.task.Requests,
-		governance.NewStateAccess(stateDraft).MaintenanceStatus(),
+		append(vmctx.dueScheduledCalls(maintenanceMode), vmctx.task.Requests...),
+		maintenanceMode,
 		vmctx.task.Log,
 	)
 
@@ -129,6 +136,15 @@ func (vmctx *vmContext) init(prevL1Commitment *state.L1Commitment) {
 		})
 	})
 
+	// save the oracle values agreed by the committee for this block
+	if vmctx.task.OracleData != nil {
+		vmctx.withStateUpdate(func(chainState kv.KVStore) {
+			withContractState(chainState, oracle.Contract, func(s kv.KVStore) {
+				oracle.SaveValues(s, vmctx.task.OracleData, vmctx.task.AnchorOutput.StateIndex+1, vmctx.task.TimeAssumption)
+			})
+		})
+	}
+
 	vmctx.txbuilder = vmtxbuilder.NewAnchorTransactionBuilder(
 		vmctx.task.AnchorOutput,
 		vmctx.task.AnchorOutputID,
@@ -142,6 +158,43 @@ func (vmctx *vmContext) init(prevL1Commitment *state.L1Commitment) {
 	)
 }
 
+// applyEVMStateOverrides applies the EVM state overrides of a simulated block
+// once, before its requests, so that they are not lost if a request is
+// reverted.
+func (vmctx *vmContext) applyEVMStateOverrides() {
+	overrides := vmctx.task.EVMCallOverrides
+	if !vmctx.task.SimulationMode || overrides == nil || len(overrides.State) == 0 {
+		return
+	}
+	vmctx.withStateUpdate(func(chainState kv.KVStore) {
+		withContractState(chainState, evm.Contract, func(evmPartition kv.KVStore) {
+			withContractState(chainState, accounts.Contract, func(accountsPartition kv.KVStore) {
+				evmimpl.ApplyStateOverrides(evmPartition, accountsPartition, vmctx.chainInfo, overrides.State)
+			})
+		})
+	})
+}
+
+// dueScheduledCalls returns the requests for the scheduled calls that must be
+// executed in this block. Scheduled calls are executed only in actual blocks,
+// and are postponed while the chain is in maintenance mode. Each call is
+// marked as executed by its own request, so that it stays due if the request
+// is skipped.
+func (vmctx *vmContext) dueScheduledCalls(maintenanceMode bool) []isc.Request {
+	if maintenanceMode || vmctx.task.SimulationMode || !vmctx.task.WillProduceBlock() {
+		return nil
+	}
+	var calls []*isc.ScheduledCall
+	withContractState(vmctx.stateDraft, scheduler.Contract, func(s kv.KVStore) {
+		calls = scheduler.DueCalls(s, vmctx.task.TimeAssumption)
+	})
+	reqs := make([]isc.Request, len(calls))
+	for i, call := range calls {
+		reqs[i] = isc.NewScheduledCallRequest(vmctx.ChainID(), call)
+	}
+	return reqs
+}
+
 func (vmctx *vmContext) getMigrations() *migrations.MigrationScheme {
 	if vmctx.task.MigrationsOverride != nil {
 		return vmctx.task.MigrationsOverride
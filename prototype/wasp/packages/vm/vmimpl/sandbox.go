@@ -99,6 +99,24 @@ func (s *contractSandbox) EVMTracer() *isc.EVMTracer {
 	return s.reqctx.vm.task.EVMTracer
 }
 
+func (s *contractSandbox) EVMCallOverrides() *isc.EVMCallOverrides {
+	overrides := s.reqctx.vm.task.EVMCallOverrides
+	if overrides != nil && s.reqctx.vm.task.SimulationMode {
+		// in simulation mode the state overrides are applied once at the
+		// beginning of the block, see applyEVMStateOverrides
+		return &isc.EVMCallOverrides{Block: overrides.Block}
+	}
+	return overrides
+}
+
+func (s *contractSandbox) SimulationMode() bool {
+	return s.reqctx.vm.task.SimulationMode
+}
+
+func (s *contractSandbox) RecordSimulatedCalls() bool {
+	return s.reqctx.vm.task.SimulationMode && s.reqctx.vm.task.RecordSimulatedCalls
+}
+
 // helper methods
 
 func (s *contractSandbox) RequireCallerAnyOf(agentIDs []isc.AgentID) {
@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/scheduler"
 	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
 )
 
@@ -40,6 +41,10 @@ func (reqctx *requestContext) earlyCheckReasonToSkip(maintenanceMode bool) error
 		return errors.New("skipped due to maintenance mode")
 	}
 
+	if req, ok := reqctx.req.(isc.ScheduledRequest); ok {
+		// created by the VM itself from the scheduler state
+		return reqctx.checkReasonScheduledCallNotDue(req.ScheduledCall())
+	}
 	if reqctx.req.IsOffLedger() {
 		return reqctx.checkReasonToSkipOffLedger()
 	}
@@ -59,6 +64,19 @@ func (reqctx *requestContext) checkReasonRequestProcessed() error {
 	return nil
 }
 
+// checkReasonScheduledCallNotDue checks if the scheduled call was cancelled or
+// already executed by a previous request of the block
+func (reqctx *requestContext) checkReasonScheduledCallNotDue(call *isc.ScheduledCall) error {
+	var isDue bool
+	withContractState(reqctx.uncommittedState, scheduler.Contract, func(s kv.KVStore) {
+		isDue = scheduler.IsDue(s, call)
+	})
+	if !isDue {
+		return errors.New("scheduled call is no longer due")
+	}
+	return nil
+}
+
 // checkReasonToSkipOffLedger checks reasons to skip off ledger request
 func (reqctx *requestContext) checkReasonToSkipOffLedger() error {
 	if reqctx.vm.task.EstimateGasMode {
@@ -93,10 +111,57 @@ func (reqctx *requestContext) checkReasonToSkipOffLedger() error {
 	}
 
 	if evmTx := offledgerReq.EVMTransaction(); evmTx != nil {
-		if err := evmutil.CheckGasPrice(evmTx, reqctx.vm.chainInfo.GasFeePolicy); err != nil {
+		if err := evmutil.CheckGasPrice(evmTx, reqctx.vm.chainInfo.GasFeePolicy, reqctx.vm.dynamicGasPrice); err != nil {
 			return err
 		}
 	}
+	if encryptedReq, ok := offledgerReq.(isc.EncryptedOffLedgerRequest); ok {
+		if err := reqctx.checkReasonDeadlinePassed(encryptedReq); err != nil {
+			return err
+		}
+		if err := reqctx.checkReasonEncapsulatedKeyUsed(encryptedReq); err != nil {
+			return err
+		}
+	}
+	return reqctx.checkReasonMaxFee(offledgerReq)
+}
+
+// checkReasonDeadlinePassed skips the encrypted request after its deadline, as
+// its call could have been revealed by a consensus round, that did not
+// produce a block.
+func (reqctx *requestContext) checkReasonDeadlinePassed(req isc.EncryptedOffLedgerRequest) error {
+	if blockIndex := reqctx.vm.stateDraft.BlockIndex(); blockIndex > req.Deadline() {
+		return fmt.Errorf("deadline passed: block %d, deadline %d", blockIndex, req.Deadline())
+	}
+	return nil
+}
+
+// checkReasonEncapsulatedKeyUsed skips the encrypted request, if its key was
+// used by a request processed already, as the key is known since then.
+func (reqctx *requestContext) checkReasonEncapsulatedKeyUsed(req isc.EncryptedOffLedgerRequest) error {
+	// A malformed key is not skipped here, the request fails, as it cannot be decrypted.
+	used, err := blocklog.IsEncapsulatedKeyUsed(reqctx.uncommittedState, req.EncapsulatedKey())
+	if err == nil && used {
+		return errors.New("encapsulated key already used")
+	}
+	return nil
+}
+
+// checkReasonMaxFee skips the request while the fee for its gas budget at the
+// current gas price exceeds the max fee set by the sender. Sponsored requests
+// are never skipped, since the sender does not pay the fee.
+func (reqctx *requestContext) checkReasonMaxFee(req isc.OffLedgerRequest) error {
+	maxFee := req.MaxFee()
+	if maxFee == 0 || !reqctx.shouldChargeGasFee() {
+		return nil
+	}
+	reqctx.loadGasFees()
+	if reqctx.gas.sponsorship != nil {
+		return nil
+	}
+	if fee := reqctx.maxGasFee(); fee > maxFee {
+		return fmt.Errorf("gas price too high: fee for the gas budget is %d, max fee is %d", fee, maxFee)
+	}
 	return nil
 }
 
@@ -25,7 +25,7 @@ func (vmctx *vmContext) stateMetadata(stateCommitment *state.L1Commitment) []byt
 	withContractState(vmctx.stateDraft, governance.Contract, func(s kv.KVStore) {
 		// On error, the publicURL is len(0)
 		stateMetadata.PublicURL, _ = governance.GetPublicURL(s)
-		stateMetadata.GasFeePolicy = governance.MustGetGasFeePolicy(s)
+		stateMetadata.GasFeePolicy = governance.MustGetCurrentGasFeePolicy(s)
 	})
 
 	return stateMetadata.Bytes()
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/buffered"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
@@ -17,6 +18,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/sponsorship"
 	"github.com/iotaledger/wasp/packages/vm/execution"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/vm/vmtxbuilder"
@@ -32,7 +34,10 @@ type vmContext struct {
 	stateDraft state.StateDraft
 	txbuilder  *vmtxbuilder.AnchorTransactionBuilder
 	chainInfo  *isc.ChainInfo
-	blockGas   blockGas
+	// dynamicGasPrice is true if the base gas price of the chain is adjusted
+	// dynamically, see gas.BaseFeeConfig
+	dynamicGasPrice bool
+	blockGas        blockGas
 }
 
 type blockGas struct {
@@ -76,6 +81,12 @@ type requestGas struct {
 	burned uint64
 	// tokens charged
 	feeCharged uint64
+	// sponsorship paying the gas fee instead of the sender, if any
+	sponsorship *sponsorship.Sponsorship
+	// fee overrides of the target contract, if any
+	contractFees *governance.ContractFeesRecord
+	// tip paid to the validators on top of the gas fee
+	tip uint64
 	// burn history. If disabled, it is nil
 	burnLog *gas.BurnLog
 }
@@ -143,6 +154,12 @@ func (vmctx *vmContext) saveBlockInfo(numRequests, numSuccess, numOffLedger uint
 		return rotationAddress
 	}
 
+	var gasPerToken util.Ratio32
+	withContractState(vmctx.stateDraft, governance.Contract, func(s kv.KVStore) {
+		gasPerToken = governance.MustGetCurrentGasFeePolicy(s).GasPerToken
+		governance.UpdateBaseGasPrice(s, vmctx.blockGas.burned)
+	})
+
 	blockInfo := &blocklog.BlockInfo{
 		SchemaVersion:         blocklog.BlockInfoLatestSchemaVersion,
 		Timestamp:             vmctx.stateDraft.Timestamp(),
@@ -152,6 +169,7 @@ func (vmctx *vmContext) saveBlockInfo(numRequests, numSuccess, numOffLedger uint
 		PreviousAliasOutput:   isc.NewAliasOutputWithID(vmctx.task.AnchorOutput, vmctx.task.AnchorOutputID),
 		GasBurned:             vmctx.blockGas.burned,
 		GasFeeCharged:         vmctx.blockGas.feeCharged,
+		GasPerToken:           gasPerToken,
 	}
 
 	withContractState(vmctx.stateDraft, blocklog.Contract, func(s kv.KVStore) {
@@ -26,11 +26,26 @@ type VMTask struct {
 	TimeAssumption     time.Time
 	Entropy            hashing.HashValue
 	ValidatorFeeTarget isc.AgentID
+	// If OracleData is set, the oracle values agreed by the committee are
+	// saved in the state of the oracle core contract
+	OracleData *isc.OracleData
 	// If EstimateGasMode is enabled, gas fee will be calculated but not charged
 	EstimateGasMode bool
 	// If EVMTracer is set, all requests will be executed normally up until the EVM
 	// tx with the given index, which will then be executed with the given tracer.
-	EVMTracer            *isc.EVMTracer
+	EVMTracer *isc.EVMTracer
+	// If EVMCallOverrides is set, the EVM state and block context are
+	// modified before executing EVM calls.
+	EVMCallOverrides *isc.EVMCallOverrides
+	// If SimulationMode is enabled (usually together with EstimateGasMode),
+	// the block is produced even with EVMCallOverrides, and EVM calls keep
+	// their changes to the state, so that several simulated blocks can be
+	// chained on top of a buffered Store.
+	SimulationMode bool
+	// If RecordSimulatedCalls is enabled together with SimulationMode, each
+	// EVM call is also recorded in the EVM block as an impersonated
+	// transaction, so that its receipt can be retrieved later.
+	RecordSimulatedCalls bool // for Solo only
 	EnableGasBurnLogging bool // for testing and Solo only
 
 	MigrationsOverride *migrations.MigrationScheme // for testing and Solo only
@@ -64,7 +79,10 @@ type RequestResult struct {
 }
 
 func (task *VMTask) WillProduceBlock() bool {
-	return !task.EstimateGasMode && task.EVMTracer == nil
+	if task.SimulationMode {
+		return task.EVMTracer == nil
+	}
+	return !task.EstimateGasMode && task.EVMTracer == nil && task.EVMCallOverrides == nil
 }
 
 func (task *VMTask) FinalStateTimestamp() time.Time {
@@ -56,6 +56,10 @@ func NoRecordFoundError(err error) *HTTPError {
 	return NewHTTPError(http.StatusNotFound, "Record not found", err)
 }
 
+func RequestNotInMempoolError(requestID string) *HTTPError {
+	return NewHTTPError(http.StatusNotFound, fmt.Sprintf("Request: %v not found in the mempool", requestID), nil)
+}
+
 func ReceiptError(err error) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, "Failed to get receipt", err)
 }
@@ -66,6 +66,10 @@ func EstimateGas(ch chainpkg.Chain, req isc.Request) (*isc.Receipt, error) {
 	if err != nil {
 		return nil, err
 	}
+	if rec.Error == nil {
+		// The request is likely to be sent next, help the mempool to fit it into a block.
+		ch.GasEstimated(req, rec.GasBurned)
+	}
 	parsedRec, err := ParseReceipt(ch, rec)
 	return parsedRec, err
 }
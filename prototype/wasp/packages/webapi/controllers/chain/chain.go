@@ -42,6 +42,7 @@ func (c *Controller) getCommitteeInfo(e echo.Context) error {
 		CommitteeNodes: models.MapCommitteeNodes(chainNodeInfo.CommitteeNodes),
 		AccessNodes:    models.MapCommitteeNodes(chainNodeInfo.AccessNodes),
 		CandidateNodes: models.MapCommitteeNodes(chainNodeInfo.CandidateNodes),
+		EncryptionKey:  iotago.EncodeHex(chainNodeInfo.EncryptionKey),
 	}
 
 	return e.JSON(http.StatusOK, chainInfo)
@@ -178,4 +178,26 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		AddResponse(http.StatusOK, "Access node was successfully removed", nil, nil).
 		SetSummary("Remove an access node.").
 		SetOperationId("removeAccessNode")
+
+	adminAPI.GET("chains/:chainID/mempool", c.getMempoolInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddResponse(http.StatusOK, "The number of requests in the pools of the mempool", mocker.Get(models.MempoolInfoResponse{}), nil).
+		SetSummary("Get the summary of the mempool of the chain").
+		SetOperationId("getMempoolInfo")
+
+	adminAPI.GET("chains/:chainID/mempool/requests", c.getMempoolRequests, authentication.ValidatePermissions([]string{permissions.Read})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamQuery("", params.ParamAfter, "The seq of the last request of the previous page (uint64 as string), the first page is returned if omitted", false).
+		AddParamQuery(0, params.ParamLimit, "The maximal number of requests to return (default 100, maximum 1000)", false).
+		AddResponse(http.StatusOK, "A page of the requests in the mempool, in the order they were added", mocker.Get(models.MempoolRequestsResponse{}), nil).
+		SetSummary("Get the requests in the mempool of the chain").
+		SetOperationId("getMempoolRequests")
+
+	adminAPI.DELETE("chains/:chainID/mempool/requests/:requestID", c.removeMempoolRequest, authentication.ValidatePermissions([]string{permissions.Write})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamPath("", params.ParamRequestID, params.DescriptionRequestID).
+		AddResponse(http.StatusNotFound, "The request is not in the mempool", nil, nil).
+		AddResponse(http.StatusOK, "The request was removed from the mempool", nil, nil).
+		SetSummary("Remove a request from the mempool of the chain, a removed on-ledger request is read from L1 again after a restart").
+		SetOperationId("removeMempoolRequest")
 }
@@ -0,0 +1,92 @@
+package chain
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+
+	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+const (
+	defaultMempoolRequestsLimit = 100
+	maximumMempoolRequestsLimit = 1000
+)
+
+func (c *Controller) getMempoolInfo(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_mempool_info")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	page, err := c.chainService.GetMempoolRequests(e.Request().Context(), chainID, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, models.MapMempoolInfoResponse(chainID, page.PoolSizes))
+}
+
+func (c *Controller) getMempoolRequests(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_mempool_requests")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	var after uint64
+	limit := defaultMempoolRequestsLimit
+	if err = echo.QueryParamsBinder(e).
+		Uint64(params.ParamAfter, &after).
+		Int(params.ParamLimit, &limit).
+		BindError(); err != nil {
+		return err
+	}
+	limit = min(max(limit, 1), maximumMempoolRequestsLimit)
+
+	page, err := c.chainService.GetMempoolRequests(e.Request().Context(), chainID, after, limit)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	res := models.MempoolRequestsResponse{
+		Requests: make([]models.MempoolRequest, 0, len(page.Requests)),
+	}
+	for _, size := range page.PoolSizes {
+		res.Total += uint32(size)
+	}
+	for _, req := range page.Requests {
+		res.Requests = append(res.Requests, models.MapMempoolRequest(req, now))
+	}
+	if len(page.Requests) == limit {
+		res.Next = iotago.EncodeUint64(page.Requests[len(page.Requests)-1].Seq)
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) removeMempoolRequest(e echo.Context) error {
+	controllerutils.SetOperation(e, "remove_mempool_request")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := params.DecodeRequestID(e)
+	if err != nil {
+		return err
+	}
+
+	if err := c.chainService.RemoveMempoolRequest(e.Request().Context(), chainID, requestID); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusOK)
+}
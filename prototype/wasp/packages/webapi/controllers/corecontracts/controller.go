@@ -191,6 +191,24 @@ func (c *Controller) addGovernanceContractRoutes(api echoswagger.ApiGroup, mocke
 		SetOperationId("governanceGetAllowedStateControllerAddresses").
 		SetDescription("Returns the allowed state controller addresses").
 		SetSummary("Get the allowed state controller addresses")
+
+	api.GET("chains/:chainID/core/governance/contractfees", c.getAllContractFees).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", authentication.ValidationError{}, nil).
+		AddResponse(http.StatusOK, "The fee overrides of the contracts", mocker.Get([]models.GovContractFeesResponse{}), nil).
+		SetOperationId("governanceGetContractFees").
+		SetDescription("Returns the fee overrides set by the chain owner for specific contracts").
+		SetSummary("Get the contract fee overrides")
+
+	api.GET("chains/:chainID/core/governance/contractfees/evm", c.getAllEVMContractFees).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", authentication.ValidationError{}, nil).
+		AddResponse(http.StatusOK, "The fee overrides of the EVM contracts", mocker.Get([]models.GovEVMContractFeesResponse{}), nil).
+		SetOperationId("governanceGetEVMContractFees").
+		SetDescription("Returns the fee overrides set by the chain owner for specific EVM contracts").
+		SetSummary("Get the EVM contract fee overrides")
 }
 
 //nolint:funlen
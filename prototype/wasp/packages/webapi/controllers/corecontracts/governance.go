@@ -2,6 +2,7 @@ package corecontracts
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/labstack/echo/v4"
 
@@ -87,3 +88,53 @@ func (c *Controller) getAllowedStateControllerAddresses(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, addressesResponse)
 }
+
+func (c *Controller) getAllContractFees(e echo.Context) error {
+	ch, chainID, err := controllerutils.ChainFromParams(e, c.chainService)
+	if err != nil {
+		return c.handleViewCallError(err, chainID)
+	}
+
+	fees, err := corecontracts.GetAllContractFees(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if err != nil {
+		return c.handleViewCallError(err, chainID)
+	}
+
+	contractFeesResponse := make([]models.GovContractFeesResponse, 0, len(fees))
+	for hname, f := range fees {
+		contractFeesResponse = append(contractFeesResponse, models.GovContractFeesResponse{
+			ContractHName: hname.String(),
+			Fees:          models.MapContractFees(f),
+		})
+	}
+	sort.Slice(contractFeesResponse, func(i, j int) bool {
+		return contractFeesResponse[i].ContractHName < contractFeesResponse[j].ContractHName
+	})
+
+	return e.JSON(http.StatusOK, contractFeesResponse)
+}
+
+func (c *Controller) getAllEVMContractFees(e echo.Context) error {
+	ch, chainID, err := controllerutils.ChainFromParams(e, c.chainService)
+	if err != nil {
+		return c.handleViewCallError(err, chainID)
+	}
+
+	fees, err := corecontracts.GetAllEVMContractFees(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if err != nil {
+		return c.handleViewCallError(err, chainID)
+	}
+
+	contractFeesResponse := make([]models.GovEVMContractFeesResponse, 0, len(fees))
+	for address, f := range fees {
+		contractFeesResponse = append(contractFeesResponse, models.GovEVMContractFeesResponse{
+			EVMAddress: address.Hex(),
+			Fees:       models.MapContractFees(f),
+		})
+	}
+	sort.Slice(contractFeesResponse, func(i, j int) bool {
+		return contractFeesResponse[i].EVMAddress < contractFeesResponse[j].EVMAddress
+	})
+
+	return e.JSON(http.StatusOK, contractFeesResponse)
+}
@@ -1,6 +1,8 @@
 package corecontracts
 
 import (
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/isc"
@@ -56,3 +58,37 @@ func GetChainInfo(ch chain.Chain, blockIndexOrTrieRoot string) (*isc.ChainInfo,
 
 	return chainInfo, nil
 }
+
+func GetAllContractFees(ch chain.Chain, blockIndexOrTrieRoot string) (map[isc.Hname]*governance.ContractFeesRecord, error) {
+	ret, err := common.CallView(ch, governance.Contract.Hname(), governance.ViewGetAllContractFees.Hname(), nil, blockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := make(map[isc.Hname]*governance.ContractFeesRecord, len(ret))
+	for key, value := range ret {
+		hname, err := isc.HnameFromBytes([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		if fees[hname], err = governance.ContractFeesRecordFromBytes(value); err != nil {
+			return nil, err
+		}
+	}
+	return fees, nil
+}
+
+func GetAllEVMContractFees(ch chain.Chain, blockIndexOrTrieRoot string) (map[ethcommon.Address]*governance.ContractFeesRecord, error) {
+	ret, err := common.CallView(ch, governance.Contract.Hname(), governance.ViewGetAllEVMContractFees.Hname(), nil, blockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := make(map[ethcommon.Address]*governance.ContractFeesRecord, len(ret))
+	for key, value := range ret {
+		if fees[ethcommon.BytesToAddress([]byte(key))], err = governance.ContractFeesRecordFromBytes(value); err != nil {
+			return nil, err
+		}
+	}
+	return fees, nil
+}
@@ -14,4 +14,5 @@ type ChainNodeInfo struct {
 	AccessNodes    []*ChainNodeStatus
 	CandidateNodes []*ChainNodeStatus
 	CommitteeNodes []*ChainNodeStatus
+	EncryptionKey  []byte
 }
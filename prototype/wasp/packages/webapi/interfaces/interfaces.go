@@ -10,6 +10,7 @@ import (
 	"github.com/pangpanglabs/echoswagger/v2"
 
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/registry"
@@ -40,6 +41,8 @@ type ChainService interface {
 	GetEVMChainID(chainID isc.ChainID, blockIndexOrTrieRoot string) (uint16, error)
 	GetState(chainID isc.ChainID, stateKey []byte) (state []byte, err error)
 	WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error)
+	GetMempoolRequests(ctx context.Context, chainID isc.ChainID, after uint64, limit int) (*mempool.RequestsPage, error)
+	RemoveMempoolRequest(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID) error
 }
 
 type EVMService interface {
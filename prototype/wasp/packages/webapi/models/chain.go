@@ -43,6 +43,7 @@ type CommitteeInfoResponse struct {
 	CandidateNodes []CommitteeNode `json:"candidateNodes" swagger:"desc(A list of all candidate nodes and their peering info.),required"`
 	ChainID        string          `json:"chainId" swagger:"desc(ChainID (Bech32-encoded).),required"`
 	CommitteeNodes []CommitteeNode `json:"committeeNodes" swagger:"desc(A list of all committee nodes and their peering info.),required"`
+	EncryptionKey  string          `json:"encryptionKey" swagger:"desc(The shared BLS public key of the committee (Hex). The encrypted requests are encrypted to it.),required"`
 	StateAddress   string          `json:"stateAddress" swagger:"desc(State address, if we are part of it.),required"`
 }
 
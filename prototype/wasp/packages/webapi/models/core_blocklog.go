@@ -5,6 +5,7 @@ import (
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 )
 
@@ -15,14 +16,15 @@ type ControlAddressesResponse struct {
 }
 
 type BlockInfoResponse struct {
-	BlockIndex            uint32    `json:"blockIndex" swagger:"required,min(1)"`
-	Timestamp             time.Time `json:"timestamp" swagger:"required"`
-	TotalRequests         uint16    `json:"totalRequests" swagger:"required,min(1)"`
-	NumSuccessfulRequests uint16    `json:"numSuccessfulRequests" swagger:"required,min(1)"`
-	NumOffLedgerRequests  uint16    `json:"numOffLedgerRequests" swagger:"required,min(1)"`
-	PreviousAliasOutput   string    `json:"previousAliasOutput" swagger:"required,min(1)"`
-	GasBurned             string    `json:"gasBurned" swagger:"required,desc(The burned gas (uint64 as string))"`
-	GasFeeCharged         string    `json:"gasFeeCharged" swagger:"required,desc(The charged gas fee (uint64 as string))"`
+	BlockIndex            uint32       `json:"blockIndex" swagger:"required,min(1)"`
+	Timestamp             time.Time    `json:"timestamp" swagger:"required"`
+	TotalRequests         uint16       `json:"totalRequests" swagger:"required,min(1)"`
+	NumSuccessfulRequests uint16       `json:"numSuccessfulRequests" swagger:"required,min(1)"`
+	NumOffLedgerRequests  uint16       `json:"numOffLedgerRequests" swagger:"required,min(1)"`
+	PreviousAliasOutput   string       `json:"previousAliasOutput" swagger:"required,min(1)"`
+	GasBurned             string       `json:"gasBurned" swagger:"required,desc(The burned gas (uint64 as string))"`
+	GasFeeCharged         string       `json:"gasFeeCharged" swagger:"required,desc(The charged gas fee (uint64 as string))"`
+	GasPerToken           util.Ratio32 `json:"gasPerToken" swagger:"required,desc(The gas price of the block (A/B) (gas/token))"`
 }
 
 func MapBlockInfoResponse(info *blocklog.BlockInfo) *BlockInfoResponse {
@@ -41,6 +43,7 @@ func MapBlockInfoResponse(info *blocklog.BlockInfo) *BlockInfoResponse {
 		NumOffLedgerRequests:  info.NumOffLedgerRequests,
 		GasBurned:             iotago.EncodeUint64(info.GasBurned),
 		GasFeeCharged:         iotago.EncodeUint64(info.GasFeeCharged),
+		GasPerToken:           info.GasPerToken,
 	}
 }
 
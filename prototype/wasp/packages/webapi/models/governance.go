@@ -1,6 +1,11 @@
 package models
 
-import "github.com/iotaledger/wasp/packages/vm/gas"
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
 
 /*
 Both Gov* structs should be removed at some point.
@@ -31,3 +36,32 @@ type GovAllowedStateControllerAddressesResponse struct {
 type GovChainOwnerResponse struct {
 	ChainOwner string `json:"chainOwner" swagger:"desc(The chain owner (Bech32-encoded))"`
 }
+
+type ContractFees struct {
+	OwnerFee              string       `json:"ownerFee" swagger:"desc(The fixed fee paid to the chain owner for each request (uint64 as string)),required"`
+	ValidatorFee          string       `json:"validatorFee" swagger:"desc(The fixed fee paid to the validators for each request (uint64 as string)),required"`
+	NoGasFee              bool         `json:"noGasFee" swagger:"desc(Whether the gas is free),required"`
+	GasPerTokenMultiplier util.Ratio32 `json:"gasPerTokenMultiplier" swagger:"desc(The multiplier of the gas per token ratio of the chain (0:0 if not set)),required"`
+}
+
+func MapContractFees(fees *governance.ContractFeesRecord) *ContractFees {
+	if fees == nil {
+		return nil
+	}
+	return &ContractFees{
+		OwnerFee:              iotago.EncodeUint64(fees.OwnerFee),
+		ValidatorFee:          iotago.EncodeUint64(fees.ValidatorFee),
+		NoGasFee:              fees.NoGasFee,
+		GasPerTokenMultiplier: fees.GasPerTokenMultiplier,
+	}
+}
+
+type GovContractFeesResponse struct {
+	ContractHName string        `json:"contractHName" swagger:"desc(The contract name as HName (Hex)),required"`
+	Fees          *ContractFees `json:"fees" swagger:"desc(The fee overrides of the contract),required"`
+}
+
+type GovEVMContractFeesResponse struct {
+	EVMAddress string        `json:"evmAddress" swagger:"desc(The address of the EVM contract (Hex)),required"`
+	Fees       *ContractFees `json:"fees" swagger:"desc(The fee overrides of the contract),required"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+const (
+	MempoolRequestTypeOnLedger  = "onLedger"
+	MempoolRequestTypeOffLedger = "offLedger"
+	MempoolRequestTypeEVM       = "evm"
+)
+
+type MempoolInfoResponse struct {
+	ChainID        string `json:"chainId" swagger:"desc(ChainID (Bech32-encoded).),required"`
+	TotalPool      uint32 `json:"totalPool" swagger:"desc(The number of requests in all the pools),required,min(0)"`
+	OnLedgerPool   uint32 `json:"onLedgerPool" swagger:"desc(The number of on-ledger requests ready to be processed),required,min(0)"`
+	OffLedgerPool  uint32 `json:"offLedgerPool" swagger:"desc(The number of off-ledger requests),required,min(0)"`
+	TimeLockedPool uint32 `json:"timeLockedPool" swagger:"desc(The number of on-ledger requests waiting for their time lock to expire),required,min(0)"`
+}
+
+func MapMempoolInfoResponse(chainID isc.ChainID, poolSizes map[string]int) *MempoolInfoResponse {
+	res := &MempoolInfoResponse{
+		ChainID:        chainID.String(),
+		OnLedgerPool:   uint32(poolSizes[mempool.PoolOnLedger]),
+		OffLedgerPool:  uint32(poolSizes[mempool.PoolOffLedger]),
+		TimeLockedPool: uint32(poolSizes[mempool.PoolTimeLocked]),
+	}
+	res.TotalPool = res.OnLedgerPool + res.OffLedgerPool + res.TimeLockedPool
+	return res
+}
+
+type MempoolRequest struct {
+	RequestID string `json:"requestId" swagger:"desc(The request ID),required"`
+	Seq       string `json:"seq" swagger:"desc(The position of the request in the mempool (uint64 as string), increasing in the order the requests were added),required"`
+	Pool      string `json:"pool" swagger:"desc(The pool containing the request: onLedger / offLedger / timeLocked),required"`
+	Type      string `json:"type" swagger:"desc(The type of the request: onLedger / offLedger / evm),required"`
+	Sender    string `json:"sender" swagger:"desc(The sender of the request (AgentID) or empty if unknown),required"`
+	Nonce     string `json:"nonce" swagger:"desc(The nonce of an off-ledger request (uint64 as string) or empty for on-ledger requests),required"`
+	GasBudget string `json:"gasBudget" swagger:"desc(The gas budget of the request (uint64 as string)),required"`
+	Age       uint32 `json:"age" swagger:"desc(The number of seconds the request is in the pool; 0 for the time-locked requests),required,min(0)"`
+}
+
+func MapMempoolRequest(req *mempool.PoolRequest, now time.Time) MempoolRequest {
+	gasBudget, isEVM := req.Request.GasBudget()
+	res := MempoolRequest{
+		RequestID: req.Request.ID().String(),
+		Seq:       iotago.EncodeUint64(req.Seq),
+		Pool:      req.Pool,
+		Type:      MempoolRequestTypeOnLedger,
+		GasBudget: iotago.EncodeUint64(gasBudget),
+	}
+	if sender := req.Request.SenderAccount(); sender != nil {
+		res.Sender = sender.String()
+	}
+	if offLedgerReq, ok := req.Request.(isc.OffLedgerRequest); ok {
+		res.Type = MempoolRequestTypeOffLedger
+		if isEVM {
+			res.Type = MempoolRequestTypeEVM
+		}
+		res.Nonce = iotago.EncodeUint64(offLedgerReq.Nonce())
+	}
+	if req.Pool != mempool.PoolTimeLocked && now.After(req.Timestamp) {
+		res.Age = uint32(now.Sub(req.Timestamp) / time.Second)
+	}
+	return res
+}
+
+type MempoolRequestsResponse struct {
+	Total    uint32           `json:"total" swagger:"desc(The number of requests in the mempool),required,min(0)"`
+	Next     string           `json:"next" swagger:"desc(The seq to pass as the after parameter to get the next page, or empty if this is the last page),required"`
+	Requests []MempoolRequest `json:"requests" swagger:"desc(The requests, in the order they were added to the mempool),required"`
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
@@ -86,6 +87,7 @@ type ReceiptResponse struct {
 	BlockIndex    uint32                     `json:"blockIndex" swagger:"required,min(1)"`
 	RequestIndex  uint16                     `json:"requestIndex" swagger:"required,min(1)"`
 	GasBurnLog    []gas.BurnRecord           `json:"gasBurnLog" swagger:"required"`
+	ContractFees  *ContractFees              `json:"contractFees,omitempty" swagger:"desc(The fee overrides of the target contract applied to the request)"`
 }
 
 func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
@@ -100,6 +102,14 @@ func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
 		panic(err)
 	}
 
+	var contractFees *governance.ContractFeesRecord
+	if receipt.ContractFees != nil {
+		contractFees, err = governance.ContractFeesRecordFromBytes(receipt.ContractFees)
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	return &ReceiptResponse{
 		Request:       mapRequestDetail(req),
 		RawError:      receipt.Error.ToJSONStruct(),
@@ -111,5 +121,6 @@ func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
 		GasFeeCharged: iotago.EncodeUint64(receipt.GasFeeCharged),
 		SDCharged:     iotago.EncodeUint64(receipt.SDCharged),
 		GasBurnLog:    burnRecords,
+		ContractFees:  MapContractFees(contractFees),
 	}
 }
@@ -1,12 +1,14 @@
 package params
 
 const (
+	ParamAfter                = "after"
 	ParamAgentID              = "agentID"
 	ParamBlobHash             = "blobHash"
 	ParamBlockIndex           = "blockIndex"
 	ParamChainID              = "chainID"
 	ParamContractHName        = "contractHname"
 	ParamFieldKey             = "fieldKey"
+	ParamLimit                = "limit"
 	ParamNFTID                = "nftID"
 	ParamPeer                 = "peer"
 	ParamPublicKey            = "publicKey"
@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotaledger/hive.go/logger"
 	chainpkg "github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chains"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
@@ -226,3 +227,30 @@ func (c *ChainService) WaitForRequestProcessed(ctx context.Context, chainID isc.
 		return nil, apierrors.Timeout("timeout while waiting for request to be processed")
 	}
 }
+
+// GetMempoolRequests returns a page of the requests in the mempool, in the
+// order they were added, see mempool.Mempool.RequestsAsync.
+func (c *ChainService) GetMempoolRequests(ctx context.Context, chainID isc.ChainID, after uint64, limit int) (*mempool.RequestsPage, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := ch.MempoolRequests(ctx, after, limit)
+	if page == nil {
+		return nil, apierrors.Timeout("timeout while reading the mempool")
+	}
+	return page, nil
+}
+
+func (c *ChainService) RemoveMempoolRequest(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID) error {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return err
+	}
+
+	if !ch.MempoolRemoveRequest(ctx, requestID) {
+		return apierrors.RequestNotInMempoolError(requestID.String())
+	}
+	return nil
+}
@@ -74,11 +74,17 @@ func (c *CommitteeService) GetCommitteeInfo(chainID isc.ChainID) (*dto.ChainNode
 		return nil, err
 	}
 
+	encryptionKey, err := dkShare.BLSSharedPublic().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
 	chainNodeInfo := dto.ChainNodeInfo{
 		Address:        committeeInfo.Address,
 		AccessNodes:    accessNodes,
 		CandidateNodes: filteredCandidateNodes,
 		CommitteeNodes: committeeNodes,
+		EncryptionKey:  encryptionKey,
 	}
 
 	return &chainNodeInfo, nil
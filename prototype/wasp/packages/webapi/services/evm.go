@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"net/http"
 	"sync"
 
@@ -25,6 +26,7 @@ type chainServer struct {
 }
 
 type EVMService struct {
+	ctx             context.Context
 	evmBackendMutex sync.Mutex
 	evmChainServers map[isc.ChainID]*chainServer
 
@@ -39,6 +41,7 @@ type EVMService struct {
 }
 
 func NewEVMService(
+	ctx context.Context,
 	chainsProvider chains.Provider,
 	chainService interfaces.ChainService,
 	networkProvider peering.NetworkProvider,
@@ -49,6 +52,7 @@ func NewEVMService(
 	log *logger.Logger,
 ) interfaces.EVMService {
 	return &EVMService{
+		ctx:             ctx,
 		chainsProvider:  chainsProvider,
 		chainService:    chainService,
 		evmChainServers: map[isc.ChainID]*chainServer{},
@@ -79,7 +83,7 @@ func (e *EVMService) getEVMBackend(chainID isc.ChainID) (*chainServer, error) {
 	backend := jsonrpc.NewWaspEVMBackend(chain, nodePubKey, parameters.L1().BaseToken)
 
 	srv, err := jsonrpc.NewServer(
-		jsonrpc.NewEVMChain(backend, e.publisher, e.chainsProvider().IsArchiveNode(), hivedb.EngineRocksDB, e.indexDbPath, e.log.Named("EVMChain")),
+		jsonrpc.NewEVMChain(e.ctx, backend, e.publisher, e.chainsProvider().IsArchiveNode(), e.jsonrpcParams.IndexAddresses, hivedb.EngineRocksDB, e.indexDbPath, e.log.Named("EVMChain")),
 		jsonrpc.NewAccountManager(nil),
 		e.metrics.GetChainMetrics(chainID).WebAPI,
 		e.jsonrpcParams,
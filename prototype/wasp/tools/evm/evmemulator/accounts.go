@@ -0,0 +1,93 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/iotaledger/wasp/packages/solo"
+)
+
+// ethereumAccounts returns the private keys of the test accounts: either
+// derived from the BIP-39 mnemonic with the default Ethereum derivation path
+// (m/44'/60'/0'/0/i, same as Hardhat and Metamask), or the deterministic Solo
+// accounts if no mnemonic is given.
+func ethereumAccounts(n int, mnemonic string) ([]*ecdsa.PrivateKey, error) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	if mnemonic == "" {
+		for i := range keys {
+			keys[i] = solo.EthereumAccountKey(i)
+		}
+		return keys, nil
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	next := accounts.DefaultIterator(accounts.DefaultBaseDerivationPath)
+	for i := range keys {
+		var err error
+		keys[i], err = deriveKey(seed, next())
+		if err != nil {
+			return nil, fmt.Errorf("cannot derive account #%d: %w", i, err)
+		}
+	}
+	return keys, nil
+}
+
+// deriveKey derives the secp256k1 private key at the given path from the seed,
+// as specified in BIP-32
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	key, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			// hardened child: 0x00 || private key || index
+			data = append([]byte{0}, key...)
+		} else {
+			// normal child: compressed public key || index
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		var tweak []byte
+		tweak, chainCode = hmacSHA512(chainCode, data)
+		childKey := new(big.Int).SetBytes(tweak)
+		if childKey.Cmp(curveOrder) >= 0 {
+			return nil, errors.New("invalid child key")
+		}
+		childKey.Add(childKey, new(big.Int).SetBytes(key))
+		childKey.Mod(childKey, curveOrder)
+		if childKey.Sign() == 0 {
+			return nil, errors.New("invalid child key")
+		}
+		key = math.PaddedBigBytes(childKey, 32)
+	}
+	return crypto.ToECDSA(key)
+}
+
+func hmacSHA512(key, data []byte) (left, right []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumAccountsFromMnemonic(t *testing.T) {
+	// the default accounts of Hardhat
+	keys, err := ethereumAccounts(2, "test test test test test test test test test test test junk")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", crypto.PubkeyToAddress(keys[0].PublicKey).Hex())
+	require.Equal(t, "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", hex.EncodeToString(crypto.FromECDSA(keys[0])))
+	require.Equal(t, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", crypto.PubkeyToAddress(keys[1].PublicKey).Hex())
+	require.Equal(t, "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d", hex.EncodeToString(crypto.FromECDSA(keys[1])))
+
+	_, err = ethereumAccounts(2, "test test test")
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
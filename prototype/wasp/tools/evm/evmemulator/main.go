@@ -4,16 +4,25 @@
 package main
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/iotaledger/wasp/components/app"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/codec"
@@ -22,7 +31,10 @@ import (
 	"github.com/iotaledger/wasp/packages/origin"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/emulator"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 )
 
@@ -58,6 +70,35 @@ func (*soloContext) Name() string {
 	return "evmemulator"
 }
 
+const chainName = "evmemulator"
+
+const (
+	miningAutomine = "automine"
+	miningInterval = "interval"
+	miningManual   = "manual"
+)
+
+var (
+	listenAddr  string
+	dbPath      string
+	forkFrom    string
+	forkChainID string
+
+	evmChainID     uint16
+	numAccounts    int
+	accountBalance uint64
+	mnemonic       string
+
+	maxGasPerBlock    uint64
+	maxGasPerRequest  uint64
+	gasPerToken       string
+	evmGasRatio       string
+	validatorFeeShare uint8
+
+	miningMode   string
+	miningPeriod time.Duration
+)
+
 func init() {
 	parameters.InitL1(parameters.L1ForTesting)
 }
@@ -73,17 +114,48 @@ func main() {
 evmemulator does the following:
 
 - Starts an ISC chain in a Solo environment
-- Initializes 10 ethereum accounts with funds (private keys and addresses printed after init)
+- Initializes ethereum accounts with funds (private keys and addresses printed after init)
 - Starts a JSONRPC server
 
+The chain, the accounts and the block production can be configured with flags.
+By default each transaction is included in its own block as soon as it is
+received (--mining automine). With --mining interval, the transactions are
+batched in a block every --mining-interval, as in a real committee; with
+--mining manual, blocks are only produced with evm_mine.
+
 You can connect any Ethereum tool (eg Metamask) to this JSON-RPC server and use it for testing Ethereum contracts.
 
-Note: chain data is stored in-memory and will be lost upon termination.
+The Hardhat-compatible evm_* and hardhat_* methods can be used to control the chain (see README.md).
+
+Note: by default, chain data is stored in-memory and will be lost upon termination.
+Use --db <dir> to store it on disk instead; restarting evmemulator with the same
+directory resumes the chain from the last block.
+
+Use --fork <file or URL> to start the chain from a snapshot of an existing chain
+(a .snap file produced by a Wasp node) instead of deploying a new one.
 `,
 		),
 	}
 
 	log.Init(cmd)
+	cmd.PersistentFlags().StringVar(&listenAddr, "addr", ":8545", "address where the JSONRPC server listens")
+	cmd.PersistentFlags().StringVar(&dbPath, "db", "", "directory where the chain data is stored (in-memory if empty)")
+	cmd.PersistentFlags().StringVar(&forkFrom, "fork", "", "path or URL of a snapshot (.snap) of the chain to fork")
+	cmd.PersistentFlags().StringVar(&forkChainID, "fork-chain-id", "", "chain ID of the forked chain (by default, the name of the directory containing the snapshot)")
+
+	cmd.PersistentFlags().Uint16Var(&evmChainID, "chain-id", 1074, "EVM chain ID")
+	cmd.PersistentFlags().IntVar(&numAccounts, "accounts", len(solo.EthereumAccounts), "number of funded ethereum accounts")
+	cmd.PersistentFlags().Uint64Var(&accountBalance, "balance", utxodb.FundsFromFaucetAmount, "initial balance of each account, in base tokens")
+	cmd.PersistentFlags().StringVar(&mnemonic, "mnemonic", "", "BIP-39 mnemonic used to derive the accounts (m/44'/60'/0'/0/i)")
+
+	cmd.PersistentFlags().Uint64Var(&maxGasPerBlock, "max-gas-per-block", gas.LimitsDefault.MaxGasPerBlock, "maximum ISC gas per block")
+	cmd.PersistentFlags().Uint64Var(&maxGasPerRequest, "max-gas-per-request", gas.LimitsDefault.MaxGasPerRequest, "maximum ISC gas per request")
+	cmd.PersistentFlags().StringVar(&gasPerToken, "gas-per-token", gas.DefaultGasPerToken.String(), "gas per token ratio (format: a:b)")
+	cmd.PersistentFlags().StringVar(&evmGasRatio, "evm-gas-ratio", gas.DefaultEVMGasRatio.String(), "ISC gas to EVM gas ratio (format: a:b)")
+	cmd.PersistentFlags().Uint8Var(&validatorFeeShare, "validator-fee-share", 0, "validator fee share (between 0 and 100)")
+
+	cmd.PersistentFlags().StringVar(&miningMode, "mining", miningAutomine, "block production mode: automine, interval or manual")
+	cmd.PersistentFlags().DurationVar(&miningPeriod, "mining-interval", 2*time.Second, "interval between blocks in interval mining mode")
 
 	err := cmd.Execute()
 	log.Check(err)
@@ -97,40 +169,160 @@ func start(cmd *cobra.Command, args []string) {
 		}
 	}()
 
-	env := solo.New(ctx, &solo.InitOptions{Debug: log.DebugFlag, PrintStackTrace: log.DebugFlag})
+	if miningMode != miningAutomine && miningMode != miningInterval && miningMode != miningManual {
+		log.Fatalf("invalid mining mode: %s", miningMode)
+	}
+	keys, err := ethereumAccounts(numAccounts, mnemonic)
+	log.Check(err)
 
-	chainOwner, chainOwnerAddr := env.NewKeyPairWithFunds()
-	chain, _ := env.NewChainExt(chainOwner, 1*isc.Million, "evmemulator", dict.Dict{
-		origin.ParamChainOwner:      isc.NewAgentID(chainOwnerAddr).Bytes(),
-		origin.ParamEVMChainID:      codec.EncodeUint16(1074),
-		origin.ParamBlockKeepAmount: codec.EncodeInt32(emulator.BlockKeepAll),
-		origin.ParamWaspVersion:     codec.EncodeString(app.Version),
-	})
+	env := solo.New(ctx, &solo.InitOptions{Debug: log.DebugFlag, PrintStackTrace: log.DebugFlag, DBPath: dbPath})
+
+	chain, ok := env.ChainByName(chainName)
+	switch {
+	case ok:
+		if forkFrom != "" {
+			log.Printf("ignoring --fork, the chain is already stored in %s\n", dbPath)
+		}
+		log.Printf("resuming chain from %s at block #%d\n", dbPath, chain.LatestBlockIndex())
+		warnIgnoredChainFlags(cmd)
+		warnIgnoredAccountFlags(cmd)
+	case forkFrom != "":
+		chain = forkChain(env)
+		warnIgnoredChainFlags(cmd)
+		fundAccounts(chain, keys)
+	default:
+		chain = deployChain(env)
+		fundAccounts(chain, keys)
+	}
 
-	var accounts []*ecdsa.PrivateKey
-	log.Printf("creating accounts with funds...\n")
 	header := []string{"private key", "address"}
 	var rows [][]string
-	for i := 0; i < len(solo.EthereumAccounts); i++ {
-		pk, addr := chain.EthereumAccountByIndexWithL2Funds(i)
-		accounts = append(accounts, pk)
-		rows = append(rows, []string{hex.EncodeToString(crypto.FromECDSA(pk)), addr.String()})
+	for _, key := range keys {
+		rows = append(rows, []string{hex.EncodeToString(crypto.FromECDSA(key)), crypto.PubkeyToAddress(key.PublicKey).String()})
 	}
 	log.PrintTable(header, rows)
 
+	switch miningMode {
+	case miningInterval:
+		chain.SetAutomine(false)
+		chain.SetIntervalMining(miningPeriod)
+	case miningManual:
+		chain.SetAutomine(false)
+	}
+
+	evmChain := chain.EVM()
+	accountManager := jsonrpc.NewAccountManager(keys)
 	srv, err := jsonrpc.NewServer(
-		chain.EVM(),
-		jsonrpc.NewAccountManager(accounts),
+		evmChain,
+		accountManager,
 		metrics.NewChainWebAPIMetricsProvider().CreateForChain(chain.ChainID),
+		jsonrpc.ParametersDefault(),
 	)
 	log.Check(err)
+	log.Check(jsonrpc.RegisterDevServices(srv, evmChain, accountManager))
 
-	const addr = ":8545"
 	s := &http.Server{
-		Addr:    addr,
+		Addr:    listenAddr,
 		Handler: srv,
 	}
-	log.Printf("starting JSONRPC server on %s...\n", addr)
+
+	// shut down gracefully, so that the chain data is flushed to disk
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		log.Printf("shutting down...\n")
+		_ = s.Shutdown(context.Background())
+	}()
+
+	log.Printf("starting JSONRPC server on %s (mining: %s)...\n", listenAddr, miningMode)
 	err = s.ListenAndServe()
+	if !errors.Is(err, http.ErrServerClosed) {
+		log.Check(err)
+	}
+}
+
+// forkChain creates the EVM chain from the snapshot given in --fork
+func forkChain(env *solo.Solo) *solo.Chain {
+	if forkChainID == "" {
+		// snapshots are stored by the nodes in <chainID>/<index>-<blockhash>.snap
+		forkChainID = path.Base(path.Dir(forkFrom))
+	}
+	chainID, err := isc.ChainIDFromString(forkChainID)
+	log.Check(err)
+
+	var snapshot io.ReadCloser
+	if strings.HasPrefix(forkFrom, "http://") || strings.HasPrefix(forkFrom, "https://") {
+		snapshot, err = sm_snapshots.NewDownloader(context.Background(), forkFrom)
+	} else {
+		snapshot, err = os.Open(forkFrom)
+	}
+	log.Check(err)
+	defer snapshot.Close()
+
+	log.Printf("forking chain %s from %s...\n", chainID, forkFrom)
+	chain := env.ForkChain(chainName, chainID, snapshot)
+	log.Printf("forked at block #%d\n", chain.LatestBlockIndex())
+	return chain
+}
+
+// deployChain deploys the EVM chain, with the gas limits and fee policy given
+// in the flags
+func deployChain(env *solo.Solo) *solo.Chain {
+	chainOwner, chainOwnerAddr := env.NewKeyPairWithFunds()
+	chain, _ := env.NewChainExt(chainOwner, 1*isc.Million, chainName, dict.Dict{
+		origin.ParamChainOwner:      isc.NewAgentID(chainOwnerAddr).Bytes(),
+		origin.ParamEVMChainID:      codec.EncodeUint16(evmChainID),
+		origin.ParamBlockKeepAmount: codec.EncodeInt32(emulator.BlockKeepAll),
+		origin.ParamWaspVersion:     codec.EncodeString(app.Version),
+	})
+
+	gasLimits := *gas.LimitsDefault
+	gasLimits.MaxGasPerBlock = maxGasPerBlock
+	gasLimits.MaxGasPerRequest = maxGasPerRequest
+	feePolicy := gas.DefaultFeePolicy()
+	var err error
+	feePolicy.GasPerToken, err = util.Ratio32FromString(gasPerToken)
+	log.Check(err)
+	feePolicy.EVMGasRatio, err = util.Ratio32FromString(evmGasRatio)
 	log.Check(err)
+	feePolicy.ValidatorFeeShare = validatorFeeShare
+	if gasLimits != *gas.LimitsDefault || *feePolicy != *gas.DefaultFeePolicy() {
+		// the chain owner pays for the governance requests
+		chain.MustDepositBaseTokensToL2(isc.Million, chainOwner)
+		chain.SetGasLimits(chainOwner, &gasLimits)
+		chain.SetGasFeePolicy(chainOwner, feePolicy)
+	}
+	return chain
+}
+
+// warnIgnoredChainFlags warns about the flags that only apply when deploying
+// a new chain
+func warnIgnoredChainFlags(cmd *cobra.Command) {
+	for _, flag := range []string{"chain-id", "max-gas-per-block", "max-gas-per-request", "gas-per-token", "evm-gas-ratio", "validator-fee-share"} {
+		if cmd.Flags().Changed(flag) {
+			log.Printf("ignoring --%s, which only applies to new chains\n", flag)
+		}
+	}
+}
+
+// warnIgnoredAccountFlags warns about the flags that configure the funded
+// accounts, which are only funded when the chain is created or forked. The
+// accounts are still available for signing.
+func warnIgnoredAccountFlags(cmd *cobra.Command) {
+	for _, flag := range []string{"accounts", "balance", "mnemonic"} {
+		if cmd.Flags().Changed(flag) {
+			log.Printf("--%s does not fund the accounts of a resumed chain\n", flag)
+		}
+	}
+}
+
+// fundAccounts sets the L2 balance of the ethereum accounts
+func fundAccounts(chain *solo.Chain, keys []*ecdsa.PrivateKey) {
+	log.Printf("creating accounts with funds...\n")
+	balance := util.BaseTokensDecimalsToEthereumDecimals(accountBalance, parameters.L1().BaseToken.Decimals)
+	for _, key := range keys {
+		err := chain.EVMSetBalance(crypto.PubkeyToAddress(key.PublicKey), balance)
+		log.Check(err)
+	}
 }
@@ -47,10 +47,13 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(initRotateWithDKGCmd())
 	chainCmd.AddCommand(initChangeAccessNodesCmd())
 	chainCmd.AddCommand(initDisableFeePolicyCmd())
+	chainCmd.AddCommand(initSetContractFeesCmd())
+	chainCmd.AddCommand(initSetBaseFeeConfigCmd())
 	chainCmd.AddCommand(initPermissionlessAccessNodesCmd())
 	chainCmd.AddCommand(initAddChainCmd())
 	chainCmd.AddCommand(initRegisterERC20NativeTokenCmd())
 	chainCmd.AddCommand(initRegisterERC20NativeTokenOnRemoteChainCmd())
 	chainCmd.AddCommand(initCreateFoundryCmd())
 	chainCmd.AddCommand(initMetadataCmd())
+	chainCmd.AddCommand(initMempoolCmd())
 }
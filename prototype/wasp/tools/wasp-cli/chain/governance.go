@@ -6,12 +6,15 @@ package chain
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
 	"github.com/iotaledger/wasp/clients/apiclient"
 	"github.com/iotaledger/wasp/clients/apiextensions"
 	"github.com/iotaledger/wasp/clients/chainclient"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
@@ -130,3 +133,100 @@ func initDisableFeePolicyCmd() *cobra.Command {
 
 	return cmd
 }
+
+func initSetContractFeesCmd() *cobra.Command {
+	var offLedger bool
+	var node string
+	var chain string
+	var remove bool
+	var evmAddress string
+	fees := &governance.ContractFeesRecord{}
+
+	cmd := &cobra.Command{
+		Use:   "set-contract-fees [<contract name>]",
+		Short: "Overrides the fee policy of the chain for the requests sent to a contract.",
+		Long:  "Overrides the fee policy of the chain for the requests sent to an ISC contract, given by name, or to an EVM contract, given with --evm-address.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			params := dict.Dict{}
+			switch {
+			case evmAddress != "" && len(args) == 0:
+				if !common.IsHexAddress(evmAddress) {
+					log.Fatalf("invalid EVM address: %s", evmAddress)
+				}
+				params[governance.ParamEVMContractAddress] = common.HexToAddress(evmAddress).Bytes()
+			case evmAddress == "" && len(args) == 1:
+				params[governance.ParamContractHname] = codec.EncodeHname(isc.Hn(args[0]))
+			default:
+				log.Fatalf("either a contract name or --evm-address must be given")
+			}
+			if !remove {
+				params[governance.ParamContractFeesBytes] = fees.Bytes()
+			}
+			postRequest(
+				node,
+				chain,
+				governance.Contract.Name,
+				governance.FuncSetContractFees.Name,
+				chainclient.PostRequestParams{Args: params},
+				offLedger,
+				true)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
+		"post an off-ledger request",
+	)
+	cmd.Flags().Uint64Var(&fees.OwnerFee, "owner-fee", 0, "fixed fee paid to the chain owner for each request")
+	cmd.Flags().Uint64Var(&fees.ValidatorFee, "validator-fee", 0, "fixed fee paid to the validators for each request")
+	cmd.Flags().BoolVar(&fees.NoGasFee, "no-gas-fee", false, "make the gas free")
+	cmd.Flags().Var(&fees.GasPerTokenMultiplier, "gas-per-token-multiplier", "multiplier of the gas per token ratio of the chain (e.g. 2:1 makes the gas twice as cheap)")
+	cmd.Flags().BoolVar(&remove, "remove", false, "remove the overrides, so that the fee policy of the chain applies")
+	cmd.Flags().StringVar(&evmAddress, "evm-address", "", "address of the EVM contract (0x...), instead of the name of an ISC contract")
+
+	return cmd
+}
+
+func initSetBaseFeeConfigCmd() *cobra.Command {
+	var offLedger bool
+	var node string
+	var chain string
+	config := gas.DefaultBaseFeeConfig()
+
+	cmd := &cobra.Command{
+		Use:   "set-base-fee-config",
+		Short: "Configures the dynamic adjustment of the gas price of the chain.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			postRequest(
+				node,
+				chain,
+				governance.Contract.Name,
+				governance.FuncSetBaseFeeConfig.Name,
+				chainclient.PostRequestParams{
+					Args: dict.Dict{governance.ParamBaseFeeConfigBytes: config.Bytes()},
+				},
+				offLedger,
+				true)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
+		"post an off-ledger request",
+	)
+	cmd.Flags().BoolVar(&config.Enabled, "enabled", true, "adjust the gas price of each block from the gas burned in the previous one")
+	cmd.Flags().Uint64Var(&config.TargetGasPerBlock, "target-gas-per-block", config.TargetGasPerBlock, "gas burned per block for which the gas price stays the same")
+	cmd.Flags().Uint32Var(&config.MaxChangeDenominator, "max-change-denominator", config.MaxChangeDenominator, "the gas price changes at most by 1/denominator per block")
+
+	return cmd
+}
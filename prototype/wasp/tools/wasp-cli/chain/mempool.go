@@ -0,0 +1,167 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initMempoolCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mempool <command>",
+		Short: "Inspect the mempool of a chain on the target node",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+	cmd.AddCommand(initMempoolInfoCmd())
+	cmd.AddCommand(initMempoolListCmd())
+	cmd.AddCommand(initMempoolRemoveCmd())
+	return cmd
+}
+
+func initMempoolInfoCmd() *cobra.Command {
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the number of requests in the mempool",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+
+			info, _, err := client.ChainsApi.GetMempoolInfo(context.Background(), chainID.String()).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.PrintCLIOutput(&MempoolInfoModel{
+				ChainID:        info.ChainId,
+				TotalPool:      info.TotalPool,
+				OnLedgerPool:   info.OnLedgerPool,
+				OffLedgerPool:  info.OffLedgerPool,
+				TimeLockedPool: info.TimeLockedPool,
+			})
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	return cmd
+}
+
+type MempoolInfoModel struct {
+	ChainID        string
+	TotalPool      uint32
+	OnLedgerPool   uint32
+	OffLedgerPool  uint32
+	TimeLockedPool uint32
+}
+
+var _ log.CLIOutput = &MempoolInfoModel{}
+
+func (m *MempoolInfoModel) AsText() (string, error) {
+	template := `Mempool of the chain {{ .ChainID }}:
+Total requests: {{ .TotalPool }}
+On-ledger: {{ .OnLedgerPool }}
+Off-ledger: {{ .OffLedgerPool }}
+Time-locked: {{ .TimeLockedPool }}`
+	return log.ParseCLIOutputTemplate(m, template)
+}
+
+func initMempoolListCmd() *cobra.Command {
+	var node string
+	var chain string
+	var after string
+	var limit int32
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the requests in the mempool, in the order they were added",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+
+			res, _, err := client.ChainsApi.
+				GetMempoolRequests(context.Background(), chainID.String()).
+				After(after).
+				Limit(limit).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("Total %d request(s) in the mempool, showing %d\n", res.Total, len(res.Requests))
+			showMempoolRequests(res.Requests)
+			if res.Next != "" {
+				log.Printf("More requests: use --after %s to show the next page\n", res.Next)
+			}
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().StringVar(&after, "after", "", "seq of the last request of the previous page")
+	cmd.Flags().Int32Var(&limit, "limit", 100, "maximal number of requests to show (at most 1000)")
+	return cmd
+}
+
+func showMempoolRequests(requests []apiclient.MempoolRequest) {
+	header := []string{"seq", "request id", "pool", "type", "sender", "nonce", "gas budget", "age"}
+	rows := make([][]string, len(requests))
+	for i, req := range requests {
+		rows[i] = []string{
+			req.Seq,
+			req.RequestId,
+			req.Pool,
+			req.Type,
+			req.Sender,
+			req.Nonce,
+			req.GasBudget,
+			fmt.Sprintf("%ds", req.Age),
+		}
+	}
+	log.PrintTable(header, rows)
+}
+
+func initMempoolRemoveCmd() *cobra.Command {
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "remove <request id>",
+		Short: "Remove a request from the mempool (requires the write permission)",
+		Long:  "Remove a request from the mempool (requires the write permission). A removed on-ledger request stays unconsumed on L1, so it is read into the mempool again after a restart of the node.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+
+			_, err := client.ChainsApi.
+				RemoveMempoolRequest(context.Background(), chainID.String(), args[0]).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+			log.Printf("removed request %s from the mempool\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	return cmd
+}